@@ -0,0 +1,131 @@
+package cloudflare
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessRule is a single Access rule, an object whose only key is the kind
+// of the rule, e.g. {"email": {"email": "test@example.com"}}.
+type accessRule map[string]interface{}
+
+// accessRulesSchema is the schema of the include, exclude and require rules
+// of an Access policy. A block groups rules by kind, with a list of values
+// for the kinds that take one.
+func accessRulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"everyone": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"certificate": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"email": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"email_domain": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"ip": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateCIDR,
+					},
+				},
+				"group": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"geo": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// accessRuleFields maps the list fields of accessRulesSchema to the kind of
+// rule they make and the key of the rule's value.
+var accessRuleFields = []struct {
+	field, kind, key string
+}{
+	{"email", "email", "email"},
+	{"email_domain", "email_domain", "domain"},
+	{"ip", "ip", "ip"},
+	{"group", "group", "id"},
+	{"geo", "geo", "country_code"},
+}
+
+// expandAccessRules turns the blocks of an include, exclude or require
+// argument into the rules the API expects.
+func expandAccessRules(v interface{}) []accessRule {
+	rules := []accessRule{}
+	for _, block := range v.([]interface{}) {
+		m, ok := block.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if m["everyone"].(bool) {
+			rules = append(rules, accessRule{"everyone": map[string]interface{}{}})
+		}
+		if m["certificate"].(bool) {
+			rules = append(rules, accessRule{"certificate": map[string]interface{}{}})
+		}
+		for _, f := range accessRuleFields {
+			for _, value := range m[f.field].([]interface{}) {
+				rules = append(rules, accessRule{f.kind: map[string]interface{}{f.key: value.(string)}})
+			}
+		}
+	}
+	return rules
+}
+
+// flattenAccessRules groups rules read from the API back into a single
+// block. Rules of kinds the schema doesn't model are ignored.
+func flattenAccessRules(rules []accessRule) []interface{} {
+	if len(rules) == 0 {
+		return []interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"everyone":    false,
+		"certificate": false,
+	}
+	values := make(map[string][]interface{})
+	for _, rule := range rules {
+		if _, ok := rule["everyone"]; ok {
+			m["everyone"] = true
+		}
+		if _, ok := rule["certificate"]; ok {
+			m["certificate"] = true
+		}
+		for _, f := range accessRuleFields {
+			if r, ok := rule[f.kind].(map[string]interface{}); ok {
+				values[f.field] = append(values[f.field], r[f.key])
+			}
+		}
+	}
+	for _, f := range accessRuleFields {
+		if values[f.field] == nil {
+			values[f.field] = []interface{}{}
+		}
+		m[f.field] = values[f.field]
+	}
+
+	return []interface{}{m}
+}
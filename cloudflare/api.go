@@ -0,0 +1,219 @@
+package cloudflare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/deliveroo/terraform-provider-cloudflare/version"
+)
+
+// The vendored cloudflare-go client only covers a handful of endpoints.
+// Resources built on the rest of the v4 API go through apiRequest, which
+// speaks the same response envelope using the client's credentials.
+
+// apiResponse is the envelope every v4 API response is wrapped in.
+type apiResponse struct {
+	cloudflare.Response
+	Result     json.RawMessage       `json:"result"`
+	ResultInfo cloudflare.ResultInfo `json:"result_info"`
+}
+
+// apiError is returned by apiRequest when the API reports a failure. It
+// carries the Ray ID of the response, which Cloudflare support asks for when
+// investigating a problem.
+type apiError struct {
+	StatusCode int
+	Errors     []cloudflare.ResponseInfo
+	RayID      string
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("HTTP status %d", e.StatusCode)
+	if len(e.Errors) > 0 {
+		messages := make([]string, 0, len(e.Errors))
+		for _, info := range e.Errors {
+			messages = append(messages, fmt.Sprintf("%s (%d)", info.Message, info.Code))
+		}
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(messages, ", "))
+	}
+
+	if e.RayID != "" {
+		msg = fmt.Sprintf("%s (Ray ID: %s)", msg, e.RayID)
+	}
+	return msg
+}
+
+// apiRequest makes a request against the v4 API and decodes the "result"
+// member of the response into result, if given. params are serialized to
+// JSON.
+func (client *CloudFlareClient) apiRequest(method, uri string, params, result interface{}) error {
+	var body io.Reader
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("error marshalling params to JSON: %s", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	return client.apiRequestWithBody(method, uri, "application/json", body, result)
+}
+
+// apiRequestWithBody is apiRequest for bodies that aren't JSON, such as
+// multipart uploads.
+func (client *CloudFlareClient) apiRequestWithBody(method, uri, contentType string, body io.Reader, result interface{}) error {
+	resp, raw, err := client.doAPIRequest(method, uri, contentType, body)
+	if err != nil {
+		return err
+	}
+
+	var r apiResponse
+	if err := json.Unmarshal(raw, &r); err != nil {
+		if resp.StatusCode >= 400 {
+			return &apiError{StatusCode: resp.StatusCode, RayID: resp.Header.Get("Cf-Ray")}
+		}
+		return fmt.Errorf("error unmarshalling the JSON response: %s", err)
+	}
+
+	if resp.StatusCode >= 400 || !r.Success {
+		return &apiError{
+			StatusCode: resp.StatusCode,
+			Errors:     r.Errors,
+			RayID:      resp.Header.Get("Cf-Ray"),
+		}
+	}
+
+	if result != nil && len(r.Result) > 0 {
+		if err := json.Unmarshal(r.Result, result); err != nil {
+			return fmt.Errorf("error unmarshalling the JSON response: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// apiRawRequest makes a request against an endpoint of the v4 API whose
+// successful responses aren't wrapped in the envelope, and returns the body
+// of the response along with its content type. Failures are still reported
+// in the envelope.
+func (client *CloudFlareClient) apiRawRequest(method, uri string) ([]byte, string, error) {
+	resp, raw, err := client.doAPIRequest(method, uri, "", nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode >= 400 {
+		var r apiResponse
+		json.Unmarshal(raw, &r)
+		return nil, "", &apiError{
+			StatusCode: resp.StatusCode,
+			Errors:     r.Errors,
+			RayID:      resp.Header.Get("Cf-Ray"),
+		}
+	}
+
+	return raw, resp.Header.Get("Content-Type"), nil
+}
+
+// doAPIRequest sends an authenticated request to the v4 API, returning the
+// response and its body.
+func (client *CloudFlareClient) doAPIRequest(method, uri, contentType string, body io.Reader) (*http.Response, []byte, error) {
+	req, err := http.NewRequest(method, client.BaseURL+uri, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("HTTP request creation failed: %s", err)
+	}
+	req.Header.Set("X-Auth-Key", client.APIKey)
+	req.Header.Set("X-Auth-Email", client.APIEmail)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("HTTP request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read response body: %s", err)
+	}
+
+	return resp, raw, nil
+}
+
+// isNotFound reports whether err is an API response for a missing object.
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// isConflict reports whether err is an API response rejecting a change that
+// conflicts with an existing object.
+func isConflict(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.StatusCode == http.StatusConflict
+}
+
+// hasErrorCode reports whether err is an API response carrying the given
+// error code.
+func hasErrorCode(err error, code int) bool {
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		return false
+	}
+	for _, info := range apiErr.Errors {
+		if info.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// userAgentTransport identifies the provider in the User-Agent of every
+// request, made through cloudflare-go or apiRequest, so that Cloudflare
+// support can find them in their logs.
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
+
+// userAgent is the User-Agent of the provider's requests, e.g.
+// "terraform-provider-cloudflare/1.2.3", followed by suffix if there is
+// one.
+func userAgent(suffix string) string {
+	ua := "terraform-provider-cloudflare/" + version.ProviderVersion
+	if suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
+// apiTokenTransport authenticates requests with a scoped API token. Both
+// cloudflare-go and apiRequest send the email and global API key, so they
+// are swapped for the token here, below both.
+type apiTokenTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+func (t *apiTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper mustn't modify the request it is given.
+	req = req.Clone(req.Context())
+	req.Header.Del("X-Auth-Key")
+	req.Header.Del("X-Auth-Email")
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,84 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAPIRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Key") != "sometoken" || r.Header.Get("X-Auth-Email") != "someemail" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/zones/1234567890/subscription":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "abc", "rate_plan": {"id": "pro"}, "state": "Paid"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 1001, "message": "Invalid zone identifier"}], "messages": [], "result": null}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	var sub zoneSubscription
+	if err := client.apiRequest("GET", "/zones/1234567890/subscription", nil, &sub); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if sub.ID != "abc" || sub.RatePlan.ID != "pro" || sub.State != "Paid" {
+		t.Fatalf("bad subscription: %#v", sub)
+	}
+
+	err = client.apiRequest("GET", "/zones/missing/subscription", nil, &sub)
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got: %#v", err)
+	}
+	if expected := "HTTP status 404: Invalid zone identifier (1001)"; err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestAPIError_RayID(t *testing.T) {
+	// Every record fails with a Ray ID of its own.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cf-Ray", path.Base(r.URL.Path)+"-LHR")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "Internal error"}], "messages": [], "result": null}`)
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	// Terraform reads resources concurrently, so each error must carry the
+	// Ray ID of its own response.
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.DNSRecord("1234567890", fmt.Sprintf("record%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if expected := fmt.Sprintf("Ray ID: record%d-LHR", i); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected %q in the error, got: %v", expected, err)
+		}
+	}
+}
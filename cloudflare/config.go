@@ -1,8 +1,13 @@
 package cloudflare
 
 import (
+	"crypto/tls"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/cloudflare/cloudflare-go"
 )
@@ -10,14 +15,129 @@ import (
 type Config struct {
 	Email string
 	Token string
+
+	// APIToken is a scoped API token. It takes precedence over Email and
+	// Token, the global API key.
+	APIToken string
+
+	BatchRecordWrites bool
+	ErrorOnProxyLoop  bool
+
+	// RetryStatusCodes are the responses retried, up to MaxRetries times.
+	// Nil retries defaultRetryStatusCodes.
+	RetryStatusCodes []int
+	MaxRetries       int
+
+	// DefaultProxied is whether records that don't set proxied are
+	// proxied, unless DefaultProxiedByZone has an entry for their domain.
+	DefaultProxied       bool
+	DefaultProxiedByZone map[string]bool
+
+	// DNSConflictStrategy is what creating a record that conflicts with an
+	// existing one does. See resolveDNSRecordConflict.
+	DNSConflictStrategy string
+
+	// Insecure skips verifying the API's TLS certificate. It is only meant
+	// for test environments whose proxies intercept TLS.
+	Insecure bool
+
+	// APIBaseURL replaces the URL of the API, e.g. with a regional endpoint
+	// or a mock of the API. Empty uses cloudflare-go's default.
+	APIBaseURL string
+
+	// UserAgentSuffix is appended to the User-Agent of every request, after
+	// the name and version of the provider.
+	UserAgentSuffix string
+}
+
+// CloudFlareClient is the meta object passed to every resource. It wraps the
+// cloudflare-go client together with the HTTP client its requests, and those
+// made by apiRequest, go through.
+type CloudFlareClient struct {
+	*cloudflare.API
+
+	httpClient    *http.Client
+	transport     *retryTransport
+	recordBatcher *recordBatcher
+
+	// usesAPIToken is set when the client authenticates with a scoped API
+	// token rather than an email and global API key.
+	usesAPIToken         bool
+	errorOnProxyLoop     bool
+	defaultProxied       bool
+	defaultProxiedByZone map[string]bool
+	dnsConflictStrategy  string
+	ipRangesMu           sync.Mutex
+	ipRanges             []*net.IPNet
+	dnssecStatusMu       sync.Mutex
+	dnssecStatus         map[string]string
+	zonePlansMu          sync.Mutex
+	zonePlans            map[string]string
+	zoneSettingsMu       sync.Mutex
+	zoneSettings         map[string]string
+	zoneIDsMu            sync.Mutex
+	zoneIDs              map[string]string
+	zoneIDLocks          map[string]*sync.Mutex
 }
 
 // Client() returns a new client for accessing cloudflare.
-func (c *Config) Client() (*cloudflare.API, error) {
-	client, err := cloudflare.New(c.Token, c.Email)
+func (c *Config) Client() (*CloudFlareClient, error) {
+	if c.APIToken == "" && (c.Email == "" || c.Token == "") {
+		return nil, fmt.Errorf("CloudFlare credentials are missing: set api_token, or both email and token")
+	}
+
+	retryStatusCodes := c.RetryStatusCodes
+	if retryStatusCodes == nil {
+		retryStatusCodes = defaultRetryStatusCodes
+	}
+
+	base := http.DefaultTransport
+	if c.Insecure {
+		log.Printf("[WARN] CloudFlare Client configured to skip TLS certificate verification")
+		insecure := http.DefaultTransport.(*http.Transport).Clone()
+		insecure.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		base = insecure
+	}
+
+	key, email := c.Token, c.Email
+	if c.APIToken != "" {
+		if c.Email != "" || c.Token != "" {
+			log.Printf("[INFO] CloudFlare Client using api_token, ignoring email and token")
+		}
+		// cloudflare-go requires an email and key, which apiTokenTransport
+		// replaces with the token on every request.
+		key, email = c.APIToken, "api_token"
+		base = &apiTokenTransport{base: base, token: c.APIToken}
+	}
+
+	transport := newRetryTransport(base, retryStatusCodes, c.MaxRetries)
+	httpClient := &http.Client{Transport: &userAgentTransport{base: transport, userAgent: userAgent(c.UserAgentSuffix)}}
+
+	client, err := cloudflare.New(key, email, cloudflare.HTTPClient(httpClient))
 	if err != nil {
 		return nil, fmt.Errorf("Error creating new CloudFlare client: %s", err)
 	}
-	log.Printf("[INFO] CloudFlare Client configured for user: %s", c.Email)
-	return client, nil
+	if c.APIToken != "" {
+		log.Printf("[INFO] CloudFlare Client configured with an API token")
+	} else {
+		log.Printf("[INFO] CloudFlare Client configured for user: %s", c.Email)
+	}
+
+	cfClient := &CloudFlareClient{
+		API:                  client,
+		httpClient:           httpClient,
+		transport:            transport,
+		usesAPIToken:         c.APIToken != "",
+		errorOnProxyLoop:     c.ErrorOnProxyLoop,
+		defaultProxied:       c.DefaultProxied,
+		defaultProxiedByZone: c.DefaultProxiedByZone,
+		dnsConflictStrategy:  c.DNSConflictStrategy,
+	}
+	if c.APIBaseURL != "" {
+		cfClient.BaseURL = strings.TrimSuffix(c.APIBaseURL, "/")
+	}
+	if c.BatchRecordWrites {
+		cfClient.recordBatcher = newRecordBatcher(cfClient, recordBatchWindow)
+	}
+	return cfClient, nil
 }
@@ -0,0 +1,181 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/deliveroo/terraform-provider-cloudflare/version"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestConfigClient_Credentials(t *testing.T) {
+	cases := []struct {
+		config Config
+		valid  bool
+	}{
+		{Config{Email: "user@example.com", Token: "key"}, true},
+		{Config{APIToken: "token"}, true},
+		{Config{APIToken: "token", Email: "user@example.com"}, true},
+		{Config{Email: "user@example.com"}, false},
+		{Config{Token: "key"}, false},
+		{Config{}, false},
+	}
+
+	for _, c := range cases {
+		_, err := c.config.Client()
+		if c.valid && err != nil {
+			t.Fatalf("%#v should be valid: %s", c.config, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%#v should be invalid", c.config)
+		}
+	}
+}
+
+func TestConfigClient_APIToken(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer scoped-token" || r.Header.Get("X-Auth-Key") != "" || r.Header.Get("X-Auth-Email") != "" {
+			writeMockError(w, http.StatusForbidden, 9109, fmt.Sprintf("unexpected credentials %q", r.Header))
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+	}))
+	defer ts.Close()
+
+	config := Config{Email: "user@example.com", Token: "key", APIToken: "scoped-token", RetryStatusCodes: []int{}}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	client.BaseURL = ts.URL
+
+	// Through cloudflare-go, and through apiRequest.
+	if _, err := client.ZoneIDByName("example.com"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := client.apiRequest("GET", "/zones", nil, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestConfigClient_APIBaseURL(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/client/v4/zones" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+	}))
+	defer ts.Close()
+
+	// The provider takes the URL from CLOUDFLARE_API_BASE_URL when
+	// api_base_url isn't set.
+	previous := os.Getenv("CLOUDFLARE_API_BASE_URL")
+	os.Setenv("CLOUDFLARE_API_BASE_URL", ts.URL+"/client/v4/")
+	defer os.Setenv("CLOUDFLARE_API_BASE_URL", previous)
+
+	provider := Provider().(*schema.Provider)
+	d := schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{
+		"email":              "user@example.com",
+		"token":              "key",
+		"retry_status_codes": []interface{}{},
+	})
+	meta, err := providerConfigure(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	client := meta.(*CloudFlareClient)
+
+	// Through cloudflare-go, and through apiRequest.
+	if _, err := client.ZoneIDByName("example.com"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := client.apiRequest("GET", "/zones", nil, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+
+	config := Config{Email: "user@example.com", Token: "key"}
+	client, err = config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.BaseURL != "https://api.cloudflare.com/client/v4" {
+		t.Fatalf("expected cloudflare-go's default base URL, got %q", client.BaseURL)
+	}
+}
+
+func TestConfigClient_UserAgent(t *testing.T) {
+	var userAgents []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+	}))
+	defer ts.Close()
+
+	previous := version.ProviderVersion
+	version.ProviderVersion = "1.2.3"
+	defer func() { version.ProviderVersion = previous }()
+
+	for suffix, expected := range map[string]string{
+		"":              "terraform-provider-cloudflare/1.2.3",
+		"platform-team": "terraform-provider-cloudflare/1.2.3 platform-team",
+	} {
+		userAgents = nil
+		config := Config{Email: "user@example.com", Token: "key", APIBaseURL: ts.URL, UserAgentSuffix: suffix}
+		client, err := config.Client()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		// Through cloudflare-go, and through apiRequest.
+		if _, err := client.ZoneIDByName("example.com"); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if err := client.apiRequest("GET", "/zones", nil, nil); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		for _, got := range userAgents {
+			if got != expected {
+				t.Fatalf("expected User-Agent %q, got %q", expected, got)
+			}
+		}
+	}
+}
+
+func TestConfigClient_Insecure(t *testing.T) {
+	for _, insecure := range []bool{false, true} {
+		config := Config{Email: "user@example.com", Token: "token", Insecure: insecure}
+		client, err := config.Client()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		base, ok := client.transport.base.(*http.Transport)
+		if !ok {
+			t.Fatalf("expected an *http.Transport, got %T", client.transport.base)
+		}
+
+		skipVerify := base.TLSClientConfig != nil && base.TLSClientConfig.InsecureSkipVerify
+		if skipVerify != insecure {
+			t.Fatalf("insecure %t: expected InsecureSkipVerify to be %t", insecure, insecure)
+		}
+	}
+
+	// The default transport, which other clients share, is never modified.
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil && config.InsecureSkipVerify {
+		t.Fatal("expected the default transport to verify TLS certificates")
+	}
+}
@@ -0,0 +1,129 @@
+package cloudflare
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCloudFlareDNSRecord() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudFlareDNSRecordRead,
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"record_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"zone_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subdomain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ttl": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"priority": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"proxied": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"proxiable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"locked": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"flatten_cname": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"created_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"modified_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCloudFlareDNSRecordRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+	recordID := d.Get("record_id").(string)
+
+	// The record is read with apiRequest rather than cloudflare-go's
+	// DNSRecord, whose errors don't say whether the record is missing.
+	var record dnsRecord
+	err := client.apiRequest("GET", "/zones/"+zoneID+"/dns_records/"+recordID, nil, &record)
+	if isRecordNotFound(err) {
+		return fmt.Errorf("No record %q in zone %q", recordID, zoneID)
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading CloudFlare Record %q: %s", recordID, err)
+	}
+
+	d.SetId(record.ID)
+	d.Set("zone_name", record.ZoneName)
+	d.Set("type", record.Type)
+	d.Set("hostname", record.Name)
+	d.Set("subdomain", subdomainName(record.Name, record.ZoneName))
+	d.Set("value", record.Content)
+	d.Set("ttl", record.TTL)
+	if record.Priority != nil {
+		d.Set("priority", *record.Priority)
+	} else {
+		d.Set("priority", 0)
+	}
+	d.Set("proxied", record.Proxied)
+	d.Set("proxiable", record.Proxiable)
+	d.Set("locked", record.Locked)
+	d.Set("flatten_cname", record.Settings != nil && record.Settings.FlattenCNAME)
+	d.Set("created_on", record.CreatedOn.Format(time.RFC3339))
+	d.Set("modified_on", record.ModifiedOn.Format(time.RFC3339))
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareDNSRecordDataSource_Basic(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "data.cloudflare_dns_record.foobar"
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: isUnitTest,
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareDNSRecordDataSourceConfig, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "id", "cloudflare_record.foobar", "id"),
+					resource.TestCheckResourceAttr(name, "type", "MX"),
+					resource.TestCheckResourceAttr(name, "hostname", "terraform."+domain),
+					resource.TestCheckResourceAttr(name, "subdomain", "terraform"),
+					resource.TestCheckResourceAttr(name, "zone_name", domain),
+					resource.TestCheckResourceAttr(name, "value", "mx."+domain),
+					resource.TestCheckResourceAttr(name, "priority", "10"),
+					resource.TestCheckResourceAttr(name, "ttl", "3600"),
+					resource.TestCheckResourceAttr(name, "proxied", "false"),
+				),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareDNSRecordDataSourceConfigUnknown, zoneID),
+				ExpectError: regexp.MustCompile(`No record "0000000000000000000000000000dead" in zone`),
+			},
+		},
+	})
+}
+
+const testAccCheckCloudFlareDNSRecordDataSourceConfig = `
+resource "cloudflare_record" "foobar" {
+	zone_id = "%[1]s"
+	subdomain = "terraform"
+	value = "mx.%[2]s"
+	type = "MX"
+	priority = 10
+	ttl = 3600
+}
+
+data "cloudflare_dns_record" "foobar" {
+	zone_id = "%[1]s"
+	record_id = "${cloudflare_record.foobar.id}"
+}`
+
+const testAccCheckCloudFlareDNSRecordDataSourceConfigUnknown = `
+data "cloudflare_dns_record" "foobar" {
+	zone_id = "%s"
+	record_id = "0000000000000000000000000000dead"
+}`
@@ -0,0 +1,70 @@
+package cloudflare
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCloudFlareIPRanges() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudFlareIPRangesRead,
+
+		Schema: map[string]*schema.Schema{
+			"cidr_blocks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"ipv4_cidr_blocks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"ipv6_cidr_blocks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceCloudFlareIPRangesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	// cloudflare-go's IPs always requests the public API with the default
+	// HTTP client, so the ranges are requested like everything else.
+	var ranges cloudflare.IPRanges
+	if err := client.apiRequest("GET", "/ips", nil, &ranges); err != nil {
+		return fmt.Errorf("Error listing CloudFlare IP ranges: %s", err)
+	}
+
+	// The ranges are sorted so that reordering them doesn't show as a
+	// change.
+	ipv4 := append([]string{}, ranges.IPv4CIDRs...)
+	ipv6 := append([]string{}, ranges.IPv6CIDRs...)
+	sort.Strings(ipv4)
+	sort.Strings(ipv6)
+	all := append(append([]string{}, ipv4...), ipv6...)
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(all, ","))))
+	if err := d.Set("cidr_blocks", all); err != nil {
+		return fmt.Errorf("Error setting cidr_blocks: %s", err)
+	}
+	if err := d.Set("ipv4_cidr_blocks", ipv4); err != nil {
+		return fmt.Errorf("Error setting ipv4_cidr_blocks: %s", err)
+	}
+	if err := d.Set("ipv6_cidr_blocks", ipv6); err != nil {
+		return fmt.Errorf("Error setting ipv6_cidr_blocks: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package cloudflare
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccCloudFlareIPRangesDataSource_Basic(t *testing.T) {
+	name := "data.cloudflare_ip_ranges.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckCloudFlareIPRangesDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "cidr_blocks.#"),
+					resource.TestCheckResourceAttrSet(name, "ipv4_cidr_blocks.0"),
+					resource.TestCheckResourceAttrSet(name, "ipv6_cidr_blocks.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlareIPRangesDataSource_Read(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ips" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeTestResult(w, cloudflare.IPRanges{
+			IPv4CIDRs: []string{"198.41.128.0/17", "173.245.48.0/20"},
+			IPv6CIDRs: []string{"2606:4700::/32", "2400:cb00::/32"},
+		})
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceCloudFlareIPRanges().Schema, map[string]interface{}{})
+	if err := dataSourceCloudFlareIPRangesRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string][]interface{}{
+		"ipv4_cidr_blocks": {"173.245.48.0/20", "198.41.128.0/17"},
+		"ipv6_cidr_blocks": {"2400:cb00::/32", "2606:4700::/32"},
+		"cidr_blocks":      {"173.245.48.0/20", "198.41.128.0/17", "2400:cb00::/32", "2606:4700::/32"},
+	}
+	for k, v := range expected {
+		if got := d.Get(k); !reflect.DeepEqual(got, v) {
+			t.Fatalf("expected %s %v, got %v", k, v, got)
+		}
+	}
+	if d.Id() == "" {
+		t.Fatalf("expected an ID to be set")
+	}
+}
+
+const testAccCheckCloudFlareIPRangesDataSourceConfig = `
+data "cloudflare_ip_ranges" "foobar" {}
+`
@@ -0,0 +1,70 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// logpushDestinationCheck is the result of checking that Cloudflare can
+// push logs to a destination.
+type logpushDestinationCheck struct {
+	Valid   bool   `json:"valid"`
+	Message string `json:"message"`
+}
+
+func dataSourceCloudFlareLogpushDestinationCheck() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudFlareLogpushDestinationCheckRead,
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// destination_conf usually carries the credentials of the
+			// destination.
+			"destination_conf": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"valid": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCloudFlareLogpushDestinationCheckRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+	destination := d.Get("destination_conf").(string)
+
+	log.Printf("[DEBUG] Checking CloudFlare Logpush destination for zone %s", zoneID)
+
+	var check logpushDestinationCheck
+	params := map[string]string{"destination_conf": destination}
+	if err := client.apiRequest("POST", "/zones/"+zoneID+"/logpush/validate/destination", params, &check); err != nil {
+		return fmt.Errorf("Error checking Logpush destination for zone %q: %s", zoneID, err)
+	}
+
+	// The ID identifies the destination without giving away its
+	// credentials.
+	d.SetId(zoneID + "/" + strconv.Itoa(hashcode.String(destination)))
+	d.Set("valid", check.Valid)
+	d.Set("message", check.Message)
+
+	return nil
+}
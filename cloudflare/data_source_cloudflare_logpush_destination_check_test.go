@@ -0,0 +1,102 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccCloudFlareLogpushDestinationCheckDataSource_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	destination := os.Getenv("CLOUDFLARE_LOGPUSH_DESTINATION_CONF")
+	name := "data.cloudflare_logpush_destination_check.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+			testAccPreCheckLogpushDestination(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLogpushDestinationCheckDataSourceConfig, zoneID, destination),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "valid", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLogpushDestinationCheckDataSourceConfig, zoneID, "s3://terraform-acctest-missing-bucket?region=us-west-2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "valid", "false"),
+					resource.TestCheckResourceAttrSet(name, "message"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlareLogpushDestinationCheckDataSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/zones/1234567890/logpush/validate/destination" || r.Method != "POST" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var params map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			writeMockError(w, http.StatusBadRequest, 10000, err.Error())
+			return
+		}
+		if strings.HasPrefix(params["destination_conf"], "s3://logs") {
+			writeTestResult(w, logpushDestinationCheck{Valid: true})
+		} else {
+			writeTestResult(w, logpushDestinationCheck{Message: "bucket does not exist"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]struct {
+		Destination string
+		Valid       bool
+		Message     string
+	}{
+		"valid":   {"s3://logs/http_requests?region=us-west-2", true, ""},
+		"invalid": {"s3://missing/http_requests?region=us-west-2", false, "bucket does not exist"},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceCloudFlareLogpushDestinationCheck().Schema, map[string]interface{}{
+			"zone_id":          "1234567890",
+			"destination_conf": tc.Destination,
+		})
+
+		if err := dataSourceCloudFlareLogpushDestinationCheckRead(d, client); err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if d.Get("valid") != tc.Valid || d.Get("message") != tc.Message {
+			t.Fatalf("%s: expected valid %t and message %q, got %v and %q", tn, tc.Valid, tc.Message, d.Get("valid"), d.Get("message"))
+		}
+		if strings.Contains(d.Id(), "s3://") {
+			t.Fatalf("%s: expected the ID not to contain the destination, got %q", tn, d.Id())
+		}
+	}
+}
+
+const testAccCheckCloudFlareLogpushDestinationCheckDataSourceConfig = `
+data "cloudflare_logpush_destination_check" "foobar" {
+	zone_id = "%s"
+	destination_conf = "%s"
+}`
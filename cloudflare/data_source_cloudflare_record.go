@@ -0,0 +1,112 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCloudFlareRecord() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudFlareRecordRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"zone_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// name is either the subdomain of the record or its full
+			// hostname.
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ttl": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"proxied": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"priority": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCloudFlareRecordRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	zoneID, domain, err := client.resourceZone(d)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	if name != domain && !strings.HasSuffix(name, "."+domain) {
+		name = recordName(name, domain)
+	}
+	recordType := d.Get("type").(string)
+
+	log.Printf("[DEBUG] Finding CloudFlare %s Record %s", recordType, name)
+
+	filter := url.Values{}
+	filter.Set("name", name)
+	filter.Set("type", recordType)
+	records, err := client.listDNSRecords(zoneID, filter)
+	if err != nil {
+		return fmt.Errorf("Error finding %s record %q: %s", recordType, name, err)
+	}
+
+	switch {
+	case len(records) == 0:
+		return fmt.Errorf("No %s record %q in zone %q", recordType, name, domain)
+	case len(records) > 1:
+		return fmt.Errorf("Found %d %s records %q, expected 1: %s. Read the one wanted by its ID with cloudflare_dns_record instead",
+			len(records), recordType, name, describeRecords(records))
+	}
+	record := records[0]
+
+	d.SetId(record.ID)
+	d.Set("zone_id", zoneID)
+	d.Set("domain", domain)
+	d.Set("hostname", record.Name)
+	d.Set("value", record.Content)
+	d.Set("ttl", record.TTL)
+	d.Set("proxied", record.Proxied)
+	d.Set("priority", record.Priority)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package cloudflare
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareRecordDataSource_Basic(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+	name := "data.cloudflare_record.foobar"
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordDataSourceConfig, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "id", "cloudflare_record.foobar", "id"),
+					resource.TestCheckResourceAttrPair(name, "zone_id", "cloudflare_record.foobar", "zone_id"),
+					resource.TestCheckResourceAttr(name, "hostname", "terraform."+domain),
+					resource.TestCheckResourceAttr(name, "value", "mx."+domain),
+					resource.TestCheckResourceAttr(name, "priority", "10"),
+					resource.TestCheckResourceAttr(name, "ttl", "3600"),
+					resource.TestCheckResourceAttr(name, "proxied", "false"),
+					resource.TestCheckResourceAttrPair("data.cloudflare_record.hostname", "id", "cloudflare_record.foobar", "id"),
+				),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordDataSourceConfigMissing, domain),
+				ExpectError: regexp.MustCompile(`No TXT record "terraform\..*" in zone`),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordDataSourceConfigSeveral, domain),
+				ExpectError: regexp.MustCompile(`Found 2 A records "terraform-rr\..*", expected 1: \w+ \(192\.0\.2\.\d\), \w+ \(192\.0\.2\.\d\)`),
+			},
+		},
+	})
+}
+
+const testAccCheckCloudFlareRecordDataSourceConfig = `
+resource "cloudflare_record" "foobar" {
+	domain = "%[1]s"
+	subdomain = "terraform"
+	value = "mx.%[1]s"
+	type = "MX"
+	priority = 10
+	ttl = 3600
+}
+
+data "cloudflare_record" "foobar" {
+	domain = "%[1]s"
+	name = "${cloudflare_record.foobar.subdomain}"
+	type = "${cloudflare_record.foobar.type}"
+}
+
+data "cloudflare_record" "hostname" {
+	zone_id = "${cloudflare_record.foobar.zone_id}"
+	name = "${cloudflare_record.foobar.subdomain}.%[1]s"
+	type = "MX"
+}`
+
+const testAccCheckCloudFlareRecordDataSourceConfigMissing = `
+data "cloudflare_record" "foobar" {
+	domain = "%s"
+	name = "terraform"
+	type = "TXT"
+}`
+
+const testAccCheckCloudFlareRecordDataSourceConfigSeveral = `
+resource "cloudflare_record" "first" {
+	domain = "%[1]s"
+	subdomain = "terraform-rr"
+	value = "192.0.2.1"
+	type = "A"
+}
+
+resource "cloudflare_record" "second" {
+	domain = "%[1]s"
+	subdomain = "terraform-rr"
+	value = "192.0.2.2"
+	type = "A"
+}
+
+data "cloudflare_record" "foobar" {
+	domain = "%[1]s"
+	name = "terraform-rr"
+	type = "A"
+
+	depends_on = ["cloudflare_record.first", "cloudflare_record.second"]
+}`
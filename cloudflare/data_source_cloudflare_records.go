@@ -0,0 +1,213 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// recordsPerPage is the largest page of records the API returns.
+const recordsPerPage = 100
+
+func dataSourceCloudFlareRecords() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudFlareRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"tag_match": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validateTagMatch,
+			},
+
+			"zone_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subdomain": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"proxied": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"import_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"import_command": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudFlareRecordsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	domain := d.Get("domain").(string)
+
+	zoneID, err := client.ZoneIDByName(domain)
+	if err != nil {
+		return fmt.Errorf("Error finding zone %q: %s", domain, err)
+	}
+
+	query := url.Values{}
+	if recordType, ok := d.GetOk("type"); ok {
+		query.Set("type", recordType.(string))
+	}
+	// Records are filtered by tag server-side, as zones can have far more
+	// records than the ones wanted.
+	if tags := expandStringSet(d.Get("tags")); len(tags) > 0 {
+		query["tag"] = tags
+		query.Set("tag_match", d.Get("tag_match").(string))
+	}
+
+	records, err := client.listDNSRecords(zoneID, query)
+	if err != nil {
+		return fmt.Errorf("Error listing records of zone %q: %s", domain, err)
+	}
+
+	log.Printf("[DEBUG] Found %d CloudFlare Records in zone %s", len(records), domain)
+
+	flattened := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		importID := recordImportID(record, domain)
+		flattened = append(flattened, map[string]interface{}{
+			"id":             record.ID,
+			"type":           record.Type,
+			"hostname":       record.Name,
+			"subdomain":      subdomainName(record.Name, domain),
+			"value":          record.Content,
+			"ttl":            record.TTL,
+			"priority":       record.Priority,
+			"proxied":        record.Proxied,
+			"import_id":      importID,
+			"import_command": fmt.Sprintf("terraform import cloudflare_record.%s '%s'", recordResourceName(record, domain), importID),
+		})
+	}
+
+	d.SetId(zoneID)
+	d.Set("zone_id", zoneID)
+	if err := d.Set("records", flattened); err != nil {
+		return fmt.Errorf("Error setting records: %s", err)
+	}
+
+	return nil
+}
+
+// listDNSRecords returns all the records of a zone that match query.
+// cloudflare-go only returns the first page of records, which isn't enough
+// for large zones, so this pages through them all.
+func (client *CloudFlareClient) listDNSRecords(zoneID string, query url.Values) ([]cloudflare.DNSRecord, error) {
+	paged := url.Values{}
+	for k, v := range query {
+		paged[k] = v
+	}
+	paged.Set("per_page", strconv.Itoa(recordsPerPage))
+
+	var records []cloudflare.DNSRecord
+	for page := 1; ; page++ {
+		paged.Set("page", strconv.Itoa(page))
+
+		var batch []cloudflare.DNSRecord
+		if err := client.apiRequest("GET", "/zones/"+zoneID+"/dns_records?"+paged.Encode(), nil, &batch); err != nil {
+			return nil, err
+		}
+		records = append(records, batch...)
+
+		if len(batch) < recordsPerPage {
+			return records, nil
+		}
+	}
+}
+
+// recordImportID is the ID to import record with as a cloudflare_record. The
+// record ID is included as a zone can have several records of the same name
+// and type.
+func recordImportID(record cloudflare.DNSRecord, domain string) string {
+	return strings.Join([]string{subdomainName(record.Name, domain), domain, record.Type, record.ID}, "|")
+}
+
+var nonIdentifierChars = regexp.MustCompile("[^a-z0-9_]+")
+
+// recordResourceName suggests a unique Terraform resource name for record,
+// e.g. www_cname_372e6795.
+func recordResourceName(record cloudflare.DNSRecord, domain string) string {
+	subdomain := subdomainName(record.Name, domain)
+	if subdomain == "" {
+		subdomain = "apex"
+	}
+
+	id := record.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+
+	name := strings.ToLower(fmt.Sprintf("%s_%s_%s", subdomain, record.Type, id))
+	name = strings.Trim(nonIdentifierChars.ReplaceAllString(name, "_"), "_")
+
+	// Resource names can't start with a digit.
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "record_" + name
+	}
+	return name
+}
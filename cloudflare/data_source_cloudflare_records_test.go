@@ -0,0 +1,153 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareRecordsDataSource_Basic(t *testing.T) {
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "data.cloudflare_records.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordsDataSourceConfig, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "zone_id"),
+					testAccCheckCloudFlareRecordsImportID(name, "terraform-records|"+domain+"|TXT|"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlareRecordsDataSource_Tags(t *testing.T) {
+	var query url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/zones":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+		case "/zones/1234567890/dns_records":
+			query = r.URL.Query()
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "A", "name": "www.example.com", "content": "192.168.0.10"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceCloudFlareRecords().Schema, map[string]interface{}{
+		"domain":    "example.com",
+		"tags":      []interface{}{"team:dns", "production"},
+		"tag_match": "all",
+	})
+
+	if err := dataSourceCloudFlareRecordsRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	tags := query["tag"]
+	sort.Strings(tags)
+	if !reflect.DeepEqual(tags, []string{"production", "team:dns"}) {
+		t.Fatalf("expected the records to be filtered by both tags, got %v", tags)
+	}
+	if match := query.Get("tag_match"); match != "all" {
+		t.Fatalf("expected tag_match all, got %q", match)
+	}
+	if n := d.Get("records.#"); n != 1 {
+		t.Fatalf("expected 1 record, got %v", n)
+	}
+}
+
+// testAccCheckCloudFlareRecordsImportID checks that a record has an import ID
+// starting with prefix.
+func testAccCheckCloudFlareRecordsImportID(name, prefix string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasSuffix(k, ".import_id") && strings.HasPrefix(v, prefix) {
+				return nil
+			}
+		}
+		return fmt.Errorf("No record with an import ID starting with %q", prefix)
+	}
+}
+
+func TestRecordImportStrings(t *testing.T) {
+	domain := "example.com"
+	records := []cloudflare.DNSRecord{
+		{ID: "372e67954025e0ba6aaa6d586b9e0b59", Type: "A", Name: "www.example.com"},
+		{ID: "9a7806061c88ada191ed06f989cc3dac", Type: "MX", Name: "example.com"},
+		{ID: "023e105f4ecef8ad9ca31a8372d0c353", Type: "TXT", Name: "_dmarc.example.com"},
+		{ID: "7778f8766e583af8de0abfcd76c5daaa", Type: "CNAME", Name: "*.dev.example.com"},
+		{ID: "5558f8766e583af8de0abfcd76c5dbbb", Type: "AAAA", Name: "1st.example.com"},
+	}
+	expected := []struct {
+		importID     string
+		resourceName string
+	}{
+		{"www|example.com|A|372e67954025e0ba6aaa6d586b9e0b59", "www_a_372e6795"},
+		{"|example.com|MX|9a7806061c88ada191ed06f989cc3dac", "apex_mx_9a780606"},
+		{"_dmarc|example.com|TXT|023e105f4ecef8ad9ca31a8372d0c353", "dmarc_txt_023e105f"},
+		{"*.dev|example.com|CNAME|7778f8766e583af8de0abfcd76c5daaa", "dev_cname_7778f876"},
+		{"1st|example.com|AAAA|5558f8766e583af8de0abfcd76c5dbbb", "record_1st_aaaa_5558f876"},
+	}
+
+	identifier := regexp.MustCompile("^[a-z_][a-z0-9_]*$")
+	for i, record := range records {
+		importID := recordImportID(record, domain)
+		if importID != expected[i].importID {
+			t.Fatalf("expected import ID %q, got %q", expected[i].importID, importID)
+		}
+		if tokens := strings.Split(importID, "|"); len(tokens) != 4 || tokens[1] != domain || tokens[2] != record.Type || tokens[3] != record.ID {
+			t.Fatalf("malformed import ID %q", importID)
+		}
+
+		name := recordResourceName(record, domain)
+		if name != expected[i].resourceName {
+			t.Fatalf("expected resource name %q, got %q", expected[i].resourceName, name)
+		}
+		if !identifier.MatchString(name) {
+			t.Fatalf("%q is not a valid resource name", name)
+		}
+	}
+}
+
+const testAccCheckCloudFlareRecordsDataSourceConfig = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "terraform-records"
+	value = "terraform"
+	type = "TXT"
+}
+
+data "cloudflare_records" "foobar" {
+	domain = "%s"
+	type = "TXT"
+	depends_on = ["cloudflare_record.foobar"]
+}`
@@ -0,0 +1,60 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// apiTokenVerification describes the API token a request was made with.
+type apiTokenVerification struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	ExpiresOn string `json:"expires_on"`
+}
+
+func dataSourceCloudFlareVerifyToken() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudFlareVerifyTokenRead,
+
+		Schema: map[string]*schema.Schema{
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"expires_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCloudFlareVerifyTokenRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	// Only scoped API tokens can be verified; the global API key has no
+	// status or expiry.
+	if !client.usesAPIToken {
+		return fmt.Errorf("cloudflare_verify_token requires the provider to be configured with api_token, not email and token")
+	}
+
+	log.Printf("[DEBUG] Verifying CloudFlare API token")
+
+	var token apiTokenVerification
+	if err := client.apiRequest("GET", "/user/tokens/verify", nil, &token); err != nil {
+		return fmt.Errorf("Error verifying API token: %s", err)
+	}
+
+	if token.ID == "" {
+		return fmt.Errorf("Failed to find API token in verify response; ID was empty")
+	}
+
+	d.SetId(token.ID)
+	d.Set("status", token.Status)
+	d.Set("expires_on", token.ExpiresOn)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package cloudflare
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccCloudFlareVerifyTokenDataSource_Basic(t *testing.T) {
+	name := "data.cloudflare_verify_token.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAPIToken(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckCloudFlareVerifyTokenDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "status", "active"),
+					resource.TestCheckResourceAttrSet(name, "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlareVerifyTokenDataSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/tokens/verify" || r.Method != "GET" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer scoped-token" {
+			writeMockError(w, http.StatusBadRequest, 1000, "Invalid API Token")
+			return
+		}
+		writeTestResult(w, apiTokenVerification{
+			ID:        "ed17574386854bf78a67040be0a770b0",
+			Status:    "active",
+			ExpiresOn: "2027-01-01T00:00:00Z",
+		})
+	}))
+	defer ts.Close()
+
+	cases := map[string]struct {
+		Config Config
+		Error  string
+	}{
+		"api token":  {Config{APIToken: "scoped-token"}, ""},
+		"revoked":    {Config{APIToken: "revoked-token"}, "Invalid API Token"},
+		"legacy key": {Config{Email: "user@example.com", Token: "key"}, "requires the provider to be configured with api_token"},
+	}
+
+	for tn, tc := range cases {
+		tc.Config.RetryStatusCodes = []int{}
+		client, err := tc.Config.Client()
+		if err != nil {
+			t.Fatalf("%s: Error building CloudFlare API: %s", tn, err)
+		}
+		client.BaseURL = ts.URL
+
+		d := schema.TestResourceDataRaw(t, dataSourceCloudFlareVerifyToken().Schema, map[string]interface{}{})
+		err = dataSourceCloudFlareVerifyTokenRead(d, client)
+		if tc.Error != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.Error) {
+				t.Fatalf("%s: expected error containing %q, got %v", tn, tc.Error, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if d.Id() != "ed17574386854bf78a67040be0a770b0" || d.Get("status") != "active" || d.Get("expires_on") != "2027-01-01T00:00:00Z" {
+			t.Fatalf("%s: unexpected token %q: %#v", tn, d.Id(), d.State().Attributes)
+		}
+	}
+}
+
+func testAccPreCheckAPIToken(t *testing.T) {
+	if v := os.Getenv("CLOUDFLARE_API_TOKEN"); v == "" {
+		t.Fatal("CLOUDFLARE_API_TOKEN must be set for this acceptance test. The token is verified against.")
+	}
+}
+
+const testAccCheckCloudFlareVerifyTokenDataSourceConfig = `
+data "cloudflare_verify_token" "foobar" {}`
@@ -0,0 +1,97 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessObjectsPerPage is the largest page of groups, identity providers and
+// other Access objects the API returns.
+const accessObjectsPerPage = 100
+
+func dataSourceCloudFlareZeroTrustAccessGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudFlareZeroTrustAccessGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"zone_id"},
+			},
+
+			"zone_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"account_id"},
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func dataSourceCloudFlareZeroTrustAccessGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	name := d.Get("name").(string)
+
+	uri, err := accessScopedURI(d, "/access/groups")
+	if err != nil {
+		return err
+	}
+
+	id, err := client.accessObjectIDByName(uri, "group", name)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Found CloudFlare Access Group %q: %s", name, id)
+
+	d.SetId(id)
+	return nil
+}
+
+// accessObjectIDByName returns the ID of the only Access object listed at uri
+// with the given name. Names aren't unique, so a name shared by several
+// objects is an error rather than a guess.
+func (client *CloudFlareClient) accessObjectIDByName(uri, kind, name string) (string, error) {
+	query := url.Values{}
+	query.Set("per_page", strconv.Itoa(accessObjectsPerPage))
+
+	var ids []string
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var batch []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := client.apiRequest("GET", uri+"?"+query.Encode(), nil, &batch); err != nil {
+			return "", fmt.Errorf("Error listing Access %ss: %s", kind, err)
+		}
+		for _, object := range batch {
+			if object.Name == name {
+				ids = append(ids, object.ID)
+			}
+		}
+
+		if len(batch) < accessObjectsPerPage {
+			break
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("No Access %s is named %q", kind, name)
+	case 1:
+		return ids[0], nil
+	}
+	return "", fmt.Errorf("%d Access %ss are named %q: %v", len(ids), kind, name, ids)
+}
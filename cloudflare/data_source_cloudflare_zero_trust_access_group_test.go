@@ -0,0 +1,106 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccCloudFlareZeroTrustAccessGroupDataSource_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustAccessGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessGroupDataSourceConfig, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.cloudflare_zero_trust_access_group.foobar", "id", "cloudflare_zero_trust_access_group.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlareZeroTrustAccessGroupDataSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/1234567890/access/groups" || r.Method != "GET" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		groups := []accessGroup{
+			{ID: "a1", Name: "employees"},
+			{ID: "b2", Name: "contractors"},
+			{ID: "c3", Name: "contractors"},
+		}
+		// Only the first page has any groups.
+		if r.URL.Query().Get("page") != "1" {
+			groups = nil
+		}
+		writeTestResult(w, groups)
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]struct {
+		Name  string
+		ID    string
+		Error string
+	}{
+		"unique":    {"employees", "a1", ""},
+		"missing":   {"admins", "", "No Access group is named"},
+		"ambiguous": {"contractors", "", "2 Access groups are named"},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceCloudFlareZeroTrustAccessGroup().Schema, map[string]interface{}{
+			"account_id": "1234567890",
+			"name":       tc.Name,
+		})
+
+		err := dataSourceCloudFlareZeroTrustAccessGroupRead(d, client)
+		if tc.Error != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.Error) {
+				t.Fatalf("%s: expected error containing %q, got %v", tn, tc.Error, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if d.Id() != tc.ID {
+			t.Fatalf("%s: expected ID %q, got %q", tn, tc.ID, d.Id())
+		}
+	}
+}
+
+const testAccCheckCloudFlareZeroTrustAccessGroupDataSourceConfig = `
+resource "cloudflare_zero_trust_access_group" "foobar" {
+	account_id = "%[1]s"
+	name = "terraform-acctest-lookup"
+
+	include {
+		email_domain = ["example.com"]
+	}
+}
+
+data "cloudflare_zero_trust_access_group" "foobar" {
+	account_id = "%[1]s"
+	name = "${cloudflare_zero_trust_access_group.foobar.name}"
+}`
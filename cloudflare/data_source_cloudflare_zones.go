@@ -0,0 +1,167 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// zonesPerPage is the largest page of zones the API returns.
+const zonesPerPage = 50
+
+func dataSourceCloudFlareZones() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudFlareZonesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"lookup_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "exact",
+				ValidateFunc: validateZoneLookupType,
+			},
+
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegexp,
+			},
+
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateZoneStatus,
+			},
+
+			"zones": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"paused": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudFlareZonesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	name := d.Get("name").(string)
+	lookupType := d.Get("lookup_type").(string)
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	var nameLookupRegex *regexp.Regexp
+	if name != "" && lookupType == "regex" {
+		var err error
+		if nameLookupRegex, err = regexp.Compile(name); err != nil {
+			return fmt.Errorf("Error parsing name %q as a regular expression: %s", name, err)
+		}
+	}
+
+	// cloudflare-go's ListZones only returns the first page of zones, so
+	// accounts with more zones than a page would silently miss some.
+	query := url.Values{}
+	query.Set("per_page", strconv.Itoa(zonesPerPage))
+	if status, ok := d.GetOk("status"); ok {
+		query.Set("status", status.(string))
+	}
+	// The API matches names exactly, or with a "contains:" operator.
+	// Regular expressions are only matched here.
+	switch {
+	case name != "" && lookupType == "exact":
+		query.Set("name", name)
+	case name != "" && lookupType == "contains":
+		query.Set("name", "contains:"+name)
+	}
+
+	var zones []cloudflare.Zone
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var batch []cloudflare.Zone
+		if err := client.apiRequest("GET", "/zones?"+query.Encode(), nil, &batch); err != nil {
+			return fmt.Errorf("Error listing zones: %s", err)
+		}
+		zones = append(zones, batch...)
+
+		if len(batch) < zonesPerPage {
+			break
+		}
+	}
+
+	log.Printf("[DEBUG] Found %d CloudFlare Zones", len(zones))
+
+	flattened := make([]interface{}, 0, len(zones))
+	ids := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		if !zoneNameMatches(zone.Name, name, lookupType, nameLookupRegex) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(zone.Name) {
+			continue
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"id":     zone.ID,
+			"name":   zone.Name,
+			"status": zone.Status,
+			"paused": zone.Paused,
+		})
+		ids = append(ids, zone.ID)
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	if err := d.Set("zones", flattened); err != nil {
+		return fmt.Errorf("Error setting zones: %s", err)
+	}
+
+	return nil
+}
+
+// zoneNameMatches reports whether the zone called zoneName matches the name
+// filter of the data source, if any. Zones the API filtered by name are
+// checked again, the way a regular expression is.
+func zoneNameMatches(zoneName, name, lookupType string, nameRegex *regexp.Regexp) bool {
+	if name == "" {
+		return true
+	}
+	switch lookupType {
+	case "contains":
+		return strings.Contains(strings.ToLower(zoneName), strings.ToLower(name))
+	case "regex":
+		return nameRegex.MatchString(zoneName)
+	}
+	return strings.EqualFold(zoneName, name)
+}
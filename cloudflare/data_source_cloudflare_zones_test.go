@@ -0,0 +1,191 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccCloudFlareZonesDataSource_Basic(t *testing.T) {
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "data.cloudflare_zones.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZonesDataSourceConfig, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zones.#", "1"),
+					resource.TestCheckResourceAttr(name, "zones.0.name", domain),
+					resource.TestCheckResourceAttr(name, "zones.0.status", "active"),
+					resource.TestCheckResourceAttrSet(name, "zones.0.id"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlareZonesDataSource_Filters(t *testing.T) {
+	var statuses []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/zones" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		statuses = append(statuses, r.URL.Query().Get("status"))
+
+		// A full first page of zones, then the rest.
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		var zones []cloudflare.Zone
+		switch page {
+		case 1:
+			for i := 0; i < zonesPerPage; i++ {
+				zones = append(zones, cloudflare.Zone{ID: fmt.Sprintf("zone%d", i), Name: fmt.Sprintf("example%d.net", i), Status: "active"})
+			}
+		case 2:
+			zones = []cloudflare.Zone{
+				{ID: "1234567890", Name: "example.com", Status: "active"},
+				{ID: "0987654321", Name: "example.org", Status: "active", Paused: true},
+			}
+		}
+		writeTestResult(w, zones)
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceCloudFlareZones().Schema, map[string]interface{}{
+		"name_regex": `^example\.(com|org)$`,
+		"status":     "active",
+	})
+
+	if err := dataSourceCloudFlareZonesRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(statuses) != 2 || statuses[0] != "active" || statuses[1] != "active" {
+		t.Fatalf("expected two pages of active zones to be listed, got %v", statuses)
+	}
+	if n := d.Get("zones.#"); n != 2 {
+		t.Fatalf("expected 2 zones, got %v", n)
+	}
+	if id, paused := d.Get("zones.1.id"), d.Get("zones.1.paused"); id != "0987654321" || paused != true {
+		t.Fatalf("expected paused zone 0987654321, got %v, paused %v", id, paused)
+	}
+	if d.Id() == "" {
+		t.Fatalf("expected an ID to be set")
+	}
+}
+
+func TestCloudFlareZonesDataSource_LookupType(t *testing.T) {
+	cases := map[string]struct {
+		Config map[string]interface{}
+		Query  string
+		Zones  []string
+		Error  string
+	}{
+		"exact": {
+			Config: map[string]interface{}{"name": "example.com", "lookup_type": "exact"},
+			Query:  "example.com",
+			Zones:  []string{"example.com"},
+		},
+		"exact by default, ignoring case": {
+			Config: map[string]interface{}{"name": "Example.com"},
+			Query:  "Example.com",
+			Zones:  []string{"example.com"},
+		},
+		"contains": {
+			Config: map[string]interface{}{"name": "example", "lookup_type": "contains"},
+			Query:  "contains:example",
+			Zones:  []string{"example.com", "example.org"},
+		},
+		"regex": {
+			Config: map[string]interface{}{"name": `^example\.(com|org)$`, "lookup_type": "regex"},
+			Zones:  []string{"example.com", "example.org"},
+		},
+		"invalid regex": {
+			Config: map[string]interface{}{"name": `^example\.(com`, "lookup_type": "regex"},
+			Error:  "as a regular expression",
+		},
+		"name and name_regex": {
+			Config: map[string]interface{}{"name": "example", "lookup_type": "contains", "name_regex": `\.org$`},
+			Query:  "contains:example",
+			Zones:  []string{"example.org"},
+		},
+	}
+
+	all := []cloudflare.Zone{
+		{ID: "1234567890", Name: "example.com"},
+		{ID: "0987654321", Name: "example.org"},
+		{ID: "1122334455", Name: "other.net"},
+	}
+
+	for name, c := range cases {
+		var queries []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Filter by name the way the API does.
+			query := r.URL.Query().Get("name")
+			queries = append(queries, query)
+			var zones []cloudflare.Zone
+			for _, zone := range all {
+				switch {
+				case query == "",
+					strings.HasPrefix(query, "contains:") && strings.Contains(zone.Name, strings.TrimPrefix(query, "contains:")),
+					strings.EqualFold(zone.Name, query):
+					zones = append(zones, zone)
+				}
+			}
+			writeTestResult(w, zones)
+		}))
+
+		client, err := testClient(ts.URL)
+		if err != nil {
+			t.Fatalf("Error building CloudFlare API: %s", err)
+		}
+
+		d := schema.TestResourceDataRaw(t, dataSourceCloudFlareZones().Schema, c.Config)
+		err = dataSourceCloudFlareZonesRead(d, client)
+		ts.Close()
+
+		if c.Error != "" {
+			if err == nil || !strings.Contains(err.Error(), c.Error) {
+				t.Fatalf("%s: expected an error with %q, got: %v", name, c.Error, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		if len(queries) != 1 || queries[0] != c.Query {
+			t.Fatalf("%s: expected zones to be listed by name %q, got %q", name, c.Query, queries)
+		}
+		var zones []string
+		for _, zone := range d.Get("zones").([]interface{}) {
+			zones = append(zones, zone.(map[string]interface{})["name"].(string))
+		}
+		if !reflect.DeepEqual(zones, c.Zones) {
+			t.Fatalf("%s: expected zones %q, got %q", name, c.Zones, zones)
+		}
+	}
+}
+
+const testAccCheckCloudFlareZonesDataSourceConfig = `
+data "cloudflare_zones" "foobar" {
+	name_regex = "^%s$"
+	status = "active"
+}`
@@ -0,0 +1,31 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// importAccountScopedResource imports resources whose ID is only unique
+// within an account, given as "account_id/resource_id".
+func importAccountScopedResource(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importScopedResource(d, "account_id")
+}
+
+// importZoneScopedResource imports resources whose ID is only unique
+// within a zone, given as "zone_id/resource_id".
+func importZoneScopedResource(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importScopedResource(d, "zone_id")
+}
+
+func importScopedResource(d *schema.ResourceData, scope string) ([]*schema.ResourceData, error) {
+	tokens := strings.SplitN(d.Id(), "/", 2)
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return nil, fmt.Errorf("expecting %s/resource_id, got %q", scope, d.Id())
+	}
+
+	d.Set(scope, tokens[0])
+	d.SetId(tokens[1])
+	return []*schema.ResourceData{d}, nil
+}
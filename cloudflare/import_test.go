@@ -0,0 +1,62 @@
+package cloudflare
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestImportAccountScopedResource(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"account_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	d.SetId("1d5fdc9e88c8a8c4518b068cd94331fe/055c2ddd2e5a4b1e8d8b4b7b0d7e1e47")
+	if _, err := importAccountScopedResource(d, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "055c2ddd2e5a4b1e8d8b4b7b0d7e1e47" || d.Get("account_id") != "1d5fdc9e88c8a8c4518b068cd94331fe" {
+		t.Fatalf("bad import: %s, %s", d.Id(), d.Get("account_id"))
+	}
+
+	for _, id := range []string{"055c2ddd2e5a4b1e8d8b4b7b0d7e1e47", "/055c2ddd2e5a4b1e8d8b4b7b0d7e1e47", "1d5fdc9e88c8a8c4518b068cd94331fe/"} {
+		d.SetId(id)
+		if _, err := importAccountScopedResource(d, nil); err == nil {
+			t.Fatalf("%q should not be importable", id)
+		}
+	}
+}
+
+func TestImportZoneScopedResource(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"zone_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	d.SetId("023e105f4ecef8ad9ca31a8372d0c353/123")
+	if _, err := importZoneScopedResource(d, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "123" || d.Get("zone_id") != "023e105f4ecef8ad9ca31a8372d0c353" {
+		t.Fatalf("bad import: %s, %s", d.Id(), d.Get("zone_id"))
+	}
+}
+
+func TestImportZone(t *testing.T) {
+	// Like an import, d starts out with only the zone's ID.
+	d := resourceCloudFlareZone().Data(nil)
+	d.SetId("023e105f4ecef8ad9ca31a8372d0c353")
+	if _, err := importZone(d, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v, ok := d.State().Attributes["force_destroy"]; !ok || v != "false" {
+		t.Fatalf("expected force_destroy to be imported as false, got %q", v)
+	}
+}
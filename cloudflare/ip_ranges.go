@@ -0,0 +1,81 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// cloudflareIPRanges returns the networks of the Cloudflare edge. They are
+// fetched once per provider run, as they rarely change.
+func (client *CloudFlareClient) cloudflareIPRanges() ([]*net.IPNet, error) {
+	client.ipRangesMu.Lock()
+	defer client.ipRangesMu.Unlock()
+
+	if client.ipRanges != nil {
+		return client.ipRanges, nil
+	}
+
+	// Like the cloudflare_ip_ranges data source, the ranges are requested
+	// through the client rather than with cloudflare-go's IPs, which always
+	// uses the public API and the default HTTP client.
+	var ranges cloudflare.IPRanges
+	if err := client.apiRequest("GET", "/ips", nil, &ranges); err != nil {
+		return nil, err
+	}
+
+	var networks []*net.IPNet
+	for _, cidr := range append(ranges.IPv4CIDRs, ranges.IPv6CIDRs...) {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Cloudflare IP range %q: %s", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+
+	client.ipRanges = networks
+	return networks, nil
+}
+
+// checkProxiedRecordTarget catches proxied A and AAAA records whose value is
+// itself a Cloudflare IP. Cloudflare would proxy requests back to its own
+// edge, which loops. It warns unless the provider is set to fail instead.
+func (client *CloudFlareClient) checkProxiedRecordTarget(record cloudflare.DNSRecord) error {
+	if !record.Proxied || (record.Type != "A" && record.Type != "AAAA") {
+		return nil
+	}
+
+	ranges, err := client.cloudflareIPRanges()
+	if err != nil {
+		log.Printf("[WARN] Could not fetch the Cloudflare IP ranges to check record %q: %s", record.Name, err)
+		return nil
+	}
+
+	if !isCloudflareIP(record.Content, ranges) {
+		return nil
+	}
+
+	if client.errorOnProxyLoop {
+		return fmt.Errorf("Record %q is proxied but its value %s is a Cloudflare IP, which would proxy requests back to Cloudflare. "+
+			"Point it at the origin instead, or turn off proxied", record.Name, record.Content)
+	}
+	log.Printf("[WARN] Record %q is proxied but its value %s is a Cloudflare IP, which would proxy requests back to Cloudflare",
+		record.Name, record.Content)
+	return nil
+}
+
+func isCloudflareIP(value string, ranges []*net.IPNet) bool {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range ranges {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
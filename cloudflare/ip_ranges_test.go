@@ -0,0 +1,74 @@
+package cloudflare
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestCheckProxiedRecordTarget(t *testing.T) {
+	var ranges []*net.IPNet
+	for _, cidr := range []string{"104.16.0.0/13", "2606:4700::/32"} {
+		_, network, _ := net.ParseCIDR(cidr)
+		ranges = append(ranges, network)
+	}
+
+	cases := []struct {
+		record cloudflare.DNSRecord
+		loop   bool
+	}{
+		{cloudflare.DNSRecord{Name: "www.example.com", Type: "A", Content: "104.16.132.229", Proxied: true}, true},
+		{cloudflare.DNSRecord{Name: "www.example.com", Type: "AAAA", Content: "2606:4700::6810:84e5", Proxied: true}, true},
+		{cloudflare.DNSRecord{Name: "www.example.com", Type: "A", Content: "192.0.2.10", Proxied: true}, false},
+		{cloudflare.DNSRecord{Name: "www.example.com", Type: "A", Content: "104.16.132.229", Proxied: false}, false},
+	}
+
+	for _, errorOnProxyLoop := range []bool{false, true} {
+		// The IP ranges are cached, so none are fetched here.
+		client := &CloudFlareClient{errorOnProxyLoop: errorOnProxyLoop, ipRanges: ranges}
+
+		for _, c := range cases {
+			err := client.checkProxiedRecordTarget(c.record)
+			if shouldFail := c.loop && errorOnProxyLoop; shouldFail != (err != nil) {
+				t.Fatalf("%s (proxied: %t, error_on_proxy_loop: %t): unexpected result %v",
+					c.record.Content, c.record.Proxied, errorOnProxyLoop, err)
+			}
+		}
+	}
+}
+
+func TestCloudflareIPRanges(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/ips" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL)
+		}
+		writeTestResult(w, cloudflare.IPRanges{
+			IPv4CIDRs: []string{"104.16.0.0/13"},
+			IPv6CIDRs: []string{"2606:4700::/32"},
+		})
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		ranges, err := client.cloudflareIPRanges()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if !isCloudflareIP("104.16.132.229", ranges) || !isCloudflareIP("2606:4700::6810:84e5", ranges) {
+			t.Fatalf("expected the ranges of the API, got %v", ranges)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected the ranges to be fetched once, got %d requests", requests)
+	}
+}
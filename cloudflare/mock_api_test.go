@@ -0,0 +1,302 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const mockZoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+// testAccRecordAPI sets up the API record acceptance tests run against.
+// With TF_ACC set they run against the zone in CLOUDFLARE_DOMAIN, whose ID
+// is CLOUDFLARE_ZONE_ID, as usual.
+// Otherwise they run with the unit tests, against a mock of the zones and
+// DNS records endpoints serving the zone example.com. It returns the domain
+// to test against, whether the test case is a unit test, and a func to call
+// once the test is done.
+func testAccRecordAPI(t *testing.T) (string, bool, func()) {
+	if os.Getenv(resource.TestEnvVar) != "" {
+		return os.Getenv("CLOUDFLARE_DOMAIN"), false, func() {}
+	}
+
+	api := newMockDNSAPI(t, "example.com")
+	ts := httptest.NewServer(api)
+
+	env := map[string]string{
+		"CLOUDFLARE_EMAIL":        "terraform@example.com",
+		"CLOUDFLARE_TOKEN":        "mock",
+		"CLOUDFLARE_DOMAIN":       api.domain,
+		"CLOUDFLARE_ZONE_ID":      mockZoneID,
+		"CLOUDFLARE_API_BASE_URL": ts.URL,
+	}
+	previous := make(map[string]string, len(env))
+	for k, v := range env {
+		previous[k] = os.Getenv(k)
+		os.Setenv(k, v)
+	}
+
+	return api.domain, true, func() {
+		for k, v := range previous {
+			os.Setenv(k, v)
+		}
+		ts.Close()
+	}
+}
+
+// mockDNSAPI is an in-memory stand-in for the parts of the API the record
+// resource uses. Its zone is on the free plan and flattens CNAMEs at the
+// apex only. Like the API, it forces the TTL of proxied records to 1
+// (automatic), replaces a record entirely on PUT and only its comment and
+// tags on PATCH, reports the CNAMEs its zone flattens as flattened, composes
+// the name, content and priority of SRV records, and the content of CAA
+// records, from their data, requires MX records to have a priority, trims
+// whitespace around contents, canonicalizes IPv6 addresses and the hostnames
+// records point to, splits TXT values longer than a string into strings of
+// its own, and rejects creating records that conflict with existing ones. It
+// lists records a page at a time, and stamps records with when they were
+// created and last modified.
+type mockDNSAPI struct {
+	t      *testing.T
+	domain string
+
+	mu      sync.Mutex
+	records map[string]dnsRecord
+	nextID  int
+}
+
+func newMockDNSAPI(t *testing.T, domain string) *mockDNSAPI {
+	return &mockDNSAPI{
+		t:       t,
+		domain:  domain,
+		records: make(map[string]dnsRecord),
+	}
+}
+
+func (api *mockDNSAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	recordsPath := "/zones/" + mockZoneID + "/dns_records"
+	switch {
+	case r.URL.Path == "/zones" && r.Method == "GET":
+		zones := []cloudflare.Zone{}
+		if name := r.URL.Query().Get("name"); name == "" || name == api.domain {
+			zones = append(zones, cloudflare.Zone{ID: mockZoneID, Name: api.domain})
+		}
+		writeTestResult(w, zones)
+
+	case r.URL.Path == "/zones/"+mockZoneID && r.Method == "GET":
+		writeTestResult(w, cloudflare.Zone{ID: mockZoneID, Name: api.domain, Plan: cloudflare.ZonePlan{LegacyID: "free"}})
+
+	case r.URL.Path == "/ips" && r.Method == "GET":
+		writeTestResult(w, cloudflare.IPRanges{
+			IPv4CIDRs: []string{"104.16.0.0/13", "172.64.0.0/13"},
+			IPv6CIDRs: []string{"2606:4700::/32"},
+		})
+
+	case r.URL.Path == "/zones/"+mockZoneID+"/settings/cname_flattening" && r.Method == "GET":
+		writeTestResult(w, map[string]string{"id": "cname_flattening", "value": cnameFlatteningAtRoot})
+
+	case r.URL.Path == recordsPath && r.Method == "GET":
+		api.listRecords(w, r)
+
+	case r.URL.Path == recordsPath && r.Method == "POST":
+		var record dnsRecord
+		if !api.decode(w, r, &record) || !api.validate(w, record) || !api.checkConflicts(w, record) {
+			return
+		}
+		api.nextID++
+		record.ID = fmt.Sprintf("%032x", api.nextID)
+		writeTestResult(w, api.save(record))
+
+	case strings.HasPrefix(r.URL.Path, recordsPath+"/"):
+		id := strings.TrimPrefix(r.URL.Path, recordsPath+"/")
+		record, ok := api.records[id]
+		if !ok {
+			writeMockError(w, http.StatusNotFound, recordNotFoundErrorCode, "Invalid dns record identifier")
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			writeTestResult(w, record)
+		case "PUT":
+			var update dnsRecord
+			if !api.decode(w, r, &update) || !api.validate(w, update) {
+				return
+			}
+			update.ID = id
+			writeTestResult(w, api.save(update))
+		case "PATCH":
+			// The record resource only patches comments and tags.
+			var patch recordMetadata
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				writeMockError(w, http.StatusBadRequest, 9207, "Request body is invalid JSON")
+				return
+			}
+			record.Comment, record.Tags = "", patch.Tags
+			if patch.Comment != nil {
+				record.Comment = *patch.Comment
+			}
+			writeTestResult(w, api.save(record))
+		case "DELETE":
+			delete(api.records, id)
+			writeTestResult(w, map[string]string{"id": id})
+		default:
+			writeMockError(w, http.StatusMethodNotAllowed, 10000, "Method not allowed")
+		}
+
+	default:
+		api.t.Logf("mock API: unexpected request %s %s", r.Method, r.URL)
+		writeMockError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+	}
+}
+
+func (api *mockDNSAPI) listRecords(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	records := []dnsRecord{}
+	for _, record := range api.records {
+		if name := query.Get("name"); name != "" && name != record.Name {
+			continue
+		}
+		if recordType := query.Get("type"); recordType != "" && recordType != record.Type {
+			continue
+		}
+		if content := query.Get("content"); content != "" && content != record.Content {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	// Like the API, records are listed a page at a time, 20 by default.
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	perPage, page := 20, 1
+	if v, err := strconv.Atoi(query.Get("per_page")); err == nil {
+		perPage = v
+	}
+	if v, err := strconv.Atoi(query.Get("page")); err == nil {
+		page = v
+	}
+	start, end := (page-1)*perPage, page*perPage
+	if start > len(records) {
+		start = len(records)
+	}
+	if end > len(records) {
+		end = len(records)
+	}
+	writeTestResult(w, records[start:end])
+}
+
+func (api *mockDNSAPI) decode(w http.ResponseWriter, r *http.Request, record *dnsRecord) bool {
+	if err := json.NewDecoder(r.Body).Decode(record); err != nil {
+		writeMockError(w, http.StatusBadRequest, 9207, "Request body is invalid JSON")
+		return false
+	}
+	return true
+}
+
+// validate rejects the records the API would, as far as the tests need.
+func (api *mockDNSAPI) validate(w http.ResponseWriter, record dnsRecord) bool {
+	if record.Type == "MX" && record.Priority == nil {
+		writeMockError(w, http.StatusBadRequest, 9100, "DNS Validation Error: priority is required for MX records")
+		return false
+	}
+	return true
+}
+
+// checkConflicts rejects records identical to an existing record, and
+// records sharing their name with a CNAME, like the API.
+func (api *mockDNSAPI) checkConflicts(w http.ResponseWriter, record dnsRecord) bool {
+	for _, existing := range api.records {
+		if existing.Name != record.Name {
+			continue
+		}
+		if existing.Type == "CNAME" || record.Type == "CNAME" {
+			writeMockError(w, http.StatusBadRequest, recordConflictErrorCode, "An A, AAAA, or CNAME record with that host already exists.")
+			return false
+		}
+		if existing.Type == record.Type && existing.Content == strings.TrimSpace(record.Content) && record.Data == nil {
+			writeMockError(w, http.StatusBadRequest, recordExistsErrorCode, "The record already exists.")
+			return false
+		}
+	}
+	return true
+}
+
+func (api *mockDNSAPI) save(record dnsRecord) dnsRecord {
+	record.Content = strings.TrimSpace(record.Content)
+	switch record.Type {
+	case "AAAA":
+		if ip := net.ParseIP(record.Content); ip != nil {
+			record.Content = ip.String()
+		}
+	case "CNAME", "MX", "NS", "PTR":
+		record.Content = strings.ToLower(strings.TrimSuffix(record.Content, "."))
+	case "TXT":
+		if text := txtValue(record.Content); len(text) > maxTXTStringLength {
+			var quoted []string
+			for len(text) > 0 {
+				n := maxTXTStringLength
+				if len(text) < n {
+					n = len(text)
+				}
+				quoted = append(quoted, strconv.Quote(text[:n]))
+				text = text[n:]
+			}
+			record.Content = strings.Join(quoted, " ")
+		}
+	}
+	record.ZoneID = mockZoneID
+	record.ZoneName = api.domain
+	record.Proxiable = record.Type == "A" || record.Type == "AAAA" || record.Type == "CNAME"
+	if record.Proxied || record.TTL == 0 {
+		record.TTL = 1
+	}
+	if record.Type == "SRV" && record.Data != nil {
+		raw, _ := json.Marshal(record.Data)
+		var data srvRecordData
+		if err := json.Unmarshal(raw, &data); err != nil {
+			api.t.Errorf("mock API: invalid SRV data %s: %s", raw, err)
+		}
+		record.Name = data.Service + "." + data.Proto + "." + data.Name
+		record.Content = fmt.Sprintf("%d %d %s", data.Weight, data.Port, data.Target)
+		record.Priority = &data.Priority
+	}
+	if record.Type == "CAA" && record.Data != nil {
+		raw, _ := json.Marshal(record.Data)
+		var data caaRecordData
+		if err := json.Unmarshal(raw, &data); err != nil {
+			api.t.Errorf("mock API: invalid CAA data %s: %s", raw, err)
+		}
+		record.Content = fmt.Sprintf("%d %s %q", data.Flags, data.Tag, data.Value)
+	}
+	// CNAMEs that the zone flattens read back as flattened.
+	if record.Type == "CNAME" && record.Name == api.domain {
+		record.Settings = &dnsRecordSettings{FlattenCNAME: true}
+	}
+	record.ModifiedOn = time.Now().UTC()
+	record.CreatedOn = record.ModifiedOn
+	if existing, ok := api.records[record.ID]; ok {
+		record.CreatedOn = existing.CreatedOn
+	}
+	api.records[record.ID] = record
+	return record
+}
+
+func writeMockError(w http.ResponseWriter, status, code int, message string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"success": false, "errors": [{"code": %d, "message": %q}], "messages": [], "result": null}`, code, message)
+}
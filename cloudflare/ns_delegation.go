@@ -0,0 +1,67 @@
+package cloudflare
+
+import (
+	"log"
+	"net/url"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// zoneDNSSECStatus returns the DNSSEC status of the zone, e.g. "active". It is
+// fetched once per zone and provider run.
+func (client *CloudFlareClient) zoneDNSSECStatus(zoneID string) (string, error) {
+	client.dnssecStatusMu.Lock()
+	defer client.dnssecStatusMu.Unlock()
+
+	if status, ok := client.dnssecStatus[zoneID]; ok {
+		return status, nil
+	}
+
+	var dnssec zoneDNSSEC
+	if err := client.apiRequest("GET", "/zones/"+zoneID+"/dnssec", nil, &dnssec); err != nil {
+		return "", err
+	}
+
+	if client.dnssecStatus == nil {
+		client.dnssecStatus = make(map[string]string)
+	}
+	client.dnssecStatus[zoneID] = dnssec.Status
+	return dnssec.Status, nil
+}
+
+// checkNSDelegation warns about NS records that delegate a subdomain of a
+// zone signed with DNSSEC when the zone has no DS record for the subdomain.
+// Resolvers that validate DNSSEC treat such a delegation as bogus, so the
+// subdomain stops resolving for them.
+func (client *CloudFlareClient) checkNSDelegation(record cloudflare.DNSRecord) {
+	if record.Type != "NS" {
+		return
+	}
+
+	missing, err := client.nsDelegationMissingDS(record)
+	if err != nil {
+		log.Printf("[WARN] Could not check the DS records of delegated subdomain %q: %s", record.Name, err)
+		return
+	}
+	if missing {
+		log.Printf("[WARN] Zone %q has DNSSEC enabled but no DS record for %q. Add a DS record for the delegated "+
+			"subdomain, or it won't resolve for resolvers that validate DNSSEC", record.ZoneName, record.Name)
+	}
+}
+
+func (client *CloudFlareClient) nsDelegationMissingDS(record cloudflare.DNSRecord) (bool, error) {
+	status, err := client.zoneDNSSECStatus(record.ZoneID)
+	if err != nil || status != "active" {
+		return false, err
+	}
+
+	query := url.Values{}
+	query.Set("type", "DS")
+	query.Set("name", record.Name)
+
+	var records []cloudflare.DNSRecord
+	if err := client.apiRequest("GET", "/zones/"+record.ZoneID+"/dns_records?"+query.Encode(), nil, &records); err != nil {
+		return false, err
+	}
+	return len(records) == 0, nil
+}
@@ -0,0 +1,62 @@
+package cloudflare
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestNSDelegationMissingDS(t *testing.T) {
+	cases := []struct {
+		dnssec  string
+		ds      []cloudflare.DNSRecord
+		missing bool
+	}{
+		{"active", nil, true},
+		{"active", []cloudflare.DNSRecord{{Type: "DS", Name: "sub.example.com"}}, false},
+		{"disabled", nil, false},
+	}
+
+	for _, c := range cases {
+		var dnssecRequests int
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/zones/zone/dnssec", func(w http.ResponseWriter, r *http.Request) {
+			dnssecRequests++
+			writeTestResult(w, zoneDNSSEC{Status: c.dnssec})
+		})
+		mux.HandleFunc("/zones/zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+			if c.dnssec != "active" {
+				t.Fatalf("DS records should only be listed when DNSSEC is active")
+			}
+			if got := r.URL.Query().Get("name"); got != "sub.example.com" {
+				t.Fatalf("expected DS records of sub.example.com, got %q", got)
+			}
+			writeTestResult(w, c.ds)
+		})
+		ts := httptest.NewServer(mux)
+
+		client, err := testClient(ts.URL)
+		if err != nil {
+			t.Fatalf("Error building CloudFlare API: %s", err)
+		}
+
+		record := cloudflare.DNSRecord{Type: "NS", Name: "sub.example.com", ZoneID: "zone", ZoneName: "example.com"}
+		for i := 0; i < 2; i++ {
+			missing, err := client.nsDelegationMissingDS(record)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if missing != c.missing {
+				t.Fatalf("DNSSEC %s with %d DS records: expected missing %t, got %t", c.dnssec, len(c.ds), c.missing, missing)
+			}
+		}
+		if dnssecRequests != 1 {
+			t.Fatalf("expected the DNSSEC status to be fetched once, got %d requests", dnssecRequests)
+		}
+
+		ts.Close()
+	}
+}
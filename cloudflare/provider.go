@@ -1,41 +1,213 @@
 package cloudflare
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"email": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CLOUDFLARE_EMAIL", nil),
-				Description: "A registered CloudFlare email address.",
+				Description: "A registered CloudFlare email address. Required with token, unless api_token is set.",
 			},
 
 			"token": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CLOUDFLARE_TOKEN", nil),
-				Description: "The token key for API operations.",
+				Description: "The global API key for API operations. Required with email, unless api_token is set.",
+			},
+
+			"api_token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDFLARE_API_TOKEN", nil),
+				Description: "A scoped API token for API operations. Takes precedence over email and token.",
+			},
+
+			"batch_record_writes": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send DNS record writes to the same zone in batches.",
+			},
+
+			"retry_status_codes": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validateHTTPErrorStatusCode,
+				},
+				Description: "The HTTP status codes of API responses to retry. Defaults to 429, 500, 502, 503 and 504.",
+			},
+
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validateMaxRetries,
+				Description:  "How many times a request is retried, with exponential backoff, before its last response is returned.",
+			},
+
+			"error_on_proxy_loop": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail, rather than warn, when a proxied record points at a Cloudflare IP.",
+			},
+
+			"default_proxied": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether records that don't set proxied are proxied.",
+			},
+
+			"default_proxied_by_zone": &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         schema.TypeBool,
+				ValidateFunc: validateDomainKeys,
+				Description:  "Overrides default_proxied for the records of the given zones, keyed by domain.",
 			},
+
+			"dns_conflict_strategy": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      dnsConflictError,
+				ValidateFunc: validateDNSConflictStrategy,
+				Description:  "What creating a record that conflicts with an existing one does: error, adopt or recreate.",
+			},
+
+			"api_base_url": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDFLARE_API_BASE_URL", nil),
+				ValidateFunc: validateAPIBaseURL,
+				Description:  "The URL of the API, e.g. a regional endpoint. Defaults to https://api.cloudflare.com/client/v4.",
+			},
+
+			"user_agent_suffix": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDFLARE_USER_AGENT_SUFFIX", nil),
+				Description: "Appended to the User-Agent of API requests, after terraform-provider-cloudflare/<version>, to identify them further.",
+			},
+
+			"insecure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip verifying the API's TLS certificate. Only for testing through TLS-intercepting proxies.",
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"cloudflare_dns_record":                dataSourceCloudFlareDNSRecord(),
+			"cloudflare_ip_ranges":                 dataSourceCloudFlareIPRanges(),
+			"cloudflare_logpush_destination_check": dataSourceCloudFlareLogpushDestinationCheck(),
+			"cloudflare_record":                    dataSourceCloudFlareRecord(),
+			"cloudflare_records":                   dataSourceCloudFlareRecords(),
+			"cloudflare_verify_token":              dataSourceCloudFlareVerifyToken(),
+			"cloudflare_zero_trust_access_group":   dataSourceCloudFlareZeroTrustAccessGroup(),
+			"cloudflare_zones":                     dataSourceCloudFlareZones(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"cloudflare_record": resourceCloudFlareRecord(),
+			"cloudflare_address_map":                                     resourceCloudFlareAddressMap(),
+			"cloudflare_filter":                                          resourceCloudFlareFilter(),
+			"cloudflare_firewall_rule":                                   resourceCloudFlareFirewallRule(),
+			"cloudflare_load_balancer":                                   resourceCloudFlareLoadBalancer(),
+			"cloudflare_load_balancer_monitor":                           resourceCloudFlareLoadBalancerMonitor(),
+			"cloudflare_load_balancer_pool":                              resourceCloudFlareLoadBalancerPool(),
+			"cloudflare_logpush_job":                                     resourceCloudFlareLogpushJob(),
+			"cloudflare_magic_wan_ipsec_tunnel":                          resourceCloudFlareMagicWANIPsecTunnel(),
+			"cloudflare_page_rule":                                       resourceCloudFlarePageRule(),
+			"cloudflare_rate_limit":                                      resourceCloudFlareRateLimit(),
+			"cloudflare_registrar_domain":                                resourceCloudFlareRegistrarDomain(),
+			"cloudflare_snippet":                                         resourceCloudFlareSnippet(),
+			"cloudflare_workers_for_platforms_dispatch_namespace":        resourceCloudFlareWorkersForPlatformsDispatchNamespace(),
+			"cloudflare_zero_trust_access_application":                   resourceCloudFlareZeroTrustAccessApplication(),
+			"cloudflare_zero_trust_access_application_policy_attachment": resourceCloudFlareZeroTrustAccessApplicationPolicyAttachment(),
+			"cloudflare_zero_trust_access_custom_page":                   resourceCloudFlareZeroTrustAccessCustomPage(),
+			"cloudflare_zero_trust_access_group":                         resourceCloudFlareZeroTrustAccessGroup(),
+			"cloudflare_zero_trust_access_infrastructure_target":         resourceCloudFlareZeroTrustAccessInfrastructureTarget(),
+			"cloudflare_zero_trust_access_key_configuration":             resourceCloudFlareZeroTrustAccessKeyConfiguration(),
+			"cloudflare_zero_trust_access_mutual_tls_hostname_settings":  resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettings(),
+			"cloudflare_zero_trust_access_policy":                        resourceCloudFlareZeroTrustAccessPolicy(),
+			"cloudflare_zero_trust_access_service_token":                 resourceCloudFlareZeroTrustAccessServiceToken(),
+			"cloudflare_zero_trust_access_tag":                           resourceCloudFlareZeroTrustAccessTag(),
+			"cloudflare_zero_trust_device_custom_profile":                resourceCloudFlareZeroTrustDeviceCustomProfile(),
+			"cloudflare_zero_trust_device_default_profile":               resourceCloudFlareZeroTrustDeviceDefaultProfile(),
+			"cloudflare_zero_trust_device_managed_networks":              resourceCloudFlareZeroTrustDeviceManagedNetworks(),
+			"cloudflare_zero_trust_dex_test":                             resourceCloudFlareZeroTrustDEXTest(),
+			"cloudflare_zero_trust_dlp_profile":                          resourceCloudFlareZeroTrustDLPProfile(),
+			"cloudflare_zero_trust_dns_location":                         resourceCloudFlareZeroTrustDNSLocation(),
+			"cloudflare_zero_trust_gateway_certificate":                  resourceCloudFlareZeroTrustGatewayCertificate(),
+			"cloudflare_zero_trust_gateway_logging":                      resourceCloudFlareZeroTrustGatewayLogging(),
+			"cloudflare_zero_trust_gateway_policy":                       resourceCloudFlareZeroTrustGatewayPolicy(),
+			"cloudflare_zero_trust_gateway_proxy_endpoint":               resourceCloudFlareZeroTrustGatewayProxyEndpoint(),
+			"cloudflare_zero_trust_gateway_settings":                     resourceCloudFlareZeroTrustGatewaySettings(),
+			"cloudflare_zero_trust_list":                                 resourceCloudFlareZeroTrustList(),
+			"cloudflare_zero_trust_organization":                         resourceCloudFlareZeroTrustOrganization(),
+			"cloudflare_zero_trust_risk_behavior":                        resourceCloudFlareZeroTrustRiskBehavior(),
+			"cloudflare_zero_trust_risk_score_integration":               resourceCloudFlareZeroTrustRiskScoreIntegration(),
+			"cloudflare_zero_trust_tunnel_cloudflared_route":             resourceCloudFlareZeroTrustTunnelCloudflaredRoute(),
+			"cloudflare_zero_trust_tunnel_virtual_network":               resourceCloudFlareZeroTrustTunnelVirtualNetwork(),
+			"cloudflare_zone":                                            resourceCloudFlareZone(),
+			"cloudflare_zone_dnssec":                                     resourceCloudFlareZoneDNSSEC(),
+			"cloudflare_zone_security_header":                            resourceCloudFlareZoneSecurityHeader(),
+			"cloudflare_zone_settings_override":                          resourceCloudFlareZoneSettingsOverride(),
+			"cloudflare_zone_subscription":                               resourceCloudFlareZoneSubscription(),
 		},
 
 		ConfigureFunc: providerConfigure,
 	}
+
+	// Records that leave proxied unset are proxied according to the
+	// provider's defaults, which diffing their ttl needs.
+	provider.ResourcesMap["cloudflare_record"] = resourceCloudFlareRecordOf(provider)
+	return provider
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
-		Email: d.Get("email").(string),
-		Token: d.Get("token").(string),
+		Email:               d.Get("email").(string),
+		Token:               d.Get("token").(string),
+		APIToken:            d.Get("api_token").(string),
+		BatchRecordWrites:   d.Get("batch_record_writes").(bool),
+		MaxRetries:          d.Get("max_retries").(int),
+		ErrorOnProxyLoop:    d.Get("error_on_proxy_loop").(bool),
+		DefaultProxied:      d.Get("default_proxied").(bool),
+		DNSConflictStrategy: d.Get("dns_conflict_strategy").(string),
+		Insecure:            d.Get("insecure").(bool),
+		APIBaseURL:          d.Get("api_base_url").(string),
+		UserAgentSuffix:     d.Get("user_agent_suffix").(string),
+	}
+
+	if v, ok := d.GetOk("default_proxied_by_zone"); ok {
+		config.DefaultProxiedByZone = make(map[string]bool)
+		for domain, proxied := range v.(map[string]interface{}) {
+			b, err := parseBool(proxied)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing default_proxied_by_zone for %q: %s", domain, err)
+			}
+			config.DefaultProxiedByZone[domain] = b
+		}
+	}
+
+	if v, ok := d.GetOk("retry_status_codes"); ok {
+		for _, code := range v.([]interface{}) {
+			config.RetryStatusCodes = append(config.RetryStatusCodes, code.(int))
+		}
 	}
 
 	return config.Client()
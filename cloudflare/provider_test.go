@@ -29,15 +29,29 @@ func TestProvider_impl(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
-	if v := os.Getenv("CLOUDFLARE_EMAIL"); v == "" {
-		t.Fatal("CLOUDFLARE_EMAIL must be set for acceptance tests")
-	}
-
-	if v := os.Getenv("CLOUDFLARE_TOKEN"); v == "" {
-		t.Fatal("CLOUDFLARE_TOKEN must be set for acceptance tests")
+	if v := os.Getenv("CLOUDFLARE_API_TOKEN"); v == "" {
+		if v := os.Getenv("CLOUDFLARE_EMAIL"); v == "" {
+			t.Fatal("CLOUDFLARE_EMAIL must be set for acceptance tests, unless CLOUDFLARE_API_TOKEN is")
+		}
+
+		if v := os.Getenv("CLOUDFLARE_TOKEN"); v == "" {
+			t.Fatal("CLOUDFLARE_TOKEN must be set for acceptance tests, unless CLOUDFLARE_API_TOKEN is")
+		}
 	}
 
 	if v := os.Getenv("CLOUDFLARE_DOMAIN"); v == "" {
 		t.Fatal("CLOUDFLARE_DOMAIN must be set for acceptance tests. The domain is used to create and destroy record against.")
 	}
 }
+
+func testAccPreCheckZoneID(t *testing.T) {
+	if v := os.Getenv("CLOUDFLARE_ZONE_ID"); v == "" {
+		t.Fatal("CLOUDFLARE_ZONE_ID must be set for this acceptance test. The zone is used to manage zone-level settings against.")
+	}
+}
+
+func testAccPreCheckAccount(t *testing.T) {
+	if v := os.Getenv("CLOUDFLARE_ACCOUNT_ID"); v == "" {
+		t.Fatal("CLOUDFLARE_ACCOUNT_ID must be set for this acceptance test. The account is used to manage account-level resources against.")
+	}
+}
@@ -0,0 +1,296 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// When batch_record_writes is set, record creates, updates and deletes are
+// not sent one by one. Terraform applies resources concurrently, so writes
+// to the same zone that arrive within recordBatchWindow of each other are
+// sent together to the batch DNS records endpoint, and each resource waits
+// for its own result.
+const (
+	recordBatchWindow  = 250 * time.Millisecond
+	recordBatchMaxSize = 200
+)
+
+// createDNSRecord creates record, as part of a batch if batching is on.
+func (client *CloudFlareClient) createDNSRecord(zoneID string, record dnsRecord) (dnsRecord, error) {
+	if client.recordBatcher != nil {
+		return client.recordBatcher.CreateDNSRecord(zoneID, record)
+	}
+
+	return client.writeDNSRecord(zoneID, "POST", record)
+}
+
+// updateDNSRecord replaces the record with recordID, as part of a batch if
+// batching is on, and returns it as updated.
+func (client *CloudFlareClient) updateDNSRecord(zoneID, recordID string, record dnsRecord) (dnsRecord, error) {
+	record.ID = recordID
+	if client.recordBatcher != nil {
+		return client.recordBatcher.UpdateDNSRecord(zoneID, record)
+	}
+	return client.writeDNSRecord(zoneID, "PUT", record)
+}
+
+// updateDNSRecordMetadata sets only the comment and tags of the record with
+// recordID, as part of a batch if batching is on, and returns it as updated.
+func (client *CloudFlareClient) updateDNSRecordMetadata(zoneID, recordID, comment string, tags []string) (dnsRecord, error) {
+	record := recordWithID(recordID)
+	record.Comment, record.Tags = comment, tags
+	if client.recordBatcher != nil {
+		return client.recordBatcher.UpdateDNSRecordMetadata(zoneID, record)
+	}
+	return client.writeDNSRecord(zoneID, "PATCH", record)
+}
+
+// deleteDNSRecord deletes the record with recordID, as part of a batch if
+// batching is on.
+func (client *CloudFlareClient) deleteDNSRecord(zoneID, recordID string) error {
+	if client.recordBatcher != nil {
+		return client.recordBatcher.DeleteDNSRecord(zoneID, recordID)
+	}
+	_, err := client.writeDNSRecord(zoneID, "DELETE", recordWithID(recordID))
+	return err
+}
+
+// DNSRecord shadows cloudflare-go's, going through apiRequest so that
+// failures carry the API's error codes and the Ray ID of their response.
+func (client *CloudFlareClient) DNSRecord(zoneID, recordID string) (cloudflare.DNSRecord, error) {
+	var record cloudflare.DNSRecord
+	err := client.apiRequest("GET", "/zones/"+zoneID+"/dns_records/"+recordID, nil, &record)
+	return record, err
+}
+
+// writeDNSRecord sends a single record write. It goes through apiRequest
+// rather than cloudflare-go so that failures carry the API's error codes.
+func (client *CloudFlareClient) writeDNSRecord(zoneID, method string, record dnsRecord) (dnsRecord, error) {
+	uri := "/zones/" + zoneID + "/dns_records"
+	if method != "POST" {
+		uri += "/" + record.ID
+	}
+
+	var params interface{}
+	switch method {
+	case "PATCH":
+		params = recordMetadataPatch(record)
+	case "DELETE":
+	default:
+		params = record
+	}
+
+	var written dnsRecord
+	if err := client.apiRequest(method, uri, params, &written); err != nil {
+		return dnsRecord{}, err
+	}
+	return written, nil
+}
+
+// recordMetadata is the body of a PATCH of a record's comment and tags.
+// Unlike dnsRecord, it sends them even when empty, as a PATCH leaves out
+// fields unchanged rather than clearing them.
+type recordMetadata struct {
+	ID      string   `json:"id,omitempty"`
+	Comment *string  `json:"comment"`
+	Tags    []string `json:"tags"`
+}
+
+func recordMetadataPatch(record dnsRecord) recordMetadata {
+	patch := recordMetadata{ID: record.ID, Tags: record.Tags}
+	if record.Comment != "" {
+		patch.Comment = &record.Comment
+	}
+	if patch.Tags == nil {
+		patch.Tags = []string{}
+	}
+	return patch
+}
+
+// recordBatch is the body of a batch request. Cloudflare applies deletes,
+// then patches, then puts, then posts.
+type recordBatch struct {
+	Deletes []dnsRecord      `json:"deletes,omitempty"`
+	Patches []recordMetadata `json:"patches,omitempty"`
+	Puts    []dnsRecord      `json:"puts,omitempty"`
+	Posts   []dnsRecord      `json:"posts,omitempty"`
+}
+
+// recordBatchResult is the result of a batch request.
+type recordBatchResult struct {
+	Deletes []dnsRecord `json:"deletes"`
+	Patches []dnsRecord `json:"patches"`
+	Puts    []dnsRecord `json:"puts"`
+	Posts   []dnsRecord `json:"posts"`
+}
+
+// recordWrite is a single write waiting to be sent as part of a batch.
+type recordWrite struct {
+	method string
+	record dnsRecord
+	done   chan recordWriteResult
+}
+
+type recordWriteResult struct {
+	record dnsRecord
+	err    error
+}
+
+type recordBatcher struct {
+	client *CloudFlareClient
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[string][]*recordWrite
+}
+
+func newRecordBatcher(client *CloudFlareClient, window time.Duration) *recordBatcher {
+	return &recordBatcher{
+		client:  client,
+		window:  window,
+		pending: make(map[string][]*recordWrite),
+	}
+}
+
+// CreateDNSRecord queues the creation of record and returns it as created.
+func (b *recordBatcher) CreateDNSRecord(zoneID string, record dnsRecord) (dnsRecord, error) {
+	return b.write(zoneID, "POST", record)
+}
+
+// UpdateDNSRecord queues the replacement of the record with record.ID and
+// returns it as updated.
+func (b *recordBatcher) UpdateDNSRecord(zoneID string, record dnsRecord) (dnsRecord, error) {
+	return b.write(zoneID, "PUT", record)
+}
+
+// UpdateDNSRecordMetadata queues setting the comment and tags of the record
+// with record.ID and returns it as updated.
+func (b *recordBatcher) UpdateDNSRecordMetadata(zoneID string, record dnsRecord) (dnsRecord, error) {
+	return b.write(zoneID, "PATCH", record)
+}
+
+// DeleteDNSRecord queues the deletion of the record with recordID.
+func (b *recordBatcher) DeleteDNSRecord(zoneID, recordID string) error {
+	_, err := b.write(zoneID, "DELETE", recordWithID(recordID))
+	return err
+}
+
+func (b *recordBatcher) write(zoneID, method string, record dnsRecord) (dnsRecord, error) {
+	w := &recordWrite{
+		method: method,
+		record: record,
+		done:   make(chan recordWriteResult, 1),
+	}
+
+	b.mu.Lock()
+	b.pending[zoneID] = append(b.pending[zoneID], w)
+	switch len(b.pending[zoneID]) {
+	case 1:
+		time.AfterFunc(b.window, func() { b.flush(zoneID) })
+	case recordBatchMaxSize:
+		go b.flush(zoneID)
+	}
+	b.mu.Unlock()
+
+	result := <-w.done
+	return result.record, result.err
+}
+
+// flush sends the writes pending for zoneID.
+func (b *recordBatcher) flush(zoneID string) {
+	b.mu.Lock()
+	writes := b.pending[zoneID]
+	delete(b.pending, zoneID)
+	b.mu.Unlock()
+
+	if len(writes) == 0 {
+		return
+	}
+	if len(writes) == 1 {
+		writes[0].done <- b.writeOne(zoneID, writes[0])
+		return
+	}
+
+	var batch recordBatch
+	var deletes, patches, puts, posts []*recordWrite
+	for _, w := range writes {
+		switch w.method {
+		case "DELETE":
+			batch.Deletes = append(batch.Deletes, recordWithID(w.record.ID))
+			deletes = append(deletes, w)
+		case "PATCH":
+			batch.Patches = append(batch.Patches, recordMetadataPatch(w.record))
+			patches = append(patches, w)
+		case "PUT":
+			batch.Puts = append(batch.Puts, w.record)
+			puts = append(puts, w)
+		case "POST":
+			batch.Posts = append(batch.Posts, w.record)
+			posts = append(posts, w)
+		}
+	}
+
+	log.Printf("[DEBUG] CloudFlare Record batch for zone %s: %d deletes, %d patches, %d puts, %d posts",
+		zoneID, len(deletes), len(patches), len(puts), len(posts))
+
+	var result recordBatchResult
+	err := b.client.apiRequest("POST", "/zones/"+zoneID+"/dns_records/batch", batch, &result)
+	if isBatchRejected(err) {
+		// A batch is applied all or nothing, and its error doesn't say which
+		// write caused it. Send the writes one by one instead so that each
+		// resource gets its own result.
+		log.Printf("[WARN] CloudFlare Record batch for zone %s was rejected, writing records one by one: %s", zoneID, err)
+		for _, w := range writes {
+			w.done <- b.writeOne(zoneID, w)
+		}
+		return
+	}
+	if err != nil {
+		// The batch may have been applied anyway, so writing the records
+		// again could create them twice.
+		for _, w := range writes {
+			w.done <- recordWriteResult{err: err}
+		}
+		return
+	}
+
+	if len(result.Deletes) != len(deletes) || len(result.Patches) != len(patches) ||
+		len(result.Puts) != len(puts) || len(result.Posts) != len(posts) {
+		err := fmt.Errorf("Unexpected batch response for zone %q: got %d deletes, %d patches, %d puts and %d posts",
+			zoneID, len(result.Deletes), len(result.Patches), len(result.Puts), len(result.Posts))
+		for _, w := range writes {
+			w.done <- recordWriteResult{err: err}
+		}
+		return
+	}
+
+	for i, w := range deletes {
+		w.done <- recordWriteResult{record: result.Deletes[i]}
+	}
+	for i, w := range patches {
+		w.done <- recordWriteResult{record: result.Patches[i]}
+	}
+	for i, w := range puts {
+		w.done <- recordWriteResult{record: result.Puts[i]}
+	}
+	for i, w := range posts {
+		w.done <- recordWriteResult{record: result.Posts[i]}
+	}
+}
+
+// isBatchRejected reports whether err is the API rejecting a batch, e.g. one
+// of its records being invalid, in which case none of it was applied. Server
+// errors and failed requests don't say whether the batch was applied.
+func isBatchRejected(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
+
+func (b *recordBatcher) writeOne(zoneID string, w *recordWrite) recordWriteResult {
+	record, err := b.client.writeDNSRecord(zoneID, w.method, w.record)
+	return recordWriteResult{record: record, err: err}
+}
@@ -0,0 +1,261 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestRecordBatcher_Creates(t *testing.T) {
+	var batches int
+	var mu sync.Mutex
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zones/zone/dns_records/batch", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		batches++
+		mu.Unlock()
+
+		var batch recordBatch
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		for i := range batch.Posts {
+			batch.Posts[i].ID = "id-" + batch.Posts[i].Name
+		}
+		writeTestResult(w, batch)
+	})
+	mux.HandleFunc("/zones/zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("records should be created in a batch")
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+	client.recordBatcher = newRecordBatcher(client, 50*time.Millisecond)
+
+	names := []string{"a.example.com", "b.example.com", "c.example.com"}
+	ids := make([]string, len(names))
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			record, err := client.createDNSRecord("zone", dnsRecord{DNSRecord: cloudflare.DNSRecord{Type: "A", Name: name, Content: "192.168.0.10"}})
+			ids[i], errs[i] = record.ID, err
+		}(i, name)
+	}
+	wg.Wait()
+
+	if batches != 1 {
+		t.Fatalf("expected 1 batch, got %d", batches)
+	}
+	for i, name := range names {
+		if errs[i] != nil {
+			t.Fatalf("creating %s: %s", name, errs[i])
+		}
+		if ids[i] != "id-"+name {
+			t.Fatalf("expected %s to get ID %q, got %q", name, "id-"+name, ids[i])
+		}
+	}
+}
+
+func TestRecordBatcher_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zones/zone/dns_records/batch", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 9005, "message": "Content for A record is invalid."}], "messages": [], "result": null}`)
+	})
+	mux.HandleFunc("/zones/zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		var record cloudflare.DNSRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if record.Content == "not an address" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 9005, "message": "Content for A record is invalid."}], "messages": [], "result": null}`)
+			return
+		}
+		record.ID = "id-" + record.Name
+		writeTestResult(w, record)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+	client.recordBatcher = newRecordBatcher(client, 50*time.Millisecond)
+
+	records := map[string]string{
+		"good.example.com": "192.168.0.10",
+		"bad.example.com":  "not an address",
+	}
+	errs := make(map[string]error)
+	ids := make(map[string]string)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for name, content := range records {
+		wg.Add(1)
+		go func(name, content string) {
+			defer wg.Done()
+			record, err := client.createDNSRecord("zone", dnsRecord{DNSRecord: cloudflare.DNSRecord{Type: "A", Name: name, Content: content}})
+			mu.Lock()
+			errs[name], ids[name] = err, record.ID
+			mu.Unlock()
+		}(name, content)
+	}
+	wg.Wait()
+
+	if errs["good.example.com"] != nil {
+		t.Fatalf("good.example.com should be created: %s", errs["good.example.com"])
+	}
+	if ids["good.example.com"] != "id-good.example.com" {
+		t.Fatalf("bad ID for good.example.com: %q", ids["good.example.com"])
+	}
+	if errs["bad.example.com"] == nil {
+		t.Fatal("bad.example.com should fail")
+	}
+}
+
+func TestRecordBatcher_ServerError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zones/zone/dns_records/batch", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "Internal error"}], "messages": [], "result": null}`)
+	})
+	mux.HandleFunc("/zones/zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("the batch may have been applied, so %s should not be sent again", r.Method)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+	client.recordBatcher = newRecordBatcher(client, 50*time.Millisecond)
+
+	names := []string{"a.example.com", "b.example.com"}
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			_, errs[i] = client.createDNSRecord("zone", dnsRecord{DNSRecord: cloudflare.DNSRecord{Type: "A", Name: name, Content: "192.168.0.10"}})
+		}(i, name)
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		if apiErr, ok := errs[i].(*apiError); !ok || apiErr.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("expected creating %s to fail with the batch's error, got: %v", name, errs[i])
+		}
+	}
+}
+
+func TestUpdateDNSRecordMetadata(t *testing.T) {
+	var method string
+	var patch map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		writeTestResult(w, cloudflare.DNSRecord{ID: "record"})
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	// Removing the comment and tags sends them empty, so that they are
+	// cleared rather than left as they are.
+	if _, err := client.updateDNSRecordMetadata("zone", "record", "", nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if method != "PATCH" {
+		t.Fatalf("expected a PATCH, got %s", method)
+	}
+	expected := map[string]interface{}{"id": "record", "comment": nil, "tags": []interface{}{}}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Fatalf("expected a PATCH of %#v, got %#v", expected, patch)
+	}
+}
+
+func TestRecordBatcher_Patches(t *testing.T) {
+	var batch recordBatch
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/zones/zone/dns_records/batch" {
+			t.Fatalf("records should be updated in a batch, got %s %s", r.Method, r.URL)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		var result recordBatchResult
+		for _, patch := range batch.Patches {
+			result.Patches = append(result.Patches, recordWithID(patch.ID))
+		}
+		result.Puts = batch.Puts
+		writeTestResult(w, result)
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+	client.recordBatcher = newRecordBatcher(client, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	var patched, updated dnsRecord
+	var patchErr, updateErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		patched, patchErr = client.updateDNSRecordMetadata("zone", "a", "owned by platform", []string{"production"})
+	}()
+	go func() {
+		defer wg.Done()
+		updated, updateErr = client.updateDNSRecord("zone", "b", dnsRecord{DNSRecord: cloudflare.DNSRecord{Type: "A", Name: "b.example.com", Content: "192.168.0.10"}})
+	}()
+	wg.Wait()
+
+	if patchErr != nil || updateErr != nil {
+		t.Fatalf("err: %v, %v", patchErr, updateErr)
+	}
+	if patched.ID != "a" || updated.ID != "b" {
+		t.Fatalf("expected records a and b back, got %q and %q", patched.ID, updated.ID)
+	}
+	if len(batch.Patches) != 1 || len(batch.Puts) != 1 {
+		t.Fatalf("expected a patch and a put, got %d patches and %d puts", len(batch.Patches), len(batch.Puts))
+	}
+	if patch := batch.Patches[0]; patch.Comment == nil || *patch.Comment != "owned by platform" ||
+		!reflect.DeepEqual(patch.Tags, []string{"production"}) {
+		t.Fatalf("bad patch: %#v", patch)
+	}
+}
+
+func writeTestResult(w http.ResponseWriter, result interface{}) {
+	b, _ := json.Marshal(result)
+	fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": %s}`, b)
+}
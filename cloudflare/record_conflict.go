@@ -0,0 +1,105 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error codes of record creates rejected because of a record that already
+// exists: an identical record, or a record that can't share its name with
+// a CNAME.
+const (
+	recordExistsErrorCode   = 81057
+	recordConflictErrorCode = 81053
+)
+
+// The values of the provider's dns_conflict_strategy, which decides what
+// creating a record that conflicts with an existing one does.
+const (
+	// dnsConflictError fails the create, as if there were no strategy.
+	dnsConflictError = "error"
+	// dnsConflictAdopt updates the existing record to the configuration
+	// and manages it from then on.
+	dnsConflictAdopt = "adopt"
+	// dnsConflictRecreate deletes the existing records, then creates the
+	// record again.
+	dnsConflictRecreate = "recreate"
+)
+
+// isRecordConflict reports whether err is the API's response to creating a
+// record that conflicts with an existing one.
+func isRecordConflict(err error) bool {
+	return hasErrorCode(err, recordExistsErrorCode) || hasErrorCode(err, recordConflictErrorCode)
+}
+
+// resolveDNSRecordConflict applies the dns_conflict_strategy to record,
+// whose create failed with the conflict err.
+func (client *CloudFlareClient) resolveDNSRecordConflict(zoneID string, record dnsRecord, err error) (dnsRecord, error) {
+	if client.dnsConflictStrategy != dnsConflictAdopt && client.dnsConflictStrategy != dnsConflictRecreate {
+		return dnsRecord{}, err
+	}
+
+	conflicting, lookupErr := client.conflictingDNSRecords(zoneID, record.DNSRecord)
+	if lookupErr != nil {
+		return dnsRecord{}, fmt.Errorf("%s. Finding the conflicting record to %s failed: %s", err, client.dnsConflictStrategy, lookupErr)
+	}
+	if len(conflicting) == 0 {
+		return dnsRecord{}, err
+	}
+
+	// The contents of records written as data are composed by the API, so
+	// which of several records of the type is the identical one is unknown.
+	if len(conflicting) > 1 && (client.dnsConflictStrategy == dnsConflictAdopt || record.Content == "") {
+		return dnsRecord{}, fmt.Errorf("%s. %d existing records conflict with it, so dns_conflict_strategy %q can't tell "+
+			"which to %s. Import or delete them instead", err, len(conflicting), client.dnsConflictStrategy, client.dnsConflictStrategy)
+	}
+
+	if client.dnsConflictStrategy == dnsConflictAdopt {
+		existing := conflicting[0]
+		log.Printf("[WARN] Adopting existing %s record %q (%s) that conflicts with the configuration", existing.Type, existing.Name, existing.ID)
+		return client.updateDNSRecord(zoneID, existing.ID, record)
+	}
+
+	for _, existing := range conflicting {
+		log.Printf("[WARN] Deleting existing %s record %q (%s) that conflicts with the configuration", existing.Type, existing.Name, existing.ID)
+		if err := client.deleteDNSRecord(zoneID, existing.ID); err != nil && !isRecordNotFound(err) {
+			return dnsRecord{}, fmt.Errorf("Error deleting conflicting record %q (%s): %s", existing.Name, existing.ID, err)
+		}
+	}
+	return client.createDNSRecord(zoneID, record)
+}
+
+// conflictingDNSRecords returns the records of the zone that keep record
+// from being created.
+func (client *CloudFlareClient) conflictingDNSRecords(zoneID string, record cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	query := url.Values{}
+	query.Set("name", record.Name)
+	query.Set("per_page", "100")
+
+	var records []cloudflare.DNSRecord
+	if err := client.apiRequest("GET", "/zones/"+zoneID+"/dns_records?"+query.Encode(), nil, &records); err != nil {
+		return nil, err
+	}
+
+	var conflicting []cloudflare.DNSRecord
+	for _, existing := range records {
+		if recordsConflict(record, existing) {
+			conflicting = append(conflicting, existing)
+		}
+	}
+	return conflicting, nil
+}
+
+// recordsConflict reports whether existing, a record of the same name,
+// keeps record from being created. A CNAME can't share its name with any
+// other record, and records of other types only conflict with identical
+// ones.
+func recordsConflict(record, existing cloudflare.DNSRecord) bool {
+	if record.Type == "CNAME" || existing.Type == "CNAME" {
+		return true
+	}
+	return record.Type == existing.Type && (record.Content == "" || record.Content == existing.Content)
+}
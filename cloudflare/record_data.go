@@ -0,0 +1,154 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// srvRecordData is the structured value of an SRV record. The API composes
+// the record's content and priority from it.
+type srvRecordData struct {
+	Service  string `json:"service"`
+	Proto    string `json:"proto"`
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+	Port     int    `json:"port"`
+	Target   string `json:"target"`
+}
+
+// caaRecordData is the structured value of a CAA record.
+type caaRecordData struct {
+	Flags int    `json:"flags"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// recordDataFromResourceData returns the data of a record with a data
+// block, to be written instead of its value, or nil for records without one.
+func recordDataFromResourceData(d *schema.ResourceData, name string) (interface{}, error) {
+	v, ok := d.GetOk("data")
+	if !ok {
+		if d.Get("value").(string) == "" {
+			return nil, fmt.Errorf("one of value or data must be set")
+		}
+		return nil, nil
+	}
+	// Cloudflare composes the value of records with data, so it is only set
+	// in the configuration when it is new or changes from the composed one.
+	if d.Get("value").(string) != "" && (d.Id() == "" || d.HasChange("value")) {
+		return nil, fmt.Errorf("value: conflicts with data, only one of them can be set")
+	}
+
+	m, _ := v.([]interface{})[0].(map[string]interface{})
+	if m == nil {
+		m = map[string]interface{}{}
+	}
+
+	switch t := d.Get("type").(string); t {
+	case "SRV":
+		data := srvRecordData{
+			Service:  m["service"].(string),
+			Proto:    m["proto"].(string),
+			Priority: m["priority"].(int),
+			Weight:   m["weight"].(int),
+			Port:     m["port"].(int),
+			Target:   m["target"].(string),
+		}
+		if err := missingRecordData(t, map[string]bool{
+			"service": data.Service == "",
+			"proto":   data.Proto == "",
+			"port":    data.Port == 0,
+			"target":  data.Target == "",
+		}); err != nil {
+			return nil, err
+		}
+		// The record's name is _service._proto.name, of which the data
+		// only holds the last part.
+		data.Name = strings.TrimPrefix(name, data.Service+"."+data.Proto+".")
+		return data, nil
+	case "CAA":
+		data := caaRecordData{
+			Flags: m["flags"].(int),
+			Tag:   m["tag"].(string),
+			Value: m["value"].(string),
+		}
+		if err := missingRecordData(t, map[string]bool{
+			"tag":   data.Tag == "",
+			"value": data.Value == "",
+		}); err != nil {
+			return nil, err
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("data is only supported for SRV and CAA records, not %s records", t)
+	}
+}
+
+// missingRecordData returns an error naming each field of the data block
+// that is missing, or nil when none are.
+func missingRecordData(t string, missing map[string]bool) error {
+	var fields []string
+	for field, ok := range missing {
+		if ok {
+			fields = append(fields, fmt.Sprintf("data.0.%s: required for %s records", field, t))
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+	return fmt.Errorf("%s", strings.Join(fields, "; "))
+}
+
+// flattenRecordData turns the data of a record read from the API back into
+// a data block.
+func flattenRecordData(t string, data interface{}) ([]interface{}, error) {
+	if data == nil {
+		return []interface{}{}, nil
+	}
+
+	// The data is decoded from JSON into generic maps, so it is decoded
+	// again into the type's struct.
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case "SRV":
+		var srv srvRecordData
+		if err := json.Unmarshal(raw, &srv); err != nil {
+			return nil, err
+		}
+		return []interface{}{map[string]interface{}{
+			"service":  srv.Service,
+			"proto":    srv.Proto,
+			"priority": srv.Priority,
+			"weight":   srv.Weight,
+			"port":     srv.Port,
+			"target":   srv.Target,
+		}}, nil
+	case "CAA":
+		var caa caaRecordData
+		if err := json.Unmarshal(raw, &caa); err != nil {
+			return nil, err
+		}
+		return []interface{}{map[string]interface{}{
+			"flags": caa.Flags,
+			"tag":   caa.Tag,
+			"value": caa.Value,
+		}}, nil
+	}
+	return []interface{}{}, nil
+}
+
+// suppressRecordDataPriorityDiff ignores changes to priority on records with
+// a data block, whose priority is part of their data.
+func suppressRecordDataPriorityDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("data.#").(int) > 0
+}
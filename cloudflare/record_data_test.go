@@ -0,0 +1,88 @@
+package cloudflare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestRecordDataFromResourceData(t *testing.T) {
+	srv := func(m map[string]interface{}) map[string]interface{} {
+		data := map[string]interface{}{
+			"service": "_sip",
+			"proto":   "_tls",
+			"port":    443,
+			"target":  "example.com",
+		}
+		for k, v := range m {
+			if v == nil {
+				delete(data, k)
+			} else {
+				data[k] = v
+			}
+		}
+		return map[string]interface{}{
+			"type": "SRV",
+			"data": []interface{}{data},
+		}
+	}
+	caa := func(m map[string]interface{}) map[string]interface{} {
+		data := map[string]interface{}{
+			"flags": 0,
+			"tag":   "issue",
+			"value": "letsencrypt.org",
+		}
+		for k, v := range m {
+			if v == nil {
+				delete(data, k)
+			} else {
+				data[k] = v
+			}
+		}
+		return map[string]interface{}{
+			"type": "CAA",
+			"data": []interface{}{data},
+		}
+	}
+
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		ExpectError string
+	}{
+		"srv":                {srv(nil), ""},
+		"srv no service":     {srv(map[string]interface{}{"service": nil}), "data.0.service: required for SRV records"},
+		"srv no proto":       {srv(map[string]interface{}{"proto": nil}), "data.0.proto: required for SRV records"},
+		"srv no port":        {srv(map[string]interface{}{"port": nil}), "data.0.port: required for SRV records"},
+		"srv no target":      {srv(map[string]interface{}{"target": nil}), "data.0.target: required for SRV records"},
+		"srv several":        {srv(map[string]interface{}{"port": nil, "target": nil}), "data.0.port: required for SRV records; data.0.target: required for SRV records"},
+		"caa":                {caa(nil), ""},
+		"caa no tag":         {caa(map[string]interface{}{"tag": nil}), "data.0.tag: required for CAA records"},
+		"caa no value":       {caa(map[string]interface{}{"value": nil}), "data.0.value: required for CAA records"},
+		"value":              {map[string]interface{}{"type": "A", "value": "192.168.0.10"}, ""},
+		"neither":            {map[string]interface{}{"type": "A"}, "one of value or data must be set"},
+		"data on a TXT":      {map[string]interface{}{"type": "TXT", "data": []interface{}{map[string]interface{}{"value": "x"}}}, "data is only supported for SRV and CAA records, not TXT records"},
+		"value and data":     {withValue(srv(nil), "0 443 example.com"), "value: conflicts with data"},
+		"value and caa data": {withValue(caa(nil), "0 issue letsencrypt.org"), "value: conflicts with data"},
+	}
+
+	for name, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, c.Config)
+		_, err := recordDataFromResourceData(d, "_sip._tls.example.com")
+
+		if c.ExpectError == "" {
+			if err != nil {
+				t.Fatalf("%s: err: %s", name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.ExpectError) {
+			t.Fatalf("%s: expected an error with %q, got: %v", name, c.ExpectError, err)
+		}
+	}
+}
+
+func withValue(config map[string]interface{}, value string) map[string]interface{} {
+	config["value"] = value
+	return config
+}
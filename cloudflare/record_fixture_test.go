@@ -0,0 +1,195 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// testAccRecordFixtures is test infrastructure, not part of the provider:
+// it creates DNS records straight through the API, outside of Terraform,
+// so that tests of import or of resources depending on records can start
+// from records whose IDs are known up front. Records are created in the
+// zone CLOUDFLARE_ZONE_ID, against the mock API when testAccRecordAPI set
+// one up, and are all deleted by Destroy.
+//
+//	fixtures := newTestAccRecordFixtures(t)
+//	defer fixtures.Destroy(t)
+//	record := fixtures.Create(t, cloudflare.DNSRecord{...})
+type testAccRecordFixtures struct {
+	client *CloudFlareClient
+	zoneID string
+
+	mu  sync.Mutex
+	ids []string
+}
+
+func newTestAccRecordFixtures(t *testing.T) *testAccRecordFixtures {
+	config := Config{
+		Email:      os.Getenv("CLOUDFLARE_EMAIL"),
+		Token:      os.Getenv("CLOUDFLARE_TOKEN"),
+		APIToken:   os.Getenv("CLOUDFLARE_API_TOKEN"),
+		APIBaseURL: os.Getenv("CLOUDFLARE_API_BASE_URL"),
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API for record fixtures: %s", err)
+	}
+
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	if zoneID == "" {
+		t.Fatal("CLOUDFLARE_ZONE_ID must be set for record fixtures. The zone is used to create and destroy fixtures against.")
+	}
+
+	return &testAccRecordFixtures{client: client, zoneID: zoneID}
+}
+
+// Create creates record and returns it as created, ID included. The test
+// fails if it can't be created.
+func (f *testAccRecordFixtures) Create(t *testing.T, record cloudflare.DNSRecord) cloudflare.DNSRecord {
+	created, err := f.client.createDNSRecord(f.zoneID, dnsRecord{DNSRecord: record})
+	if err != nil {
+		t.Fatalf("Error creating record fixture %s %s: %s", record.Type, record.Name, err)
+	}
+
+	f.mu.Lock()
+	f.ids = append(f.ids, created.ID)
+	f.mu.Unlock()
+
+	return created.DNSRecord
+}
+
+// Destroy deletes every fixture created, including those a test already
+// deleted, such as records imported into Terraform and destroyed with it.
+func (f *testAccRecordFixtures) Destroy(t *testing.T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range f.ids {
+		if err := f.client.deleteDNSRecord(f.zoneID, id); err != nil && !isRecordNotFound(err) {
+			t.Errorf("Error deleting record fixture %s: %s", id, err)
+		}
+	}
+	f.ids = nil
+}
+
+func TestAccCloudFlareRecord_ImportFixture(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	fixtures := newTestAccRecordFixtures(t)
+	defer fixtures.Destroy(t)
+	record := fixtures.Create(t, cloudflare.DNSRecord{
+		Type:    "A",
+		Name:    "fixture." + domain,
+		Content: "192.0.2.1",
+		TTL:     3600,
+	})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:        fmt.Sprintf(testAccCheckCloudFlareRecordFixtureConfig, domain),
+				ResourceName:  "cloudflare_record.foobar",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("fixture|%s|A|%s", domain, record.ID),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 record imported, got %d", len(states))
+					}
+					if id, value := states[0].ID, states[0].Attributes["value"]; id != record.ID || value != "192.0.2.1" {
+						return fmt.Errorf("expected record %s with value 192.0.2.1, got %s with value %s", record.ID, id, value)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudFlareDNSRecordDataSource_Fixture(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "data.cloudflare_dns_record.foobar"
+
+	fixtures := newTestAccRecordFixtures(t)
+	defer fixtures.Destroy(t)
+	record := fixtures.Create(t, cloudflare.DNSRecord{
+		Type:    "TXT",
+		Name:    "fixture." + domain,
+		Content: "terraform fixture",
+		TTL:     3600,
+	})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: isUnitTest,
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareDNSRecordDataSourceFixtureConfig, zoneID, record.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", record.ID),
+					resource.TestCheckResourceAttr(name, "type", "TXT"),
+					resource.TestCheckResourceAttr(name, "subdomain", "fixture"),
+					resource.TestCheckResourceAttr(name, "value", "terraform fixture"),
+				),
+			},
+		},
+	})
+}
+
+func TestRecordFixturesDestroy(t *testing.T) {
+	domain, _, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+	if os.Getenv(resource.TestEnvVar) != "" {
+		t.Skip("Record fixture teardown is only checked against the mock API")
+	}
+
+	fixtures := newTestAccRecordFixtures(t)
+	kept := fixtures.Create(t, cloudflare.DNSRecord{Type: "A", Name: "kept." + domain, Content: "192.0.2.1", TTL: 1})
+	gone := fixtures.Create(t, cloudflare.DNSRecord{Type: "A", Name: "gone." + domain, Content: "192.0.2.2", TTL: 1})
+
+	// A fixture deleted by the test itself doesn't fail the teardown.
+	if err := fixtures.client.deleteDNSRecord(fixtures.zoneID, gone.ID); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	fixtures.Destroy(t)
+
+	for _, record := range []cloudflare.DNSRecord{kept, gone} {
+		if _, err := fixtures.client.DNSRecord(fixtures.zoneID, record.ID); err == nil {
+			t.Fatalf("Record fixture %s still exists", record.Name)
+		}
+	}
+	if len(fixtures.ids) != 0 {
+		t.Fatalf("expected no fixtures left to destroy, got %v", fixtures.ids)
+	}
+}
+
+const testAccCheckCloudFlareRecordFixtureConfig = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "fixture"
+	value = "192.0.2.1"
+	type = "A"
+	ttl = 3600
+}`
+
+const testAccCheckCloudFlareDNSRecordDataSourceFixtureConfig = `
+data "cloudflare_dns_record" "foobar" {
+	zone_id = "%s"
+	record_id = "%s"
+}`
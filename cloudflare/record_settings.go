@@ -0,0 +1,127 @@
+package cloudflare
+
+import (
+	"log"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dnsRecord is a record as it is written to and read from the API.
+// cloudflare-go's DNSRecord predates record settings, so they are added
+// alongside it. Its priority is replaced too, as it leaves out a priority of
+// 0, which is valid for MX and SRV records.
+type dnsRecord struct {
+	cloudflare.DNSRecord
+	Priority *int               `json:"priority,omitempty"`
+	Settings *dnsRecordSettings `json:"settings,omitempty"`
+	// Comment and Tags are null in the API for records without them.
+	// They are left out of writes when empty, which clears them as writes
+	// replace the whole record.
+	Comment string   `json:"comment,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+type dnsRecordSettings struct {
+	FlattenCNAME bool `json:"flatten_cname"`
+}
+
+func recordWithID(recordID string) dnsRecord {
+	return dnsRecord{DNSRecord: cloudflare.DNSRecord{ID: recordID}}
+}
+
+// recordPriority is the priority to write record with. Only MX and SRV
+// records have one, except for SRV records given as data, whose data holds
+// it.
+func recordPriority(record cloudflare.DNSRecord) *int {
+	if (record.Type != "MX" && record.Type != "SRV") || record.Data != nil {
+		return nil
+	}
+	priority := record.Priority
+	return &priority
+}
+
+// CNAME flattening can be turned on for a whole zone, with the zone's
+// cname_flattening setting, as well as for single records. Zone-level
+// flattening covers the zone apex, or every CNAME of the zone.
+const (
+	cnameFlatteningAtRoot = "flatten_at_root"
+	cnameFlatteningAll    = "flatten_all"
+)
+
+// zoneSetting returns the value of a string setting of the zone, e.g.
+// "flatten_at_root" for cname_flattening. It is fetched once per zone and
+// provider run.
+func (client *CloudFlareClient) zoneSetting(zoneID, setting string) (string, error) {
+	client.zoneSettingsMu.Lock()
+	defer client.zoneSettingsMu.Unlock()
+
+	key := zoneID + "/" + setting
+	if value, ok := client.zoneSettings[key]; ok {
+		return value, nil
+	}
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	if err := client.apiRequest("GET", "/zones/"+zoneID+"/settings/"+setting, nil, &result); err != nil {
+		return "", err
+	}
+
+	if client.zoneSettings == nil {
+		client.zoneSettings = make(map[string]string)
+	}
+	client.zoneSettings[key] = result.Value
+	return result.Value, nil
+}
+
+// zoneFlattensCNAME reports whether the zone's own setting flattens the
+// CNAME record, whatever the record's flatten_cname setting is. The
+// setting read back from such a record says nothing about its
+// configuration. Records are assumed not to be flattened by their zone if
+// the zone's setting can't be found.
+func (client *CloudFlareClient) zoneFlattensCNAME(record cloudflare.DNSRecord) bool {
+	if record.Type != "CNAME" {
+		return false
+	}
+
+	flattening, err := client.zoneSetting(record.ZoneID, "cname_flattening")
+	if err != nil {
+		log.Printf("[WARN] Could not find the CNAME flattening of zone %q for %q: %s", record.ZoneName, record.Name, err)
+		return false
+	}
+
+	switch flattening {
+	case cnameFlatteningAll:
+		return true
+	case cnameFlatteningAtRoot:
+		return record.Name == record.ZoneName
+	}
+	return false
+}
+
+// recordSettingsFromResourceData returns the settings of a record. Only
+// CNAME records have any.
+func recordSettingsFromResourceData(d *schema.ResourceData) *dnsRecordSettings {
+	if d.Get("type").(string) != "CNAME" {
+		return nil
+	}
+
+	settings := &dnsRecordSettings{}
+	if v, ok := d.GetOk("settings"); ok {
+		if m, ok := v.([]interface{})[0].(map[string]interface{}); ok {
+			settings.FlattenCNAME = m["flatten_cname"].(bool)
+		}
+	}
+	return settings
+}
+
+func flattenRecordSettings(settings *dnsRecordSettings) []interface{} {
+	if settings == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"flatten_cname": settings.FlattenCNAME,
+	}}
+}
@@ -0,0 +1,67 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// The lowest TTL records can have, other than 1 (automatic), depends on the
+// plan of their zone. Enterprise zones can go lower than the others. No
+// record can have a TTL over a day.
+const (
+	minRecordTTL           = 120
+	enterpriseMinRecordTTL = 30
+	maxRecordTTL           = 86400
+)
+
+// zonePlan returns the plan of the zone, e.g. "free". It is fetched once per
+// zone and provider run.
+func (client *CloudFlareClient) zonePlan(zoneID string) (string, error) {
+	client.zonePlansMu.Lock()
+	defer client.zonePlansMu.Unlock()
+
+	if plan, ok := client.zonePlans[zoneID]; ok {
+		return plan, nil
+	}
+
+	var zone cloudflare.Zone
+	if err := client.apiRequest("GET", "/zones/"+zoneID, nil, &zone); err != nil {
+		return "", err
+	}
+
+	if client.zonePlans == nil {
+		client.zonePlans = make(map[string]string)
+	}
+	client.zonePlans[zoneID] = zone.Plan.LegacyID
+	return zone.Plan.LegacyID, nil
+}
+
+// checkRecordTTL ensures that the TTL of record isn't below the minimum of
+// its zone's plan, which the API would otherwise reject with a less helpful
+// error. Records are let through if the plan can't be found.
+func (client *CloudFlareClient) checkRecordTTL(record cloudflare.DNSRecord) error {
+	if record.TTL <= 1 {
+		return nil
+	}
+
+	plan, err := client.zonePlan(record.ZoneID)
+	if err != nil {
+		log.Printf("[WARN] Could not find the plan of zone %q to check the TTL of %q: %s", record.ZoneName, record.Name, err)
+		return nil
+	}
+
+	if min := recordTTLMinimum(plan); record.TTL < min {
+		return fmt.Errorf("ttl %d of record %q is below the %s plan minimum of %d. Set ttl to at least %d, "+
+			"or to 1 for automatic", record.TTL, record.Name, plan, min, min)
+	}
+	return nil
+}
+
+func recordTTLMinimum(plan string) int {
+	if plan == "enterprise" {
+		return enterpriseMinRecordTTL
+	}
+	return minRecordTTL
+}
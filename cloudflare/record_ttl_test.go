@@ -0,0 +1,59 @@
+package cloudflare
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestCheckRecordTTL(t *testing.T) {
+	plans := map[string]string{"free": "free", "enterprise": "enterprise"}
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		plan, ok := plans[strings.TrimPrefix(r.URL.Path, "/zones/")]
+		if !ok {
+			writeMockError(w, http.StatusNotFound, 1001, "Invalid zone identifier")
+			return
+		}
+		writeTestResult(w, cloudflare.Zone{Plan: cloudflare.ZonePlan{LegacyID: plan}})
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := []struct {
+		ZoneID      string
+		TTL         int
+		ExpectError string
+	}{
+		{"free", 60, "ttl 60 of record \"terraform.example.com\" is below the free plan minimum of 120"},
+		{"free", 120, ""},
+		{"free", 1, ""},
+		{"enterprise", 60, ""},
+		{"enterprise", 10, "below the enterprise plan minimum of 30"},
+		{"unknown", 60, ""},
+	}
+
+	for _, c := range cases {
+		record := cloudflare.DNSRecord{Name: "terraform.example.com", ZoneID: c.ZoneID, TTL: c.TTL}
+		err := client.checkRecordTTL(record)
+		if c.ExpectError == "" && err != nil {
+			t.Fatalf("%s zone, ttl %d: err: %s", c.ZoneID, c.TTL, err)
+		}
+		if c.ExpectError != "" && (err == nil || !strings.Contains(err.Error(), c.ExpectError)) {
+			t.Fatalf("%s zone, ttl %d: expected error containing %q, got: %v", c.ZoneID, c.TTL, c.ExpectError, err)
+		}
+	}
+
+	// The plan of each zone is only fetched once.
+	if requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+}
@@ -0,0 +1,274 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// addressMap binds BYOIP addresses to the zones and accounts that use them.
+type addressMap struct {
+	ID          string                 `json:"id,omitempty"`
+	Description string                 `json:"description"`
+	DefaultSNI  string                 `json:"default_sni,omitempty"`
+	Enabled     bool                   `json:"enabled"`
+	IPs         []addressMapIP         `json:"ips,omitempty"`
+	Memberships []addressMapMembership `json:"memberships,omitempty"`
+}
+
+type addressMapIP struct {
+	IP string `json:"ip"`
+}
+
+type addressMapMembership struct {
+	Identifier string `json:"identifier"`
+	Kind       string `json:"kind"`
+}
+
+// addressMapRequest is the body of an address map create; IPs are plain
+// strings on the way in.
+type addressMapRequest struct {
+	Description string                 `json:"description"`
+	DefaultSNI  string                 `json:"default_sni,omitempty"`
+	Enabled     bool                   `json:"enabled"`
+	IPs         []string               `json:"ips,omitempty"`
+	Memberships []addressMapMembership `json:"memberships,omitempty"`
+}
+
+func resourceCloudFlareAddressMap() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareAddressMapCreate,
+		Read:   resourceCloudFlareAddressMapRead,
+		Update: resourceCloudFlareAddressMapUpdate,
+		Delete: resourceCloudFlareAddressMapDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"default_sni": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ips": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateIPAddress,
+				},
+				Set: schema.HashString,
+			},
+
+			"memberships": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"kind": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAddressMapMembershipKind,
+						},
+					},
+				},
+				Set: addressMapMembershipHash,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareAddressMapCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	req := addressMapRequest{
+		Description: d.Get("description").(string),
+		DefaultSNI:  d.Get("default_sni").(string),
+		Enabled:     d.Get("enabled").(bool),
+	}
+	for _, ip := range d.Get("ips").(*schema.Set).List() {
+		req.IPs = append(req.IPs, ip.(string))
+	}
+	for _, m := range d.Get("memberships").(*schema.Set).List() {
+		req.Memberships = append(req.Memberships, expandAddressMapMembership(m))
+	}
+
+	log.Printf("[DEBUG] CloudFlare Address Map create configuration: %#v", req)
+
+	var m addressMap
+	if err := client.apiRequest("POST", addressMapsURI(accountID), req, &m); err != nil {
+		return fmt.Errorf("Error creating address map for account %q: %s", accountID, err)
+	}
+
+	if m.ID == "" {
+		return fmt.Errorf("Failed to find address map in create response; ID was empty")
+	}
+
+	d.SetId(m.ID)
+
+	log.Printf("[INFO] CloudFlare Address Map ID: %s", d.Id())
+
+	return resourceCloudFlareAddressMapRead(d, meta)
+}
+
+func resourceCloudFlareAddressMapRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var m addressMap
+	err := client.apiRequest("GET", addressMapsURI(accountID)+"/"+d.Id(), nil, &m)
+	if isNotFound(err) {
+		log.Printf("[INFO] Address map %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding address map %q: %s", d.Id(), err)
+	}
+
+	d.Set("description", m.Description)
+	d.Set("enabled", m.Enabled)
+	d.Set("default_sni", m.DefaultSNI)
+
+	ips := make([]interface{}, 0, len(m.IPs))
+	for _, ip := range m.IPs {
+		ips = append(ips, ip.IP)
+	}
+	if err := d.Set("ips", schema.NewSet(schema.HashString, ips)); err != nil {
+		return fmt.Errorf("Error setting ips: %s", err)
+	}
+
+	memberships := make([]interface{}, 0, len(m.Memberships))
+	for _, membership := range m.Memberships {
+		memberships = append(memberships, map[string]interface{}{
+			"identifier": membership.Identifier,
+			"kind":       membership.Kind,
+		})
+	}
+	if err := d.Set("memberships", schema.NewSet(addressMapMembershipHash, memberships)); err != nil {
+		return fmt.Errorf("Error setting memberships: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareAddressMapUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+	uri := addressMapsURI(accountID) + "/" + d.Id()
+
+	if d.HasChange("description") || d.HasChange("enabled") || d.HasChange("default_sni") {
+		update := map[string]interface{}{
+			"description": d.Get("description").(string),
+			"enabled":     d.Get("enabled").(bool),
+			"default_sni": d.Get("default_sni").(string),
+		}
+
+		log.Printf("[DEBUG] CloudFlare Address Map update configuration: %#v", update)
+		if err := client.apiRequest("PATCH", uri, update, nil); err != nil {
+			return fmt.Errorf("Error updating address map %q: %s", d.Id(), err)
+		}
+	}
+
+	// IPs and memberships are added and removed through their own endpoints.
+	if d.HasChange("ips") {
+		o, n := d.GetChange("ips")
+		removed := o.(*schema.Set).Difference(n.(*schema.Set))
+		added := n.(*schema.Set).Difference(o.(*schema.Set))
+
+		for _, ip := range removed.List() {
+			if err := client.apiRequest("DELETE", uri+"/ips/"+ip.(string), nil, nil); err != nil && !isNotFound(err) {
+				return fmt.Errorf("Error removing IP %q from address map %q: %s", ip, d.Id(), err)
+			}
+		}
+		for _, ip := range added.List() {
+			if err := client.apiRequest("PUT", uri+"/ips/"+ip.(string), nil, nil); err != nil {
+				return fmt.Errorf("Error adding IP %q to address map %q: %s", ip, d.Id(), err)
+			}
+		}
+	}
+
+	if d.HasChange("memberships") {
+		o, n := d.GetChange("memberships")
+		removed := o.(*schema.Set).Difference(n.(*schema.Set))
+		added := n.(*schema.Set).Difference(o.(*schema.Set))
+
+		for _, v := range removed.List() {
+			m := expandAddressMapMembership(v)
+			if err := client.apiRequest("DELETE", uri+addressMapMembershipPath(m), nil, nil); err != nil && !isNotFound(err) {
+				return fmt.Errorf("Error removing %s %q from address map %q: %s", m.Kind, m.Identifier, d.Id(), err)
+			}
+		}
+		for _, v := range added.List() {
+			m := expandAddressMapMembership(v)
+			if err := client.apiRequest("PUT", uri+addressMapMembershipPath(m), nil, nil); err != nil {
+				return fmt.Errorf("Error adding %s %q to address map %q: %s", m.Kind, m.Identifier, d.Id(), err)
+			}
+		}
+	}
+
+	return resourceCloudFlareAddressMapRead(d, meta)
+}
+
+func resourceCloudFlareAddressMapDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Address Map: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", addressMapsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting address map %q: %s", d.Id(), err)
+}
+
+func addressMapsURI(accountID string) string {
+	return "/accounts/" + accountID + "/addressing/address_maps"
+}
+
+// addressMapMembershipPath is the path of a membership below its address map.
+func addressMapMembershipPath(m addressMapMembership) string {
+	if m.Kind == "account" {
+		return "/accounts/" + m.Identifier
+	}
+	return "/zones/" + m.Identifier
+}
+
+func expandAddressMapMembership(v interface{}) addressMapMembership {
+	m := v.(map[string]interface{})
+	return addressMapMembership{
+		Identifier: m["identifier"].(string),
+		Kind:       m["kind"].(string),
+	}
+}
+
+func addressMapMembershipHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s-%s", m["kind"].(string), m["identifier"].(string)))
+}
@@ -0,0 +1,84 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareAddressMap_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "cloudflare_address_map.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareAddressMapDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareAddressMapConfigBasic, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "description", "terraform"),
+					resource.TestCheckResourceAttr(name, "enabled", "false"),
+					resource.TestCheckResourceAttr(name, "memberships.#", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareAddressMapConfigMembership, accountID, zoneID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "memberships.#", "1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareAddressMapDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_address_map" {
+			continue
+		}
+
+		uri := addressMapsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Address map still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareAddressMapConfigBasic = `
+resource "cloudflare_address_map" "foobar" {
+	account_id = "%s"
+	description = "terraform"
+}`
+
+const testAccCheckCloudFlareAddressMapConfigMembership = `
+resource "cloudflare_address_map" "foobar" {
+	account_id = "%s"
+	description = "terraform"
+	enabled = true
+
+	memberships {
+		identifier = "%s"
+		kind = "zone"
+	}
+}`
@@ -0,0 +1,158 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// filter is an expression matching requests to a zone, which firewall
+// rules act on.
+type filter struct {
+	ID          string `json:"id,omitempty"`
+	Expression  string `json:"expression"`
+	Paused      bool   `json:"paused"`
+	Description string `json:"description,omitempty"`
+	Ref         string `json:"ref,omitempty"`
+}
+
+func resourceCloudFlareFilter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareFilterCreate,
+		Read:   resourceCloudFlareFilterRead,
+		Update: resourceCloudFlareFilterUpdate,
+		Delete: resourceCloudFlareFilterDelete,
+		Importer: &schema.ResourceImporter{
+			State: importZoneScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"expression": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressSurroundingWhitespaceDiff,
+			},
+
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ref": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareFilterCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	f := filterFromResourceData(d)
+
+	log.Printf("[DEBUG] CloudFlare Filter create configuration: %#v", f)
+
+	// Filters are created in batches, so one is sent as a batch of one.
+	var created []filter
+	if err := client.apiRequest("POST", filtersURI(zoneID), []filter{f}, &created); err != nil {
+		return fmt.Errorf("Error creating filter for zone %q: %s", zoneID, err)
+	}
+
+	if len(created) != 1 || created[0].ID == "" {
+		return fmt.Errorf("Failed to find filter in create response; ID was empty")
+	}
+
+	d.SetId(created[0].ID)
+
+	log.Printf("[INFO] CloudFlare Filter ID: %s", d.Id())
+
+	return resourceCloudFlareFilterRead(d, meta)
+}
+
+func resourceCloudFlareFilterRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	var f filter
+	err := client.apiRequest("GET", filtersURI(zoneID)+"/"+d.Id(), nil, &f)
+	if isNotFound(err) {
+		log.Printf("[INFO] Filter %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding filter %q: %s", d.Id(), err)
+	}
+
+	d.Set("expression", f.Expression)
+	d.Set("paused", f.Paused)
+	d.Set("description", f.Description)
+	d.Set("ref", f.Ref)
+
+	return nil
+}
+
+func resourceCloudFlareFilterUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	f := filterFromResourceData(d)
+	f.ID = d.Id()
+
+	log.Printf("[DEBUG] CloudFlare Filter update configuration: %#v", f)
+
+	if err := client.apiRequest("PUT", filtersURI(zoneID)+"/"+d.Id(), f, nil); err != nil {
+		return fmt.Errorf("Error updating filter %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareFilterRead(d, meta)
+}
+
+func resourceCloudFlareFilterDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Filter: %s, %s", zoneID, d.Id())
+
+	err := client.apiRequest("DELETE", filtersURI(zoneID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting filter %q: %s", d.Id(), err)
+}
+
+func filtersURI(zoneID string) string {
+	return "/zones/" + zoneID + "/filters"
+}
+
+func filterFromResourceData(d *schema.ResourceData) filter {
+	return filter{
+		Expression:  d.Get("expression").(string),
+		Paused:      d.Get("paused").(bool),
+		Description: d.Get("description").(string),
+		Ref:         d.Get("ref").(string),
+	}
+}
+
+// suppressSurroundingWhitespaceDiff ignores changes to an expression that
+// only add or remove whitespace around it, such as the trailing newline of a
+// heredoc, as CloudFlare trims it.
+func suppressSurroundingWhitespaceDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
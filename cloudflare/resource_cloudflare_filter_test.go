@@ -0,0 +1,116 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareFilter_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "cloudflare_filter.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareFilterDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareFilterConfig, zoneID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "expression", `(http.request.uri.path eq "/wp-login.php")`),
+					resource.TestCheckResourceAttr(name, "paused", "false"),
+					resource.TestCheckResourceAttr(name, "ref", "TF-1"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareFilterConfig, zoneID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "paused", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: zoneID + "/",
+			},
+		},
+	})
+}
+
+func TestCloudFlareFilterCreate(t *testing.T) {
+	var stored filter
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri := filtersURI("abc")
+		switch {
+		case r.URL.Path == uri && r.Method == "POST":
+			var created []filter
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil || len(created) != 1 {
+				t.Errorf("expected a batch of one filter, got %v (%v)", created, err)
+			}
+			stored = created[0]
+			stored.ID = "1234"
+			writeTestResult(w, []filter{stored})
+		case r.URL.Path == uri+"/1234" && r.Method == "GET":
+			writeTestResult(w, stored)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareFilter().Schema, map[string]interface{}{
+		"zone_id":     "abc",
+		"expression":  `(ip.src eq 192.0.2.1)`,
+		"description": "bad actor",
+	})
+	if err := resourceCloudFlareFilterCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "1234" || d.Get("expression") != stored.Expression || d.Get("description") != "bad actor" {
+		t.Fatalf("bad state: id %q, expression %v, description %v", d.Id(), d.Get("expression"), d.Get("description"))
+	}
+}
+
+func testAccCheckCloudFlareFilterDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_filter" {
+			continue
+		}
+
+		uri := filtersURI(rs.Primary.Attributes["zone_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Filter still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareFilterConfig = `
+resource "cloudflare_filter" "foobar" {
+	zone_id = "%s"
+	expression = "(http.request.uri.path eq \"/wp-login.php\")"
+	description = "WordPress login"
+	ref = "TF-1"
+	paused = %t
+}`
@@ -0,0 +1,187 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// firewallRule takes an action on the requests to a zone matching a
+// filter.
+type firewallRule struct {
+	ID          string             `json:"id,omitempty"`
+	Filter      firewallRuleFilter `json:"filter"`
+	Action      string             `json:"action"`
+	Priority    *int               `json:"priority,omitempty"`
+	Paused      bool               `json:"paused"`
+	Description string             `json:"description,omitempty"`
+}
+
+// firewallRuleFilter is the filter of a firewall rule, which is written as
+// a reference by ID but read back in full.
+type firewallRuleFilter struct {
+	ID string `json:"id"`
+}
+
+func resourceCloudFlareFirewallRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareFirewallRuleCreate,
+		Read:   resourceCloudFlareFirewallRuleRead,
+		Update: resourceCloudFlareFirewallRuleUpdate,
+		Delete: resourceCloudFlareFirewallRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: importZoneScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"filter_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateFirewallRuleAction,
+			},
+
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateFirewallRulePriority,
+			},
+
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareFirewallRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	rule := firewallRuleFromResourceData(d)
+
+	log.Printf("[DEBUG] CloudFlare Firewall Rule create configuration: %#v", rule)
+
+	// Rules are created in batches, so one is sent as a batch of one.
+	var created []firewallRule
+	if err := client.apiRequest("POST", firewallRulesURI(zoneID), []firewallRule{rule}, &created); err != nil {
+		return fmt.Errorf("Error creating firewall rule for zone %q: %s", zoneID, err)
+	}
+
+	if len(created) != 1 || created[0].ID == "" {
+		return fmt.Errorf("Failed to find firewall rule in create response; ID was empty")
+	}
+
+	d.SetId(created[0].ID)
+
+	log.Printf("[INFO] CloudFlare Firewall Rule ID: %s", d.Id())
+
+	return resourceCloudFlareFirewallRuleRead(d, meta)
+}
+
+func resourceCloudFlareFirewallRuleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	var rule firewallRule
+	err := client.apiRequest("GET", firewallRulesURI(zoneID)+"/"+d.Id(), nil, &rule)
+	if isNotFound(err) {
+		log.Printf("[INFO] Firewall rule %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding firewall rule %q: %s", d.Id(), err)
+	}
+
+	// A rule whose filter was deleted no longer matches anything, so it is
+	// removed from the state to be created again.
+	if rule.Filter.ID == "" || client.filterDeleted(zoneID, rule.Filter.ID) {
+		log.Printf("[INFO] Filter %q of firewall rule %s no longer exists", rule.Filter.ID, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("filter_id", rule.Filter.ID)
+	d.Set("action", rule.Action)
+	d.Set("paused", rule.Paused)
+	d.Set("description", rule.Description)
+	if rule.Priority != nil {
+		d.Set("priority", *rule.Priority)
+	} else {
+		d.Set("priority", 0)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareFirewallRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	rule := firewallRuleFromResourceData(d)
+	rule.ID = d.Id()
+
+	log.Printf("[DEBUG] CloudFlare Firewall Rule update configuration: %#v", rule)
+
+	if err := client.apiRequest("PUT", firewallRulesURI(zoneID)+"/"+d.Id(), rule, nil); err != nil {
+		return fmt.Errorf("Error updating firewall rule %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareFirewallRuleRead(d, meta)
+}
+
+func resourceCloudFlareFirewallRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Firewall Rule: %s, %s", zoneID, d.Id())
+
+	err := client.apiRequest("DELETE", firewallRulesURI(zoneID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting firewall rule %q: %s", d.Id(), err)
+}
+
+func firewallRulesURI(zoneID string) string {
+	return "/zones/" + zoneID + "/firewall/rules"
+}
+
+// filterDeleted reports whether the filter is known to be gone. Other
+// errors are left to the next request to surface.
+func (client *CloudFlareClient) filterDeleted(zoneID, filterID string) bool {
+	return isNotFound(client.apiRequest("GET", filtersURI(zoneID)+"/"+filterID, nil, nil))
+}
+
+func firewallRuleFromResourceData(d *schema.ResourceData) firewallRule {
+	rule := firewallRule{
+		Filter:      firewallRuleFilter{ID: d.Get("filter_id").(string)},
+		Action:      d.Get("action").(string),
+		Paused:      d.Get("paused").(bool),
+		Description: d.Get("description").(string),
+	}
+	if priority, ok := d.GetOk("priority"); ok {
+		p := priority.(int)
+		rule.Priority = &p
+	}
+	return rule
+}
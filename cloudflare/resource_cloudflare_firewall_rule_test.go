@@ -0,0 +1,134 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareFirewallRule_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "cloudflare_firewall_rule.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareFirewallRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareFirewallRuleConfig, zoneID, zoneID, "block"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "filter_id", "cloudflare_filter.foobar", "id"),
+					resource.TestCheckResourceAttr(name, "action", "block"),
+					resource.TestCheckResourceAttr(name, "priority", "10"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareFirewallRuleConfig, zoneID, zoneID, "js_challenge"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "action", "js_challenge"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: zoneID + "/",
+			},
+		},
+	})
+}
+
+func TestCloudFlareFirewallRuleRead_FilterDeleted(t *testing.T) {
+	cases := map[string]struct {
+		Rule          string
+		FilterDeleted bool
+		Gone          bool
+	}{
+		"filter exists":  {Rule: `{"id": "1234", "filter": {"id": "5678"}, "action": "block", "priority": 10}`},
+		"filter deleted": {Rule: `{"id": "1234", "filter": {"id": "5678"}, "action": "block"}`, FilterDeleted: true, Gone: true},
+		"no filter":      {Rule: `{"id": "1234", "filter": {}, "action": "block"}`, Gone: true},
+	}
+
+	for tn, tc := range cases {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case firewallRulesURI("abc") + "/1234":
+				fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": %s}`, tc.Rule)
+			case filtersURI("abc") + "/5678":
+				if tc.FilterDeleted {
+					writeMockError(w, http.StatusNotFound, 10000, "not found")
+					return
+				}
+				fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "5678", "expression": "(ip.src eq 192.0.2.1)"}}`)
+			default:
+				writeMockError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+			}
+		}))
+
+		client, err := testClient(ts.URL)
+		if err != nil {
+			t.Fatalf("Error building CloudFlare API: %s", err)
+		}
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareFirewallRule().Schema, map[string]interface{}{
+			"zone_id":   "abc",
+			"filter_id": "5678",
+			"action":    "block",
+		})
+		d.SetId("1234")
+
+		err = resourceCloudFlareFirewallRuleRead(d, client)
+		ts.Close()
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		if gone := d.Id() == ""; gone != tc.Gone {
+			t.Fatalf("%s: expected the rule to be removed from the state: %t, got id %q", tn, tc.Gone, d.Id())
+		}
+		if !tc.Gone && d.Get("priority") != 10 {
+			t.Fatalf("%s: expected priority 10, got %v", tn, d.Get("priority"))
+		}
+	}
+}
+
+func testAccCheckCloudFlareFirewallRuleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_firewall_rule" {
+			continue
+		}
+
+		uri := firewallRulesURI(rs.Primary.Attributes["zone_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Firewall rule still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareFirewallRuleConfig = `
+resource "cloudflare_filter" "foobar" {
+	zone_id = "%s"
+	expression = "(ip.src eq 192.0.2.1)"
+}
+
+resource "cloudflare_firewall_rule" "foobar" {
+	zone_id = "%s"
+	filter_id = "${cloudflare_filter.foobar.id}"
+	action = "%s"
+	priority = 10
+	description = "Terraform test rule"
+}`
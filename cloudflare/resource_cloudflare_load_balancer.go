@@ -0,0 +1,313 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// loadBalancer spreads the requests to a hostname of a zone over pools of
+// origins.
+type loadBalancer struct {
+	ID              string              `json:"id,omitempty"`
+	Name            string              `json:"name"`
+	Description     string              `json:"description,omitempty"`
+	DefaultPools    []string            `json:"default_pools"`
+	FallbackPool    string              `json:"fallback_pool"`
+	Proxied         bool                `json:"proxied"`
+	SteeringPolicy  string              `json:"steering_policy,omitempty"`
+	SessionAffinity string              `json:"session_affinity,omitempty"`
+	PopPools        map[string][]string `json:"pop_pools"`
+	RegionPools     map[string][]string `json:"region_pools"`
+	CountryPools    map[string][]string `json:"country_pools"`
+	CreatedOn       string              `json:"created_on,omitempty"`
+	ModifiedOn      string              `json:"modified_on,omitempty"`
+}
+
+// loadBalancerPoolMaps are the blocks that pick the pools of requests by
+// where they come from, keyed by the attribute naming the location.
+var loadBalancerPoolMaps = map[string]string{
+	"pop_pools":     "pop",
+	"region_pools":  "region",
+	"country_pools": "country",
+}
+
+func resourceCloudFlareLoadBalancer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareLoadBalancerCreate,
+		Read:   resourceCloudFlareLoadBalancerRead,
+		Update: resourceCloudFlareLoadBalancerUpdate,
+		Delete: resourceCloudFlareLoadBalancerDelete,
+		Importer: &schema.ResourceImporter{
+			State: importZoneScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Pools are tried in order, so default_pool_ids is a list.
+			"default_pool_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"fallback_pool_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"proxied": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"steering_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "off",
+				ValidateFunc: validateLoadBalancerSteeringPolicy,
+			},
+
+			"session_affinity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validateLoadBalancerSessionAffinity,
+			},
+
+			"pop_pools":     loadBalancerPoolMapSchema("pop"),
+			"region_pools":  loadBalancerPoolMapSchema("region"),
+			"country_pools": loadBalancerPoolMapSchema("country"),
+
+			"created_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"modified_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// loadBalancerPoolMapSchema is the schema of a map from location to pool
+// IDs. Maps of lists can't be expressed directly, so each location is a
+// block naming the location and its pools.
+func loadBalancerPoolMapSchema(location string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				location: {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"pool_ids": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareLoadBalancerCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	lb := loadBalancerFromResourceData(d)
+	if err := checkLoadBalancer(lb); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Load Balancer create configuration: %#v", lb)
+
+	var created loadBalancer
+	if err := client.apiRequest("POST", loadBalancersURI(zoneID), lb, &created); err != nil {
+		return fmt.Errorf("Error creating load balancer %q for zone %q: %s", lb.Name, zoneID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find load balancer in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Load Balancer ID: %s", d.Id())
+
+	return resourceCloudFlareLoadBalancerRead(d, meta)
+}
+
+func resourceCloudFlareLoadBalancerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	var lb loadBalancer
+	err := client.apiRequest("GET", loadBalancersURI(zoneID)+"/"+d.Id(), nil, &lb)
+	if isNotFound(err) {
+		log.Printf("[INFO] Load balancer %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding load balancer %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", lb.Name)
+	d.Set("description", lb.Description)
+	d.Set("fallback_pool_id", lb.FallbackPool)
+	d.Set("proxied", lb.Proxied)
+	d.Set("steering_policy", lb.SteeringPolicy)
+	d.Set("session_affinity", lb.SessionAffinity)
+	d.Set("created_on", lb.CreatedOn)
+	d.Set("modified_on", lb.ModifiedOn)
+
+	if err := d.Set("default_pool_ids", lb.DefaultPools); err != nil {
+		return fmt.Errorf("Error setting default_pool_ids: %s", err)
+	}
+
+	pools := loadBalancerLocationPools(lb)
+	for key, location := range loadBalancerPoolMaps {
+		if err := d.Set(key, flattenLoadBalancerPoolMap(location, pools[key])); err != nil {
+			return fmt.Errorf("Error setting %s: %s", key, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceCloudFlareLoadBalancerUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	lb := loadBalancerFromResourceData(d)
+	if err := checkLoadBalancer(lb); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Load Balancer update configuration: %#v", lb)
+
+	if err := client.apiRequest("PUT", loadBalancersURI(zoneID)+"/"+d.Id(), lb, nil); err != nil {
+		return fmt.Errorf("Error updating load balancer %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareLoadBalancerRead(d, meta)
+}
+
+func resourceCloudFlareLoadBalancerDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Load Balancer: %s, %s", zoneID, d.Id())
+
+	err := client.apiRequest("DELETE", loadBalancersURI(zoneID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting load balancer %q: %s", d.Id(), err)
+}
+
+func loadBalancersURI(zoneID string) string {
+	return "/zones/" + zoneID + "/load_balancers"
+}
+
+// checkLoadBalancer ensures that every pool the load balancer refers to
+// has an ID. Pool IDs are usually interpolated from pool resources, so an
+// empty one is only known at apply time.
+func checkLoadBalancer(lb loadBalancer) error {
+	if lb.FallbackPool == "" {
+		return fmt.Errorf("load balancer %q: fallback_pool_id can't be empty", lb.Name)
+	}
+	for _, id := range lb.DefaultPools {
+		if id == "" {
+			return fmt.Errorf("load balancer %q: default_pool_ids can't have empty pool IDs", lb.Name)
+		}
+	}
+
+	pools := loadBalancerLocationPools(lb)
+	for key, location := range loadBalancerPoolMaps {
+		for name, ids := range pools[key] {
+			for _, id := range ids {
+				if id == "" {
+					return fmt.Errorf("load balancer %q: %s of %s %q can't have empty pool IDs", lb.Name, key, location, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// loadBalancerLocationPools returns the pools of lb by location, keyed like
+// loadBalancerPoolMaps.
+func loadBalancerLocationPools(lb loadBalancer) map[string]map[string][]string {
+	return map[string]map[string][]string{
+		"pop_pools":     lb.PopPools,
+		"region_pools":  lb.RegionPools,
+		"country_pools": lb.CountryPools,
+	}
+}
+
+func loadBalancerFromResourceData(d *schema.ResourceData) loadBalancer {
+	return loadBalancer{
+		Name:            d.Get("name").(string),
+		Description:     d.Get("description").(string),
+		DefaultPools:    expandStringList(d.Get("default_pool_ids")),
+		FallbackPool:    d.Get("fallback_pool_id").(string),
+		Proxied:         d.Get("proxied").(bool),
+		SteeringPolicy:  d.Get("steering_policy").(string),
+		SessionAffinity: d.Get("session_affinity").(string),
+		PopPools:        expandLoadBalancerPoolMap("pop", d.Get("pop_pools").(*schema.Set)),
+		RegionPools:     expandLoadBalancerPoolMap("region", d.Get("region_pools").(*schema.Set)),
+		CountryPools:    expandLoadBalancerPoolMap("country", d.Get("country_pools").(*schema.Set)),
+	}
+}
+
+func expandLoadBalancerPoolMap(location string, set *schema.Set) map[string][]string {
+	pools := map[string][]string{}
+	for _, v := range set.List() {
+		m := v.(map[string]interface{})
+		pools[m[location].(string)] = expandStringList(m["pool_ids"])
+	}
+	return pools
+}
+
+func flattenLoadBalancerPoolMap(location string, pools map[string][]string) []interface{} {
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	flattened := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		flattened = append(flattened, map[string]interface{}{
+			location:   name,
+			"pool_ids": stringsToInterfaces(pools[name]),
+		})
+	}
+	return flattened
+}
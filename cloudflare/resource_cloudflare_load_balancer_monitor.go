@@ -0,0 +1,306 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// loadBalancerMonitor is a health check load balancers run against the
+// origins of their pools.
+type loadBalancerMonitor struct {
+	ID            string              `json:"id,omitempty"`
+	Type          string              `json:"type"`
+	Description   string              `json:"description,omitempty"`
+	Method        string              `json:"method,omitempty"`
+	Path          string              `json:"path,omitempty"`
+	Header        map[string][]string `json:"header,omitempty"`
+	Port          int                 `json:"port,omitempty"`
+	ExpectedCodes string              `json:"expected_codes,omitempty"`
+	ExpectedBody  string              `json:"expected_body,omitempty"`
+	Interval      int                 `json:"interval"`
+	Retries       int                 `json:"retries"`
+	Timeout       int                 `json:"timeout"`
+	CreatedOn     string              `json:"created_on,omitempty"`
+	ModifiedOn    string              `json:"modified_on,omitempty"`
+}
+
+func resourceCloudFlareLoadBalancerMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareLoadBalancerMonitorCreate,
+		Read:   resourceCloudFlareLoadBalancerMonitorRead,
+		Update: resourceCloudFlareLoadBalancerMonitorUpdate,
+		Delete: resourceCloudFlareLoadBalancerMonitorDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Changing the type replaces the monitor, as the method and
+			// path Cloudflare defaulted for the old type don't apply to
+			// the new one.
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "http",
+				ValidateFunc: validateLoadBalancerMonitorType,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// method and path default differently for HTTP and TCP
+			// monitors, so they are left to Cloudflare.
+			"method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"header": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"header": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"values": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+				Set: loadBalancerMonitorHeaderHash,
+			},
+
+			"port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"expected_codes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"expected_body": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"interval": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  60,
+			},
+
+			"retries": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  2,
+			},
+
+			"timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  5,
+			},
+
+			"created_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"modified_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareLoadBalancerMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	monitor := loadBalancerMonitorFromResourceData(d)
+	if err := checkLoadBalancerMonitor(monitor); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Load Balancer Monitor create configuration: %#v", monitor)
+
+	var created loadBalancerMonitor
+	if err := client.apiRequest("POST", loadBalancerMonitorsURI(accountID), monitor, &created); err != nil {
+		return fmt.Errorf("Error creating load balancer monitor for account %q: %s", accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find load balancer monitor in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Load Balancer Monitor ID: %s", d.Id())
+
+	return resourceCloudFlareLoadBalancerMonitorRead(d, meta)
+}
+
+func resourceCloudFlareLoadBalancerMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var monitor loadBalancerMonitor
+	err := client.apiRequest("GET", loadBalancerMonitorsURI(accountID)+"/"+d.Id(), nil, &monitor)
+	if isNotFound(err) {
+		log.Printf("[INFO] Load balancer monitor %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding load balancer monitor %q: %s", d.Id(), err)
+	}
+
+	d.Set("type", monitor.Type)
+	d.Set("description", monitor.Description)
+	d.Set("method", monitor.Method)
+	d.Set("path", monitor.Path)
+	d.Set("port", monitor.Port)
+	d.Set("expected_codes", monitor.ExpectedCodes)
+	d.Set("expected_body", monitor.ExpectedBody)
+	d.Set("interval", monitor.Interval)
+	d.Set("retries", monitor.Retries)
+	d.Set("timeout", monitor.Timeout)
+	d.Set("created_on", monitor.CreatedOn)
+	d.Set("modified_on", monitor.ModifiedOn)
+
+	if err := d.Set("header", flattenLoadBalancerMonitorHeader(monitor.Header)); err != nil {
+		return fmt.Errorf("Error setting header: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareLoadBalancerMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	monitor := loadBalancerMonitorFromResourceData(d)
+	if err := checkLoadBalancerMonitor(monitor); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Load Balancer Monitor update configuration: %#v", monitor)
+
+	if err := client.apiRequest("PUT", loadBalancerMonitorsURI(accountID)+"/"+d.Id(), monitor, nil); err != nil {
+		return fmt.Errorf("Error updating load balancer monitor %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareLoadBalancerMonitorRead(d, meta)
+}
+
+func resourceCloudFlareLoadBalancerMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Load Balancer Monitor: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", loadBalancerMonitorsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting load balancer monitor %q: %s", d.Id(), err)
+}
+
+func loadBalancerMonitorsURI(accountID string) string {
+	return "/accounts/" + accountID + "/load_balancers/monitors"
+}
+
+// checkLoadBalancerMonitor ensures that HTTP monitors say which status
+// codes are healthy, and that TCP monitors, which only check that a
+// connection can be made, aren't given HTTP settings.
+func checkLoadBalancerMonitor(monitor loadBalancerMonitor) error {
+	if monitor.Type == "tcp" {
+		if monitor.ExpectedCodes != "" || monitor.ExpectedBody != "" || monitor.Path != "" || len(monitor.Header) > 0 {
+			return fmt.Errorf("load balancer monitor of type \"tcp\" can't have expected_codes, expected_body, path or header")
+		}
+		return nil
+	}
+	if monitor.ExpectedCodes == "" {
+		return fmt.Errorf("load balancer monitor of type %q requires expected_codes", monitor.Type)
+	}
+	return nil
+}
+
+func loadBalancerMonitorFromResourceData(d *schema.ResourceData) loadBalancerMonitor {
+	return loadBalancerMonitor{
+		Type:          d.Get("type").(string),
+		Description:   d.Get("description").(string),
+		Method:        d.Get("method").(string),
+		Path:          d.Get("path").(string),
+		Header:        expandLoadBalancerMonitorHeader(d.Get("header").(*schema.Set)),
+		Port:          d.Get("port").(int),
+		ExpectedCodes: d.Get("expected_codes").(string),
+		ExpectedBody:  d.Get("expected_body").(string),
+		Interval:      d.Get("interval").(int),
+		Retries:       d.Get("retries").(int),
+		Timeout:       d.Get("timeout").(int),
+	}
+}
+
+func expandLoadBalancerMonitorHeader(set *schema.Set) map[string][]string {
+	header := map[string][]string{}
+	for _, v := range set.List() {
+		m := v.(map[string]interface{})
+		header[m["header"].(string)] = expandStringSet(m["values"])
+	}
+	return header
+}
+
+func flattenLoadBalancerMonitorHeader(header map[string][]string) *schema.Set {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	set := schema.NewSet(loadBalancerMonitorHeaderHash, nil)
+	for _, name := range names {
+		set.Add(map[string]interface{}{
+			"header": name,
+			"values": schema.NewSet(schema.HashString, stringsToInterfaces(header[name])),
+		})
+	}
+	return set
+}
+
+// loadBalancerMonitorHeaderHash hashes a header by its name and values,
+// whatever order the values are in.
+func loadBalancerMonitorHeaderHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	values := expandStringSet(m["values"])
+	sort.Strings(values)
+	return hashcode.String(fmt.Sprintf("%s-%v", m["header"], values))
+}
@@ -0,0 +1,200 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareLoadBalancerMonitor_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "cloudflare_load_balancer_monitor.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareLoadBalancerMonitorDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLoadBalancerMonitorConfigHTTPS, accountID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "type", "https"),
+					resource.TestCheckResourceAttr(name, "expected_codes", "2xx"),
+					resource.TestCheckResourceAttr(name, "method", "GET"),
+					resource.TestCheckResourceAttr(name, "path", "/health"),
+					resource.TestCheckResourceAttr(name, "header.#", "1"),
+					resource.TestCheckResourceAttrSet(name, "created_on"),
+					resource.TestCheckResourceAttrSet(name, "modified_on"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLoadBalancerMonitorConfigTCP, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "type", "tcp"),
+					resource.TestCheckResourceAttr(name, "port", "8080"),
+					resource.TestCheckResourceAttr(name, "header.#", "0"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+// TestCloudFlareLoadBalancerMonitor_Roundtrip checks that monitors read
+// back with Cloudflare's defaults filled in match their configuration.
+func TestCloudFlareLoadBalancerMonitor_Roundtrip(t *testing.T) {
+	var stored loadBalancerMonitor
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri := loadBalancerMonitorsURI("abc")
+		switch {
+		case r.URL.Path == uri && r.Method == "POST":
+			stored = loadBalancerMonitor{}
+			if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+				t.Errorf("invalid monitor: %s", err)
+			}
+			stored.ID = "1234"
+			stored.CreatedOn = "2017-06-01T00:00:00Z"
+			stored.ModifiedOn = "2017-06-01T00:00:00Z"
+			if stored.Method == "" {
+				stored.Method = "GET"
+				if stored.Type == "tcp" {
+					stored.Method = "connection_established"
+				}
+			}
+			if stored.Path == "" && stored.Type != "tcp" {
+				stored.Path = "/"
+			}
+			writeTestResult(w, stored)
+		case r.URL.Path == uri+"/1234" && r.Method == "GET":
+			writeTestResult(w, stored)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]map[string]interface{}{
+		"http": {
+			"expected_codes": "2xx",
+			"header": []interface{}{map[string]interface{}{
+				"header": "Host",
+				"values": []interface{}{"example.com", "www.example.com"},
+			}},
+		},
+		"tcp": {
+			"type": "tcp",
+			"port": 8080,
+		},
+	}
+
+	for tn, c := range cases {
+		c["account_id"] = "abc"
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareLoadBalancerMonitor().Schema, c)
+		if err := resourceCloudFlareLoadBalancerMonitorCreate(d, client); err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if d.Get("created_on") != stored.CreatedOn || d.Get("method") != stored.Method {
+			t.Fatalf("%s: bad state: created_on %v, method %v", tn, d.Get("created_on"), d.Get("method"))
+		}
+
+		raw, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		diff, err := resourceCloudFlareLoadBalancerMonitor().Diff(d.State(), terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Fatalf("%s: expected no diff after create, got %#v", tn, diff)
+		}
+	}
+}
+
+func TestCheckLoadBalancerMonitor(t *testing.T) {
+	cases := []struct {
+		monitor loadBalancerMonitor
+		valid   bool
+	}{
+		{loadBalancerMonitor{Type: "http", ExpectedCodes: "200"}, true},
+		{loadBalancerMonitor{Type: "https"}, false},
+		{loadBalancerMonitor{Type: "tcp", Port: 8080}, true},
+		{loadBalancerMonitor{Type: "tcp", ExpectedCodes: "200"}, false},
+		{loadBalancerMonitor{Type: "tcp", Header: map[string][]string{"Host": {"example.com"}}}, false},
+	}
+
+	for _, c := range cases {
+		err := checkLoadBalancerMonitor(c.monitor)
+		if c.valid && err != nil {
+			t.Fatalf("%#v should be valid: %s", c.monitor, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%#v should not be valid", c.monitor)
+		}
+	}
+}
+
+func testAccCheckCloudFlareLoadBalancerMonitorDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_load_balancer_monitor" {
+			continue
+		}
+
+		uri := loadBalancerMonitorsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Load balancer monitor still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareLoadBalancerMonitorConfigHTTPS = `
+resource "cloudflare_load_balancer_monitor" "foobar" {
+	account_id = "%s"
+	type = "https"
+	expected_codes = "2xx"
+	expected_body = "ok"
+	method = "GET"
+	path = "/health"
+	interval = 60
+	retries = 5
+	timeout = 7
+
+	header {
+		header = "Host"
+		values = ["%s"]
+	}
+}`
+
+const testAccCheckCloudFlareLoadBalancerMonitorConfigTCP = `
+resource "cloudflare_load_balancer_monitor" "foobar" {
+	account_id = "%s"
+	type = "tcp"
+	port = 8080
+}`
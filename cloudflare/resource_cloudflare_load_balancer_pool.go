@@ -0,0 +1,276 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// loadBalancerPool is a group of origins a load balancer spreads requests
+// over, checked by a monitor.
+type loadBalancerPool struct {
+	ID                string                   `json:"id,omitempty"`
+	Name              string                   `json:"name"`
+	Origins           []loadBalancerPoolOrigin `json:"origins"`
+	Monitor           string                   `json:"monitor,omitempty"`
+	Enabled           bool                     `json:"enabled"`
+	MinimumOrigins    int                      `json:"minimum_origins"`
+	NotificationEmail string                   `json:"notification_email,omitempty"`
+	CreatedOn         string                   `json:"created_on,omitempty"`
+	ModifiedOn        string                   `json:"modified_on,omitempty"`
+}
+
+type loadBalancerPoolOrigin struct {
+	Name    string  `json:"name"`
+	Address string  `json:"address"`
+	Weight  float64 `json:"weight"`
+	Enabled bool    `json:"enabled"`
+}
+
+func resourceCloudFlareLoadBalancerPool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareLoadBalancerPoolCreate,
+		Read:   resourceCloudFlareLoadBalancerPoolRead,
+		Update: resourceCloudFlareLoadBalancerPoolUpdate,
+		Delete: resourceCloudFlareLoadBalancerPoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Cloudflare doesn't return origins in the order they were
+			// configured in, so they are a set hashed by their settings.
+			"origins": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"weight": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      1.0,
+							ValidateFunc: validateLoadBalancerPoolOriginWeight,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+				Set: loadBalancerPoolOriginHash,
+			},
+
+			"monitor": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"minimum_origins": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"notification_email": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"created_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"modified_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareLoadBalancerPoolCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	pool := loadBalancerPoolFromResourceData(d)
+	if err := checkLoadBalancerPool(pool); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Load Balancer Pool create configuration: %#v", pool)
+
+	var created loadBalancerPool
+	if err := client.apiRequest("POST", loadBalancerPoolsURI(accountID), pool, &created); err != nil {
+		return fmt.Errorf("Error creating load balancer pool %q for account %q: %s", pool.Name, accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find load balancer pool in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Load Balancer Pool ID: %s", d.Id())
+
+	return resourceCloudFlareLoadBalancerPoolRead(d, meta)
+}
+
+func resourceCloudFlareLoadBalancerPoolRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var pool loadBalancerPool
+	err := client.apiRequest("GET", loadBalancerPoolsURI(accountID)+"/"+d.Id(), nil, &pool)
+	if isNotFound(err) {
+		log.Printf("[INFO] Load balancer pool %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding load balancer pool %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", pool.Name)
+	d.Set("monitor", pool.Monitor)
+	d.Set("enabled", pool.Enabled)
+	d.Set("minimum_origins", pool.MinimumOrigins)
+	d.Set("notification_email", pool.NotificationEmail)
+	d.Set("created_on", pool.CreatedOn)
+	d.Set("modified_on", pool.ModifiedOn)
+
+	if err := d.Set("origins", flattenLoadBalancerPoolOrigins(pool.Origins)); err != nil {
+		return fmt.Errorf("Error setting origins: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareLoadBalancerPoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	pool := loadBalancerPoolFromResourceData(d)
+	if err := checkLoadBalancerPool(pool); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Load Balancer Pool update configuration: %#v", pool)
+
+	if err := client.apiRequest("PUT", loadBalancerPoolsURI(accountID)+"/"+d.Id(), pool, nil); err != nil {
+		return fmt.Errorf("Error updating load balancer pool %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareLoadBalancerPoolRead(d, meta)
+}
+
+func resourceCloudFlareLoadBalancerPoolDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Load Balancer Pool: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", loadBalancerPoolsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting load balancer pool %q: %s", d.Id(), err)
+}
+
+func loadBalancerPoolsURI(accountID string) string {
+	return "/accounts/" + accountID + "/load_balancers/pools"
+}
+
+// checkLoadBalancerPool ensures that origin names are unique, and that the
+// pool has enough origins to ever be healthy.
+func checkLoadBalancerPool(pool loadBalancerPool) error {
+	names := map[string]bool{}
+	for _, origin := range pool.Origins {
+		if names[origin.Name] {
+			return fmt.Errorf("load balancer pool %q has more than one origin named %q", pool.Name, origin.Name)
+		}
+		names[origin.Name] = true
+	}
+	if pool.MinimumOrigins > len(pool.Origins) {
+		return fmt.Errorf("load balancer pool %q has minimum_origins %d but only %d origins", pool.Name, pool.MinimumOrigins, len(pool.Origins))
+	}
+	return nil
+}
+
+func loadBalancerPoolFromResourceData(d *schema.ResourceData) loadBalancerPool {
+	return loadBalancerPool{
+		Name:              d.Get("name").(string),
+		Origins:           expandLoadBalancerPoolOrigins(d.Get("origins").(*schema.Set)),
+		Monitor:           d.Get("monitor").(string),
+		Enabled:           d.Get("enabled").(bool),
+		MinimumOrigins:    d.Get("minimum_origins").(int),
+		NotificationEmail: d.Get("notification_email").(string),
+	}
+}
+
+func expandLoadBalancerPoolOrigins(set *schema.Set) []loadBalancerPoolOrigin {
+	origins := make([]loadBalancerPoolOrigin, 0, set.Len())
+	for _, v := range set.List() {
+		m := v.(map[string]interface{})
+		origins = append(origins, loadBalancerPoolOrigin{
+			Name:    m["name"].(string),
+			Address: m["address"].(string),
+			Weight:  m["weight"].(float64),
+			Enabled: m["enabled"].(bool),
+		})
+	}
+	return origins
+}
+
+// flattenLoadBalancerPoolOrigins sorts origins by name, so that the state
+// doesn't depend on the order Cloudflare returned them in.
+func flattenLoadBalancerPoolOrigins(origins []loadBalancerPoolOrigin) *schema.Set {
+	sorted := make([]loadBalancerPoolOrigin, len(origins))
+	copy(sorted, origins)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	set := schema.NewSet(loadBalancerPoolOriginHash, nil)
+	for _, origin := range sorted {
+		set.Add(map[string]interface{}{
+			"name":    origin.Name,
+			"address": origin.Address,
+			"weight":  origin.Weight,
+			"enabled": origin.Enabled,
+		})
+	}
+	return set
+}
+
+func loadBalancerPoolOriginHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s-%s-%g-%t", m["name"], m["address"], m["weight"], m["enabled"]))
+}
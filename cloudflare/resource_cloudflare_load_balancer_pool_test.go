@@ -0,0 +1,186 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareLoadBalancerPool_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_load_balancer_pool.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareLoadBalancerPoolDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLoadBalancerPoolConfig, accountID, accountID, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-pool"),
+					resource.TestCheckResourceAttr(name, "origins.#", "2"),
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "minimum_origins", "1"),
+					resource.TestCheckResourceAttrPair(name, "monitor", "cloudflare_load_balancer_monitor.foobar", "id"),
+					resource.TestCheckResourceAttrSet(name, "created_on"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLoadBalancerPoolConfig, accountID, accountID, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "minimum_origins", "2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+// TestCloudFlareLoadBalancerPool_Roundtrip checks that pools whose origins
+// Cloudflare returns in another order than configured don't have a diff.
+func TestCloudFlareLoadBalancerPool_Roundtrip(t *testing.T) {
+	var stored loadBalancerPool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri := loadBalancerPoolsURI("abc")
+		switch {
+		case r.URL.Path == uri && r.Method == "POST":
+			stored = loadBalancerPool{}
+			if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+				t.Errorf("invalid pool: %s", err)
+			}
+			stored.ID = "1234"
+			stored.CreatedOn = "2017-06-01T00:00:00Z"
+			stored.ModifiedOn = "2017-06-01T00:00:00Z"
+			for i, j := 0, len(stored.Origins)-1; i < j; i, j = i+1, j-1 {
+				stored.Origins[i], stored.Origins[j] = stored.Origins[j], stored.Origins[i]
+			}
+			writeTestResult(w, stored)
+		case r.URL.Path == uri+"/1234" && r.Method == "GET":
+			writeTestResult(w, stored)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	c := map[string]interface{}{
+		"account_id": "abc",
+		"name":       "terraform-pool",
+		"origins": []interface{}{
+			map[string]interface{}{"name": "origin-b", "address": "192.0.2.2", "weight": 0.5},
+			map[string]interface{}{"name": "origin-a", "address": "192.0.2.1"},
+			map[string]interface{}{"name": "origin-c", "address": "192.0.2.3", "enabled": false},
+		},
+		"minimum_origins":    2,
+		"notification_email": "ops@example.com",
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareLoadBalancerPool().Schema, c)
+	if err := resourceCloudFlareLoadBalancerPoolCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("origins.#") != 3 || d.Get("created_on") != stored.CreatedOn {
+		t.Fatalf("bad state: origins.# %v, created_on %v", d.Get("origins.#"), d.Get("created_on"))
+	}
+
+	raw, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := resourceCloudFlareLoadBalancerPool().Diff(d.State(), terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff after create, got %#v", diff)
+	}
+}
+
+func TestCheckLoadBalancerPool(t *testing.T) {
+	origin := func(name string) loadBalancerPoolOrigin {
+		return loadBalancerPoolOrigin{Name: name, Address: name + ".example.com", Weight: 1, Enabled: true}
+	}
+
+	cases := []struct {
+		pool  loadBalancerPool
+		valid bool
+	}{
+		{loadBalancerPool{Origins: []loadBalancerPoolOrigin{origin("a")}, MinimumOrigins: 1}, true},
+		{loadBalancerPool{Origins: []loadBalancerPoolOrigin{origin("a"), origin("b")}, MinimumOrigins: 2}, true},
+		{loadBalancerPool{Origins: []loadBalancerPoolOrigin{origin("a")}, MinimumOrigins: 2}, false},
+		{loadBalancerPool{Origins: []loadBalancerPoolOrigin{origin("a"), origin("a")}, MinimumOrigins: 1}, false},
+	}
+
+	for _, c := range cases {
+		err := checkLoadBalancerPool(c.pool)
+		if c.valid && err != nil {
+			t.Fatalf("%#v should be valid: %s", c.pool, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%#v should not be valid", c.pool)
+		}
+	}
+}
+
+func testAccCheckCloudFlareLoadBalancerPoolDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_load_balancer_pool" {
+			continue
+		}
+
+		uri := loadBalancerPoolsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Load balancer pool still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareLoadBalancerPoolConfig = `
+resource "cloudflare_load_balancer_monitor" "foobar" {
+	account_id = "%s"
+	expected_codes = "2xx"
+}
+
+resource "cloudflare_load_balancer_pool" "foobar" {
+	account_id = "%s"
+	name = "terraform-pool"
+	monitor = "${cloudflare_load_balancer_monitor.foobar.id}"
+	minimum_origins = %d
+
+	origins {
+		name = "origin-a"
+		address = "192.0.2.1"
+	}
+
+	origins {
+		name = "origin-b"
+		address = "192.0.2.2"
+		weight = 0.5
+	}
+}`
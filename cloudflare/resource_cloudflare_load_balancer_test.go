@@ -0,0 +1,202 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareLoadBalancer_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "cloudflare_load_balancer.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLoadBalancerConfig, accountID, accountID, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-lb."+domain),
+					resource.TestCheckResourceAttr(name, "default_pool_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(name, "default_pool_ids.0", "cloudflare_load_balancer_pool.primary", "id"),
+					resource.TestCheckResourceAttrPair(name, "fallback_pool_id", "cloudflare_load_balancer_pool.secondary", "id"),
+					resource.TestCheckResourceAttr(name, "steering_policy", "geo"),
+					resource.TestCheckResourceAttr(name, "session_affinity", "cookie"),
+					resource.TestCheckResourceAttr(name, "region_pools.#", "1"),
+					resource.TestCheckResourceAttrSet(name, "created_on"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: zoneID + "/",
+			},
+		},
+	})
+}
+
+// TestCloudFlareLoadBalancer_Roundtrip checks that load balancers with
+// pools by location read back the same as they were configured.
+func TestCloudFlareLoadBalancer_Roundtrip(t *testing.T) {
+	var stored loadBalancer
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri := loadBalancersURI("abc")
+		switch {
+		case r.URL.Path == uri && r.Method == "POST":
+			stored = loadBalancer{}
+			if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+				t.Errorf("invalid load balancer: %s", err)
+			}
+			stored.ID = "1234"
+			stored.CreatedOn = "2017-06-01T00:00:00Z"
+			stored.ModifiedOn = "2017-06-01T00:00:00Z"
+			writeTestResult(w, stored)
+		case r.URL.Path == uri+"/1234" && r.Method == "GET":
+			writeTestResult(w, stored)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	c := map[string]interface{}{
+		"zone_id":          "abc",
+		"name":             "www.example.com",
+		"default_pool_ids": []interface{}{"pool-b", "pool-a"},
+		"fallback_pool_id": "pool-c",
+		"proxied":          true,
+		"steering_policy":  "geo",
+		"session_affinity": "cookie",
+		"region_pools": []interface{}{
+			map[string]interface{}{"region": "WNAM", "pool_ids": []interface{}{"pool-a"}},
+			map[string]interface{}{"region": "ENAM", "pool_ids": []interface{}{"pool-b", "pool-a"}},
+		},
+		"country_pools": []interface{}{
+			map[string]interface{}{"country": "GB", "pool_ids": []interface{}{"pool-c"}},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareLoadBalancer().Schema, c)
+	if err := resourceCloudFlareLoadBalancerCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if pools := stored.RegionPools["ENAM"]; len(pools) != 2 || pools[0] != "pool-b" {
+		t.Fatalf("bad region_pools sent: %v", stored.RegionPools)
+	}
+	if len(stored.PopPools) != 0 || stored.PopPools == nil {
+		t.Fatalf("expected empty pop_pools to be sent, got %#v", stored.PopPools)
+	}
+	if d.Get("default_pool_ids.0") != "pool-b" || d.Get("created_on") != stored.CreatedOn {
+		t.Fatalf("bad state: default_pool_ids.0 %v, created_on %v", d.Get("default_pool_ids.0"), d.Get("created_on"))
+	}
+
+	raw, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := resourceCloudFlareLoadBalancer().Diff(d.State(), terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff after create, got %#v", diff)
+	}
+}
+
+func TestCheckLoadBalancer(t *testing.T) {
+	cases := []struct {
+		lb    loadBalancer
+		valid bool
+	}{
+		{loadBalancer{DefaultPools: []string{"a"}, FallbackPool: "b"}, true},
+		{loadBalancer{DefaultPools: []string{"a"}, FallbackPool: "b", PopPools: map[string][]string{"LAX": {"a"}}}, true},
+		{loadBalancer{DefaultPools: []string{"a"}}, false},
+		{loadBalancer{DefaultPools: []string{"a", ""}, FallbackPool: "b"}, false},
+		{loadBalancer{DefaultPools: []string{"a"}, FallbackPool: "b", CountryPools: map[string][]string{"GB": {""}}}, false},
+	}
+
+	for _, c := range cases {
+		err := checkLoadBalancer(c.lb)
+		if c.valid && err != nil {
+			t.Fatalf("%#v should be valid: %s", c.lb, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%#v should not be valid", c.lb)
+		}
+	}
+}
+
+func testAccCheckCloudFlareLoadBalancerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_load_balancer" {
+			continue
+		}
+
+		uri := loadBalancersURI(rs.Primary.Attributes["zone_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Load balancer still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareLoadBalancerConfig = `
+resource "cloudflare_load_balancer_pool" "primary" {
+	account_id = "%s"
+	name = "terraform-primary"
+
+	origins {
+		name = "origin-a"
+		address = "192.0.2.1"
+	}
+}
+
+resource "cloudflare_load_balancer_pool" "secondary" {
+	account_id = "%s"
+	name = "terraform-secondary"
+
+	origins {
+		name = "origin-b"
+		address = "192.0.2.2"
+	}
+}
+
+resource "cloudflare_load_balancer" "foobar" {
+	zone_id = "%s"
+	name = "terraform-lb.%s"
+	default_pool_ids = ["${cloudflare_load_balancer_pool.primary.id}", "${cloudflare_load_balancer_pool.secondary.id}"]
+	fallback_pool_id = "${cloudflare_load_balancer_pool.secondary.id}"
+	proxied = true
+	steering_policy = "geo"
+	session_affinity = "cookie"
+
+	region_pools {
+		region = "WEU"
+		pool_ids = ["${cloudflare_load_balancer_pool.secondary.id}"]
+	}
+}`
@@ -0,0 +1,211 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// logpushJob pushes the logs of a dataset to a destination.
+type logpushJob struct {
+	ID                 int    `json:"id,omitempty"`
+	Dataset            string `json:"dataset,omitempty"`
+	Enabled            bool   `json:"enabled"`
+	Kind               string `json:"kind,omitempty"`
+	Name               string `json:"name,omitempty"`
+	LogpullOptions     string `json:"logpull_options"`
+	DestinationConf    string `json:"destination_conf"`
+	OwnershipChallenge string `json:"ownership_challenge,omitempty"`
+	Frequency          string `json:"frequency,omitempty"`
+}
+
+// logpushEdgeDatasets are the datasets that can be pushed from the edge as
+// they happen, rather than in batches.
+var logpushEdgeDatasets = []string{"firewall_events", "http_requests"}
+
+func resourceCloudFlareLogpushJob() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareLogpushJobCreate,
+		Read:   resourceCloudFlareLogpushJobRead,
+		Update: resourceCloudFlareLogpushJobUpdate,
+		Delete: resourceCloudFlareLogpushJobDelete,
+		Importer: &schema.ResourceImporter{
+			State: importZoneScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"dataset": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"kind": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateLogpushJobKind,
+			},
+
+			"frequency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateLogpushJobFrequency,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"logpull_options": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"destination_conf": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ownership_challenge": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareLogpushJobCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	job := logpushJobFromResourceData(d)
+	if err := checkLogpushJobDataset(job.Kind, job.Dataset); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Logpush Job create configuration: %#v", job)
+
+	var created logpushJob
+	if err := client.apiRequest("POST", logpushJobsURI(zoneID), job, &created); err != nil {
+		return fmt.Errorf("Error creating logpush job for zone %q: %s", zoneID, err)
+	}
+
+	if created.ID == 0 {
+		return fmt.Errorf("Failed to find logpush job in create response; ID was empty")
+	}
+
+	d.SetId(strconv.Itoa(created.ID))
+
+	log.Printf("[INFO] CloudFlare Logpush Job ID: %s", d.Id())
+
+	return resourceCloudFlareLogpushJobRead(d, meta)
+}
+
+func resourceCloudFlareLogpushJobRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	var job logpushJob
+	err := client.apiRequest("GET", logpushJobsURI(zoneID)+"/"+d.Id(), nil, &job)
+	if isNotFound(err) {
+		log.Printf("[INFO] Logpush job %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding logpush job %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", job.Name)
+	d.Set("dataset", job.Dataset)
+	d.Set("kind", job.Kind)
+	d.Set("frequency", job.Frequency)
+	d.Set("enabled", job.Enabled)
+	d.Set("logpull_options", job.LogpullOptions)
+	d.Set("destination_conf", job.DestinationConf)
+
+	return nil
+}
+
+func resourceCloudFlareLogpushJobUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	// The dataset, kind and name of a job cannot be changed.
+	job := logpushJobFromResourceData(d)
+	job.Dataset = ""
+	job.Kind = ""
+	job.Name = ""
+
+	log.Printf("[DEBUG] CloudFlare Logpush Job update configuration: %#v", job)
+
+	if err := client.apiRequest("PUT", logpushJobsURI(zoneID)+"/"+d.Id(), job, nil); err != nil {
+		return fmt.Errorf("Error updating logpush job %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareLogpushJobRead(d, meta)
+}
+
+func resourceCloudFlareLogpushJobDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Logpush Job: %s, %s", zoneID, d.Id())
+
+	err := client.apiRequest("DELETE", logpushJobsURI(zoneID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting logpush job %q: %s", d.Id(), err)
+}
+
+func logpushJobsURI(zoneID string) string {
+	return "/zones/" + zoneID + "/logpush/jobs"
+}
+
+func logpushJobFromResourceData(d *schema.ResourceData) logpushJob {
+	return logpushJob{
+		Name:               d.Get("name").(string),
+		Dataset:            d.Get("dataset").(string),
+		Kind:               d.Get("kind").(string),
+		Frequency:          d.Get("frequency").(string),
+		Enabled:            d.Get("enabled").(bool),
+		LogpullOptions:     d.Get("logpull_options").(string),
+		DestinationConf:    d.Get("destination_conf").(string),
+		OwnershipChallenge: d.Get("ownership_challenge").(string),
+	}
+}
+
+// checkLogpushJobDataset ensures that edge jobs only push datasets that are
+// available from the edge. It can't be a ValidateFunc as it depends on two
+// fields.
+func checkLogpushJobDataset(kind, dataset string) error {
+	if kind != "edge" {
+		return nil
+	}
+
+	for _, supported := range logpushEdgeDatasets {
+		if dataset == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("Dataset %q is not available to edge logpush jobs. Supported datasets are %q", dataset, logpushEdgeDatasets)
+}
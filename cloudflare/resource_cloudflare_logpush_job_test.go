@@ -0,0 +1,146 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareLogpushJob_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	destination := os.Getenv("CLOUDFLARE_LOGPUSH_DESTINATION_CONF")
+	challenge := os.Getenv("CLOUDFLARE_LOGPUSH_OWNERSHIP_CHALLENGE")
+	name := "cloudflare_logpush_job.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+			testAccPreCheckLogpushDestination(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareLogpushJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLogpushJobConfigBasic, zoneID, destination, challenge),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "dataset", "http_requests"),
+					resource.TestCheckResourceAttr(name, "kind", ""),
+					resource.TestCheckResourceAttr(name, "frequency", "low"),
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     zoneID + "/",
+				ImportStateVerifyIgnore: []string{"ownership_challenge"},
+			},
+		},
+	})
+}
+
+func TestAccCloudFlareLogpushJob_Edge(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	destination := os.Getenv("CLOUDFLARE_LOGPUSH_DESTINATION_CONF")
+	challenge := os.Getenv("CLOUDFLARE_LOGPUSH_OWNERSHIP_CHALLENGE")
+	name := "cloudflare_logpush_job.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+			testAccPreCheckLogpushDestination(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareLogpushJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareLogpushJobConfigEdge, zoneID, destination, challenge),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "dataset", "firewall_events"),
+					resource.TestCheckResourceAttr(name, "kind", "edge"),
+					resource.TestCheckResourceAttr(name, "frequency", "high"),
+				),
+			},
+		},
+	})
+}
+
+func TestCheckLogpushJobDataset(t *testing.T) {
+	cases := []struct {
+		kind    string
+		dataset string
+		valid   bool
+	}{
+		{"", "spectrum_events", true},
+		{"edge", "firewall_events", true},
+		{"edge", "http_requests", true},
+		{"edge", "spectrum_events", false},
+	}
+
+	for _, c := range cases {
+		err := checkLogpushJobDataset(c.kind, c.dataset)
+		if c.valid && err != nil {
+			t.Fatalf("%q job of %q should be valid: %s", c.kind, c.dataset, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%q job of %q should not be valid", c.kind, c.dataset)
+		}
+	}
+}
+
+func testAccPreCheckLogpushDestination(t *testing.T) {
+	if v := os.Getenv("CLOUDFLARE_LOGPUSH_DESTINATION_CONF"); v == "" {
+		t.Fatal("CLOUDFLARE_LOGPUSH_DESTINATION_CONF must be set for this acceptance test")
+	}
+
+	if v := os.Getenv("CLOUDFLARE_LOGPUSH_OWNERSHIP_CHALLENGE"); v == "" {
+		t.Fatal("CLOUDFLARE_LOGPUSH_OWNERSHIP_CHALLENGE must be set for this acceptance test")
+	}
+}
+
+func testAccCheckCloudFlareLogpushJobDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_logpush_job" {
+			continue
+		}
+
+		uri := logpushJobsURI(rs.Primary.Attributes["zone_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Logpush job still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareLogpushJobConfigBasic = `
+resource "cloudflare_logpush_job" "foobar" {
+	zone_id = "%s"
+	name = "terraform"
+	dataset = "http_requests"
+	frequency = "low"
+	enabled = true
+	logpull_options = "fields=ClientIP,EdgeResponseStatus&timestamps=rfc3339"
+	destination_conf = "%s"
+	ownership_challenge = "%s"
+}`
+
+const testAccCheckCloudFlareLogpushJobConfigEdge = `
+resource "cloudflare_logpush_job" "foobar" {
+	zone_id = "%s"
+	name = "terraform-edge"
+	dataset = "firewall_events"
+	kind = "edge"
+	frequency = "high"
+	enabled = true
+	destination_conf = "%s"
+	ownership_challenge = "%s"
+}`
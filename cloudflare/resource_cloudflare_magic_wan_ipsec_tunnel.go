@@ -0,0 +1,301 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// ipsecTunnel connects a customer network to Magic WAN over IPsec. Its
+// pre-shared key is never returned by the API.
+type ipsecTunnel struct {
+	ID                 string                  `json:"id,omitempty"`
+	Name               string                  `json:"name"`
+	Description        string                  `json:"description"`
+	CustomerEndpoint   string                  `json:"customer_endpoint"`
+	CloudflareEndpoint string                  `json:"cloudflare_endpoint"`
+	InterfaceAddress   string                  `json:"interface_address"`
+	PSK                string                  `json:"psk,omitempty"`
+	HealthCheck        *ipsecTunnelHealthCheck `json:"health_check,omitempty"`
+	ReplayProtection   bool                    `json:"replay_protection"`
+	AllowNullCipher    bool                    `json:"allow_null_cipher"`
+	RemoteIdentities   *ipsecTunnelIdentities  `json:"remote_identities,omitempty"`
+}
+
+type ipsecTunnelHealthCheck struct {
+	Enabled   bool   `json:"enabled"`
+	Type      string `json:"type,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	Rate      string `json:"rate,omitempty"`
+}
+
+// ipsecTunnelIdentities are the IKE identities the customer's end of the
+// tunnel authenticates with.
+type ipsecTunnelIdentities struct {
+	HexID  string `json:"hex_id"`
+	FQDNID string `json:"fqdn_id"`
+	UserID string `json:"user_id"`
+}
+
+func resourceCloudFlareMagicWANIPsecTunnel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareMagicWANIPsecTunnelCreate,
+		Read:   resourceCloudFlareMagicWANIPsecTunnelRead,
+		Update: resourceCloudFlareMagicWANIPsecTunnelUpdate,
+		Delete: resourceCloudFlareMagicWANIPsecTunnelDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"customer_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateIPAddress,
+			},
+
+			"cloudflare_endpoint": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateIPAddress,
+			},
+
+			"interface_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDR,
+			},
+
+			// The API never returns psk, so changes made outside Terraform
+			// can't be detected.
+			"psk": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"health_check": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"direction": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"rate": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"replay_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"allow_null_cipher": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"remote_identities": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hex_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fqdn_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareMagicWANIPsecTunnelCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	tunnel := ipsecTunnelFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare IPsec Tunnel create configuration: %s", tunnel.Name)
+
+	var created struct {
+		IPsecTunnels []ipsecTunnel `json:"ipsec_tunnels"`
+	}
+	params := map[string][]ipsecTunnel{"ipsec_tunnels": {tunnel}}
+	if err := client.apiRequest("POST", ipsecTunnelsURI(accountID), params, &created); err != nil {
+		return fmt.Errorf("Error creating IPsec tunnel %q for account %q: %s", tunnel.Name, accountID, err)
+	}
+
+	if len(created.IPsecTunnels) != 1 || created.IPsecTunnels[0].ID == "" {
+		return fmt.Errorf("Failed to find IPsec tunnel in create response; ID was empty")
+	}
+
+	d.SetId(created.IPsecTunnels[0].ID)
+
+	log.Printf("[INFO] CloudFlare IPsec Tunnel ID: %s", d.Id())
+
+	return resourceCloudFlareMagicWANIPsecTunnelRead(d, meta)
+}
+
+func resourceCloudFlareMagicWANIPsecTunnelRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var result struct {
+		IPsecTunnel ipsecTunnel `json:"ipsec_tunnel"`
+	}
+	err := client.apiRequest("GET", ipsecTunnelsURI(accountID)+"/"+d.Id(), nil, &result)
+	if isNotFound(err) {
+		log.Printf("[INFO] IPsec tunnel %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding IPsec tunnel %q: %s", d.Id(), err)
+	}
+
+	tunnel := result.IPsecTunnel
+	d.Set("name", tunnel.Name)
+	d.Set("description", tunnel.Description)
+	d.Set("customer_endpoint", tunnel.CustomerEndpoint)
+	d.Set("cloudflare_endpoint", tunnel.CloudflareEndpoint)
+	d.Set("interface_address", tunnel.InterfaceAddress)
+	d.Set("replay_protection", tunnel.ReplayProtection)
+	d.Set("allow_null_cipher", tunnel.AllowNullCipher)
+	if err := d.Set("health_check", flattenIPsecTunnelHealthCheck(tunnel.HealthCheck)); err != nil {
+		return fmt.Errorf("Error setting health_check: %s", err)
+	}
+	if err := d.Set("remote_identities", flattenIPsecTunnelIdentities(tunnel.RemoteIdentities)); err != nil {
+		return fmt.Errorf("Error setting remote_identities: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareMagicWANIPsecTunnelUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	tunnel := ipsecTunnelFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare IPsec Tunnel update configuration: %s", tunnel.Name)
+
+	if err := client.apiRequest("PUT", ipsecTunnelsURI(accountID)+"/"+d.Id(), tunnel, nil); err != nil {
+		return fmt.Errorf("Error updating IPsec tunnel %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareMagicWANIPsecTunnelRead(d, meta)
+}
+
+func resourceCloudFlareMagicWANIPsecTunnelDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare IPsec Tunnel: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", ipsecTunnelsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting IPsec tunnel %q: %s", d.Id(), err)
+}
+
+func ipsecTunnelsURI(accountID string) string {
+	return "/accounts/" + accountID + "/magic/ipsec_tunnels"
+}
+
+func ipsecTunnelFromResourceData(d *schema.ResourceData) ipsecTunnel {
+	tunnel := ipsecTunnel{
+		Name:               d.Get("name").(string),
+		Description:        d.Get("description").(string),
+		CustomerEndpoint:   d.Get("customer_endpoint").(string),
+		CloudflareEndpoint: d.Get("cloudflare_endpoint").(string),
+		InterfaceAddress:   d.Get("interface_address").(string),
+		PSK:                d.Get("psk").(string),
+		ReplayProtection:   d.Get("replay_protection").(bool),
+		AllowNullCipher:    d.Get("allow_null_cipher").(bool),
+	}
+
+	if v, ok := d.GetOk("health_check"); ok {
+		m := v.([]interface{})[0].(map[string]interface{})
+		tunnel.HealthCheck = &ipsecTunnelHealthCheck{
+			Enabled:   m["enabled"].(bool),
+			Type:      m["type"].(string),
+			Direction: m["direction"].(string),
+			Rate:      m["rate"].(string),
+		}
+	}
+
+	return tunnel
+}
+
+func flattenIPsecTunnelHealthCheck(healthCheck *ipsecTunnelHealthCheck) []interface{} {
+	if healthCheck == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"enabled":   healthCheck.Enabled,
+		"type":      healthCheck.Type,
+		"direction": healthCheck.Direction,
+		"rate":      healthCheck.Rate,
+	}}
+}
+
+func flattenIPsecTunnelIdentities(identities *ipsecTunnelIdentities) []interface{} {
+	if identities == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"hex_id":  identities.HexID,
+		"fqdn_id": identities.FQDNID,
+		"user_id": identities.UserID,
+	}}
+}
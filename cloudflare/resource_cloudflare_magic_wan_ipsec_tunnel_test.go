@@ -0,0 +1,83 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareMagicWANIPsecTunnel_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_magic_wan_ipsec_tunnel.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareMagicWANIPsecTunnelDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareMagicWANIPsecTunnelConfig, accountID, "false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "customer_endpoint", "203.0.113.1"),
+					resource.TestCheckResourceAttr(name, "interface_address", "10.212.0.9/31"),
+					resource.TestCheckResourceAttr(name, "replay_protection", "false"),
+					resource.TestCheckResourceAttr(name, "health_check.0.enabled", "true"),
+					resource.TestCheckResourceAttrSet(name, "remote_identities.0.fqdn_id"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareMagicWANIPsecTunnelConfig, accountID, "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "replay_protection", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     accountID + "/",
+				ImportStateVerifyIgnore: []string{"psk"},
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareMagicWANIPsecTunnelDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_magic_wan_ipsec_tunnel" {
+			continue
+		}
+
+		uri := ipsecTunnelsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("IPsec tunnel still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareMagicWANIPsecTunnelConfig = `
+resource "cloudflare_magic_wan_ipsec_tunnel" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	customer_endpoint = "203.0.113.1"
+	cloudflare_endpoint = "162.159.64.41"
+	interface_address = "10.212.0.9/31"
+	psk = "terraform-acctest-psk"
+	replay_protection = %s
+
+	health_check {
+		enabled = true
+		type = "request"
+	}
+}`
@@ -0,0 +1,309 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// pageRule is a page rule as it is written to and read from the API. Its
+// priority is left out when it isn't set, so that the API assigns one.
+type pageRule struct {
+	cloudflare.PageRule
+	Priority int `json:"priority,omitempty"`
+}
+
+func resourceCloudFlarePageRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlarePageRuleCreate,
+		Read:   resourceCloudFlarePageRuleRead,
+		Update: resourceCloudFlarePageRuleUpdate,
+		Delete: resourceCloudFlarePageRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: importZoneScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"target": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"priority": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validatePageRuleStatus,
+			},
+
+			"actions": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"forwarding_url": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"url": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"status_code": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validateForwardingStatusCode,
+									},
+								},
+							},
+						},
+
+						"always_use_https": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						"ssl": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validatePageRuleSSL,
+						},
+
+						"cache_level": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validatePageRuleCacheLevel,
+						},
+
+						"browser_cache_ttl": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlarePageRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	zoneID, domain, err := client.resourceZone(d)
+	if err != nil {
+		return err
+	}
+	d.Set("zone_id", zoneID)
+	d.Set("domain", domain)
+
+	rule, err := pageRuleFromResourceData(d)
+	if err != nil {
+		return fmt.Errorf("Error validating page rule %q: %s", d.Get("target").(string), err)
+	}
+	log.Printf("[DEBUG] CloudFlare Page Rule create configuration: %#v", rule)
+
+	// cloudflare-go's CreatePageRule drops the created rule, and with it
+	// the rule's ID.
+	var created pageRule
+	if err := client.apiRequest("POST", "/zones/"+zoneID+"/pagerules", rule, &created); err != nil {
+		return fmt.Errorf("Error creating page rule %q in zone %q: %s", d.Get("target").(string), domain, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find page rule in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Page Rule ID: %s", d.Id())
+
+	return resourceCloudFlarePageRuleRead(d, meta)
+}
+
+func resourceCloudFlarePageRuleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	zoneID, domain, err := client.resourceZone(d)
+	if err != nil {
+		return err
+	}
+
+	var rule pageRule
+	err = client.apiRequest("GET", "/zones/"+zoneID+"/pagerules/"+d.Id(), nil, &rule)
+	if isNotFound(err) {
+		log.Printf("[INFO] Page rule %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding page rule %q: %s", d.Id(), err)
+	}
+
+	d.Set("zone_id", zoneID)
+	d.Set("domain", domain)
+	d.Set("priority", rule.Priority)
+	d.Set("status", rule.Status)
+	if len(rule.Targets) > 0 {
+		d.Set("target", rule.Targets[0].Constraint.Value)
+	}
+	if err := d.Set("actions", flattenPageRuleActions(rule.Actions)); err != nil {
+		return fmt.Errorf("Error setting actions: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlarePageRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	zoneID, _, err := client.resourceZone(d)
+	if err != nil {
+		return err
+	}
+
+	rule, err := pageRuleFromResourceData(d)
+	if err != nil {
+		return fmt.Errorf("Error validating page rule %q: %s", d.Get("target").(string), err)
+	}
+	log.Printf("[DEBUG] CloudFlare Page Rule update configuration: %#v", rule)
+
+	// cloudflare-go's UpdatePageRule doesn't send the rule.
+	if err := client.apiRequest("PUT", "/zones/"+zoneID+"/pagerules/"+d.Id(), rule, nil); err != nil {
+		return fmt.Errorf("Error updating page rule %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlarePageRuleRead(d, meta)
+}
+
+func resourceCloudFlarePageRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	zoneID, domain, err := client.resourceZone(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting CloudFlare Page Rule: %s, %s", domain, d.Id())
+
+	err = client.apiRequest("DELETE", "/zones/"+zoneID+"/pagerules/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting page rule %q: %s", d.Id(), err)
+}
+
+func pageRuleFromResourceData(d *schema.ResourceData) (pageRule, error) {
+	target := cloudflare.PageRuleTarget{Target: "url"}
+	target.Constraint.Operator = "matches"
+	target.Constraint.Value = d.Get("target").(string)
+
+	actions, err := expandPageRuleActions(d.Get("actions"))
+	if err != nil {
+		return pageRule{}, err
+	}
+
+	return pageRule{
+		PageRule: cloudflare.PageRule{
+			Targets: []cloudflare.PageRuleTarget{target},
+			Actions: actions,
+			Status:  d.Get("status").(string),
+		},
+		Priority: d.Get("priority").(int),
+	}, nil
+}
+
+// expandPageRuleActions turns the actions block into the list of actions
+// the API takes. Unset actions are left out.
+func expandPageRuleActions(v interface{}) ([]cloudflare.PageRuleAction, error) {
+	var m map[string]interface{}
+	if list, ok := v.([]interface{}); ok && len(list) > 0 {
+		m, _ = list[0].(map[string]interface{})
+	}
+
+	var actions []cloudflare.PageRuleAction
+	if m["always_use_https"] == true {
+		actions = append(actions, cloudflare.PageRuleAction{ID: "always_use_https"})
+	}
+	for _, id := range []string{"ssl", "cache_level"} {
+		if value, _ := m[id].(string); value != "" {
+			actions = append(actions, cloudflare.PageRuleAction{ID: id, Value: value})
+		}
+	}
+	if ttl, _ := m["browser_cache_ttl"].(int); ttl != 0 {
+		actions = append(actions, cloudflare.PageRuleAction{ID: "browser_cache_ttl", Value: ttl})
+	}
+
+	if forwarding, _ := m["forwarding_url"].([]interface{}); len(forwarding) > 0 && forwarding[0] != nil {
+		if len(actions) > 0 {
+			return nil, fmt.Errorf("forwarding_url can't be combined with other actions")
+		}
+		f := forwarding[0].(map[string]interface{})
+		actions = append(actions, cloudflare.PageRuleAction{
+			ID: "forwarding_url",
+			Value: map[string]interface{}{
+				"url":         f["url"].(string),
+				"status_code": f["status_code"].(int),
+			},
+		})
+	}
+
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("at least one action must be set")
+	}
+	return actions, nil
+}
+
+// flattenPageRuleActions turns the actions of a rule read from the API back
+// into an actions block. Actions this resource doesn't manage are left out.
+func flattenPageRuleActions(actions []cloudflare.PageRuleAction) []interface{} {
+	m := map[string]interface{}{}
+	for _, action := range actions {
+		switch action.ID {
+		case "always_use_https":
+			m["always_use_https"] = true
+		case "ssl", "cache_level":
+			m[action.ID], _ = action.Value.(string)
+		case "browser_cache_ttl":
+			// JSON numbers are decoded as float64.
+			if ttl, ok := action.Value.(float64); ok {
+				m["browser_cache_ttl"] = int(ttl)
+			}
+		case "forwarding_url":
+			f, _ := action.Value.(map[string]interface{})
+			url, _ := f["url"].(string)
+			statusCode, _ := f["status_code"].(float64)
+			m["forwarding_url"] = []interface{}{map[string]interface{}{
+				"url":         url,
+				"status_code": int(statusCode),
+			}}
+		default:
+			log.Printf("[WARN] Page rule action %q isn't managed by cloudflare_page_rule and is ignored", action.ID)
+		}
+	}
+	return []interface{}{m}
+}
@@ -0,0 +1,219 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlarePageRule_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "cloudflare_page_rule.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlarePageRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlarePageRuleConfigCache, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "target", "terraform."+domain+"/static/*"),
+					resource.TestCheckResourceAttr(name, "status", "active"),
+					resource.TestCheckResourceAttr(name, "domain", domain),
+					resource.TestCheckResourceAttr(name, "actions.0.always_use_https", "true"),
+					resource.TestCheckResourceAttr(name, "actions.0.cache_level", "cache_everything"),
+					resource.TestCheckResourceAttr(name, "actions.0.browser_cache_ttl", "14400"),
+					resource.TestCheckResourceAttrSet(name, "priority"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlarePageRuleConfigForwarding, zoneID, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "status", "disabled"),
+					resource.TestCheckResourceAttr(name, "actions.0.forwarding_url.0.url", "https://www."+domain+"/$1"),
+					resource.TestCheckResourceAttr(name, "actions.0.forwarding_url.0.status_code", "301"),
+					resource.TestCheckResourceAttr(name, "actions.0.cache_level", ""),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: zoneID + "/",
+			},
+		},
+	})
+}
+
+// TestCloudFlarePageRule_Roundtrip checks that the actions read back from
+// the API match the configuration, so that applying it again plans nothing.
+func TestCloudFlarePageRule_Roundtrip(t *testing.T) {
+	rules := map[string]json.RawMessage{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/zones/1234567890" && r.Method == "GET":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "1234567890", "name": "example.com"}}`)
+		case r.URL.Path == "/zones/1234567890/pagerules" && r.Method == "POST":
+			var rule map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+				t.Errorf("invalid page rule: %s", err)
+			}
+			rule["id"] = "abc"
+			if _, ok := rule["priority"]; !ok {
+				rule["priority"] = 1
+			}
+			raw, _ := json.Marshal(rule)
+			rules["abc"] = raw
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": %s}`, raw)
+		case strings.HasPrefix(r.URL.Path, "/zones/1234567890/pagerules/") && r.Method == "GET":
+			raw, ok := rules[strings.TrimPrefix(r.URL.Path, "/zones/1234567890/pagerules/")]
+			if !ok {
+				writeMockError(w, http.StatusNotFound, 1002, "Invalid Page Rule identifier")
+				return
+			}
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": %s}`, raw)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]map[string]interface{}{
+		"cache": {
+			"always_use_https":  true,
+			"ssl":               "strict",
+			"cache_level":       "cache_everything",
+			"browser_cache_ttl": 14400,
+		},
+		"forwarding": {
+			"forwarding_url": []interface{}{map[string]interface{}{
+				"url":         "https://www.example.com/$1",
+				"status_code": 301,
+			}},
+		},
+	}
+
+	for tn, actions := range cases {
+		c := map[string]interface{}{
+			"zone_id": "1234567890",
+			"target":  "example.com/*",
+			"actions": []interface{}{actions},
+		}
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlarePageRule().Schema, c)
+		if err := resourceCloudFlarePageRuleCreate(d, client); err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if d.Get("priority") != 1 || d.Get("domain") != "example.com" {
+			t.Fatalf("%s: bad state: priority %v, domain %v", tn, d.Get("priority"), d.Get("domain"))
+		}
+
+		raw, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		diff, err := resourceCloudFlarePageRule().Diff(d.State(), terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Fatalf("%s: expected no diff after create, got %#v", tn, diff)
+		}
+	}
+}
+
+func TestExpandPageRuleActions(t *testing.T) {
+	forwarding := []interface{}{map[string]interface{}{
+		"url":         "https://www.example.com/$1",
+		"status_code": 302,
+	}}
+
+	cases := map[string]struct {
+		Actions     map[string]interface{}
+		ExpectError string
+	}{
+		"forwarding": {
+			Actions: map[string]interface{}{"forwarding_url": forwarding},
+		},
+		"forwarding with others": {
+			Actions:     map[string]interface{}{"forwarding_url": forwarding, "ssl": "full"},
+			ExpectError: "forwarding_url can't be combined with other actions",
+		},
+		"none": {
+			Actions:     map[string]interface{}{"always_use_https": false, "ssl": "", "browser_cache_ttl": 0},
+			ExpectError: "at least one action must be set",
+		},
+	}
+
+	for tn, tc := range cases {
+		_, err := expandPageRuleActions([]interface{}{tc.Actions})
+		if tc.ExpectError == "" && err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if tc.ExpectError != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectError)) {
+			t.Fatalf("%s: expected error containing %q, got: %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func testAccCheckCloudFlarePageRuleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_page_rule" {
+			continue
+		}
+
+		uri := "/zones/" + rs.Primary.Attributes["zone_id"] + "/pagerules/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Page rule still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlarePageRuleConfigCache = `
+resource "cloudflare_page_rule" "foobar" {
+	zone_id = "%s"
+	target = "terraform.%s/static/*"
+
+	actions {
+		always_use_https = true
+		cache_level = "cache_everything"
+		browser_cache_ttl = 14400
+	}
+}`
+
+const testAccCheckCloudFlarePageRuleConfigForwarding = `
+resource "cloudflare_page_rule" "foobar" {
+	zone_id = "%s"
+	target = "terraform.%s/*"
+	status = "disabled"
+
+	actions {
+		forwarding_url {
+			url = "https://www.%s/$1"
+			status_code = 301
+		}
+	}
+}`
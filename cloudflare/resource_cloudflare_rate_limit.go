@@ -0,0 +1,400 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// rateLimit throttles clients that make more than threshold matching
+// requests within period seconds.
+type rateLimit struct {
+	ID          string          `json:"id,omitempty"`
+	Disabled    bool            `json:"disabled"`
+	Description string          `json:"description,omitempty"`
+	Match       rateLimitMatch  `json:"match"`
+	Threshold   int             `json:"threshold"`
+	Period      int             `json:"period"`
+	Action      rateLimitAction `json:"action"`
+}
+
+type rateLimitMatch struct {
+	Request  rateLimitRequest   `json:"request"`
+	Response *rateLimitResponse `json:"response,omitempty"`
+}
+
+// rateLimitRequest matches requests. Cloudflare matches every method and
+// scheme, reported as "_ALL_", when none are given.
+type rateLimitRequest struct {
+	Methods    []string `json:"methods,omitempty"`
+	Schemes    []string `json:"schemes,omitempty"`
+	URLPattern string   `json:"url"`
+}
+
+// rateLimitResponse only counts requests whose response matches.
+type rateLimitResponse struct {
+	Statuses      []int `json:"status,omitempty"`
+	OriginTraffic bool  `json:"origin_traffic"`
+}
+
+type rateLimitAction struct {
+	Mode     string                   `json:"mode"`
+	Timeout  int                      `json:"timeout,omitempty"`
+	Response *rateLimitActionResponse `json:"response,omitempty"`
+}
+
+// rateLimitActionResponse replaces the page shown to banned clients.
+type rateLimitActionResponse struct {
+	ContentType string `json:"content_type"`
+	Body        string `json:"body"`
+}
+
+func resourceCloudFlareRateLimit() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareRateLimitCreate,
+		Read:   resourceCloudFlareRateLimitRead,
+		Update: resourceCloudFlareRateLimitUpdate,
+		Delete: resourceCloudFlareRateLimitDelete,
+		Importer: &schema.ResourceImporter{
+			State: importZoneScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"threshold": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"period": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateRateLimitPeriod,
+			},
+
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"match": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"request": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"methods": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+									"schemes": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+									"url_pattern": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"response": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"statuses": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeInt},
+										Set:      schema.HashSchema(&schema.Schema{Type: schema.TypeInt}),
+									},
+									"origin_traffic": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"action": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mode": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateRateLimitMode,
+						},
+						"timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"response": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"content_type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateRateLimitContentType,
+									},
+									"body": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareRateLimitCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	limit := rateLimitFromResourceData(d)
+	if err := checkRateLimitAction(limit.Action); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Rate Limit create configuration: %#v", limit)
+
+	var created rateLimit
+	if err := client.apiRequest("POST", rateLimitsURI(zoneID), limit, &created); err != nil {
+		return fmt.Errorf("Error creating rate limit for zone %q: %s", zoneID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find rate limit in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Rate Limit ID: %s", d.Id())
+
+	return resourceCloudFlareRateLimitRead(d, meta)
+}
+
+func resourceCloudFlareRateLimitRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	var limit rateLimit
+	err := client.apiRequest("GET", rateLimitsURI(zoneID)+"/"+d.Id(), nil, &limit)
+	if isNotFound(err) {
+		log.Printf("[INFO] Rate limit %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding rate limit %q: %s", d.Id(), err)
+	}
+
+	d.Set("threshold", limit.Threshold)
+	d.Set("period", limit.Period)
+	d.Set("disabled", limit.Disabled)
+	d.Set("description", limit.Description)
+
+	if err := d.Set("match", flattenRateLimitMatch(limit.Match)); err != nil {
+		return fmt.Errorf("Error setting match: %s", err)
+	}
+
+	if err := d.Set("action", flattenRateLimitAction(limit.Action)); err != nil {
+		return fmt.Errorf("Error setting action: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareRateLimitUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	limit := rateLimitFromResourceData(d)
+	if err := checkRateLimitAction(limit.Action); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Rate Limit update configuration: %#v", limit)
+
+	if err := client.apiRequest("PUT", rateLimitsURI(zoneID)+"/"+d.Id(), limit, nil); err != nil {
+		return fmt.Errorf("Error updating rate limit %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareRateLimitRead(d, meta)
+}
+
+func resourceCloudFlareRateLimitDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Rate Limit: %s, %s", zoneID, d.Id())
+
+	err := client.apiRequest("DELETE", rateLimitsURI(zoneID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting rate limit %q: %s", d.Id(), err)
+}
+
+func rateLimitsURI(zoneID string) string {
+	return "/zones/" + zoneID + "/rate_limits"
+}
+
+// checkRateLimitAction ensures that clients are banned, or the ban
+// simulated, for a timeout, and that challenges, which last until the
+// client passes them, have neither a timeout nor a custom response.
+func checkRateLimitAction(action rateLimitAction) error {
+	switch action.Mode {
+	case "simulate", "ban":
+		if action.Timeout == 0 {
+			return fmt.Errorf("rate limit action %q requires a timeout", action.Mode)
+		}
+	case "challenge", "js_challenge":
+		if action.Timeout != 0 {
+			return fmt.Errorf("rate limit action %q can't have a timeout", action.Mode)
+		}
+		if action.Response != nil {
+			return fmt.Errorf("rate limit action %q can't have a custom response", action.Mode)
+		}
+	}
+	return nil
+}
+
+func rateLimitFromResourceData(d *schema.ResourceData) rateLimit {
+	limit := rateLimit{
+		Disabled:    d.Get("disabled").(bool),
+		Description: d.Get("description").(string),
+		Threshold:   d.Get("threshold").(int),
+		Period:      d.Get("period").(int),
+		Action:      expandRateLimitAction(d.Get("action").([]interface{})[0].(map[string]interface{})),
+	}
+
+	if v, ok := d.GetOk("match"); ok {
+		limit.Match = expandRateLimitMatch(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	return limit
+}
+
+func expandRateLimitMatch(m map[string]interface{}) rateLimitMatch {
+	var match rateLimitMatch
+
+	if v, ok := m["request"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		request := v[0].(map[string]interface{})
+		match.Request = rateLimitRequest{
+			Methods:    expandStringSet(request["methods"]),
+			Schemes:    expandStringSet(request["schemes"]),
+			URLPattern: request["url_pattern"].(string),
+		}
+	}
+
+	if v, ok := m["response"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		response := v[0].(map[string]interface{})
+		match.Response = &rateLimitResponse{OriginTraffic: response["origin_traffic"].(bool)}
+		if statuses, ok := response["statuses"].(*schema.Set); ok {
+			for _, status := range statuses.List() {
+				match.Response.Statuses = append(match.Response.Statuses, status.(int))
+			}
+		}
+	}
+
+	return match
+}
+
+func flattenRateLimitMatch(match rateLimitMatch) []interface{} {
+	m := map[string]interface{}{
+		"request": []interface{}{map[string]interface{}{
+			"methods":     schema.NewSet(schema.HashString, stringsToInterfaces(match.Request.Methods)),
+			"schemes":     schema.NewSet(schema.HashString, stringsToInterfaces(match.Request.Schemes)),
+			"url_pattern": match.Request.URLPattern,
+		}},
+	}
+
+	if match.Response != nil {
+		statuses := make([]interface{}, 0, len(match.Response.Statuses))
+		for _, status := range match.Response.Statuses {
+			statuses = append(statuses, status)
+		}
+		m["response"] = []interface{}{map[string]interface{}{
+			"statuses":       schema.NewSet(schema.HashSchema(&schema.Schema{Type: schema.TypeInt}), statuses),
+			"origin_traffic": match.Response.OriginTraffic,
+		}}
+	}
+
+	return []interface{}{m}
+}
+
+func expandRateLimitAction(m map[string]interface{}) rateLimitAction {
+	action := rateLimitAction{
+		Mode:    m["mode"].(string),
+		Timeout: m["timeout"].(int),
+	}
+
+	if v, ok := m["response"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		response := v[0].(map[string]interface{})
+		action.Response = &rateLimitActionResponse{
+			ContentType: response["content_type"].(string),
+			Body:        response["body"].(string),
+		}
+	}
+
+	return action
+}
+
+func flattenRateLimitAction(action rateLimitAction) []interface{} {
+	m := map[string]interface{}{
+		"mode":    action.Mode,
+		"timeout": action.Timeout,
+	}
+
+	if action.Response != nil {
+		m["response"] = []interface{}{map[string]interface{}{
+			"content_type": action.Response.ContentType,
+			"body":         action.Response.Body,
+		}}
+	}
+
+	return []interface{}{m}
+}
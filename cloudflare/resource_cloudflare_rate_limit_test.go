@@ -0,0 +1,247 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareRateLimit_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "cloudflare_rate_limit.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRateLimitDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRateLimitConfigBan, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "threshold", "100"),
+					resource.TestCheckResourceAttr(name, "period", "60"),
+					resource.TestCheckResourceAttr(name, "match.0.request.0.url_pattern", domain+"/login*"),
+					resource.TestCheckResourceAttr(name, "match.0.request.0.methods.#", "1"),
+					resource.TestCheckResourceAttr(name, "match.0.response.0.statuses.#", "2"),
+					resource.TestCheckResourceAttr(name, "action.0.mode", "ban"),
+					resource.TestCheckResourceAttr(name, "action.0.timeout", "3600"),
+					resource.TestCheckResourceAttr(name, "action.0.response.0.content_type", "application/json"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRateLimitConfigChallenge, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "disabled", "true"),
+					resource.TestCheckResourceAttr(name, "action.0.mode", "challenge"),
+					resource.TestCheckResourceAttr(name, "action.0.timeout", "0"),
+					resource.TestCheckResourceAttr(name, "action.0.response.#", "0"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: zoneID + "/",
+			},
+		},
+	})
+}
+
+// TestCloudFlareRateLimit_Roundtrip checks that rate limits read back from
+// the API match their configuration, whatever their mode, so that applying
+// it again plans nothing.
+func TestCloudFlareRateLimit_Roundtrip(t *testing.T) {
+	var stored rateLimit
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/zones/1234567890/rate_limits" && r.Method == "POST":
+			stored = rateLimit{}
+			if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+				t.Errorf("invalid rate limit: %s", err)
+			}
+			stored.ID = "abc"
+			// Cloudflare reports every method and scheme when none are
+			// given.
+			if len(stored.Match.Request.Methods) == 0 {
+				stored.Match.Request.Methods = []string{"_ALL_"}
+			}
+			if len(stored.Match.Request.Schemes) == 0 {
+				stored.Match.Request.Schemes = []string{"_ALL_"}
+			}
+			writeTestResult(w, stored)
+		case r.URL.Path == "/zones/1234567890/rate_limits/abc" && r.Method == "GET":
+			writeTestResult(w, stored)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]map[string]interface{}{
+		"disabled": {
+			"disabled": true,
+			"action":   []interface{}{map[string]interface{}{"mode": "simulate", "timeout": 60}},
+		},
+		"challenge": {
+			"match": []interface{}{map[string]interface{}{
+				"request": []interface{}{map[string]interface{}{
+					"url_pattern": "example.com/*",
+				}},
+			}},
+			"action": []interface{}{map[string]interface{}{"mode": "challenge"}},
+		},
+		"ban": {
+			"match": []interface{}{map[string]interface{}{
+				"request": []interface{}{map[string]interface{}{
+					"methods":     []interface{}{"POST"},
+					"schemes":     []interface{}{"HTTPS"},
+					"url_pattern": "example.com/login*",
+				}},
+				"response": []interface{}{map[string]interface{}{
+					"statuses":       []interface{}{401, 403},
+					"origin_traffic": false,
+				}},
+			}},
+			"action": []interface{}{map[string]interface{}{
+				"mode":    "ban",
+				"timeout": 3600,
+				"response": []interface{}{map[string]interface{}{
+					"content_type": "application/json",
+					"body":         `{"error": "slow down"}`,
+				}},
+			}},
+		},
+	}
+
+	for tn, c := range cases {
+		c["zone_id"] = "1234567890"
+		c["threshold"] = 100
+		c["period"] = 60
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareRateLimit().Schema, c)
+		if err := resourceCloudFlareRateLimitCreate(d, client); err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if mode := d.Get("action.0.mode"); mode != stored.Action.Mode {
+			t.Fatalf("%s: expected mode %q, got %v", tn, stored.Action.Mode, mode)
+		}
+
+		raw, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		diff, err := resourceCloudFlareRateLimit().Diff(d.State(), terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Fatalf("%s: expected no diff after create, got %#v", tn, diff)
+		}
+	}
+}
+
+func TestCheckRateLimitAction(t *testing.T) {
+	response := &rateLimitActionResponse{ContentType: "text/plain", Body: "slow down"}
+
+	cases := []struct {
+		action rateLimitAction
+		valid  bool
+	}{
+		{rateLimitAction{Mode: "ban", Timeout: 60, Response: response}, true},
+		{rateLimitAction{Mode: "ban"}, false},
+		{rateLimitAction{Mode: "simulate", Timeout: 60}, true},
+		{rateLimitAction{Mode: "simulate"}, false},
+		{rateLimitAction{Mode: "challenge"}, true},
+		{rateLimitAction{Mode: "challenge", Timeout: 60}, false},
+		{rateLimitAction{Mode: "js_challenge", Response: response}, false},
+	}
+
+	for _, c := range cases {
+		err := checkRateLimitAction(c.action)
+		if c.valid && err != nil {
+			t.Fatalf("%#v should be valid: %s", c.action, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%#v should not be valid", c.action)
+		}
+	}
+}
+
+func testAccCheckCloudFlareRateLimitDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_rate_limit" {
+			continue
+		}
+
+		uri := rateLimitsURI(rs.Primary.Attributes["zone_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Rate limit still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareRateLimitConfigBan = `
+resource "cloudflare_rate_limit" "foobar" {
+	zone_id = "%s"
+	threshold = 100
+	period = 60
+
+	match {
+		request {
+			methods = ["POST"]
+			url_pattern = "%s/login*"
+		}
+		response {
+			statuses = [401, 403]
+		}
+	}
+
+	action {
+		mode = "ban"
+		timeout = 3600
+		response {
+			content_type = "application/json"
+			body = "{\"error\": \"slow down\"}"
+		}
+	}
+}`
+
+const testAccCheckCloudFlareRateLimitConfigChallenge = `
+resource "cloudflare_rate_limit" "foobar" {
+	zone_id = "%s"
+	threshold = 100
+	period = 60
+	disabled = true
+
+	match {
+		request {
+			methods = ["POST"]
+			url_pattern = "%s/login*"
+		}
+	}
+
+	action {
+		mode = "challenge"
+	}
+}`
@@ -3,15 +3,55 @@ package cloudflare
 import (
 	"fmt"
 	"log"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
-const recordNotFoundMessage = "Invalid dns record identifier"
+// recordNotFoundErrorCode is returned for record IDs that the zone doesn't
+// have, such as records deleted outside Terraform. Older endpoints return
+// recordInvalidIDErrorCode instead.
+const (
+	recordNotFoundErrorCode  = 81044
+	recordInvalidIDErrorCode = 1032
+)
+
+// recordNotFoundMessages are the messages of the not found errors above,
+// which are only matched when an error carries no status or codes.
+var recordNotFoundMessages = []string{
+	"Invalid dns record identifier",
+	"Record does not exist",
+}
+
+// How long, and how often, records are read after being written until they
+// reflect the write.
+const recordConsistencyTimeout = 10 * time.Second
+
+var recordConsistencyPollInterval = time.Second
+
+// Error codes of record writes rejected for reasons that the record's
+// configuration can't fix.
+const (
+	// recordLockedErrorCode is returned for records that another Cloudflare
+	// product, such as Email Routing, manages.
+	recordLockedErrorCode = 81062
+
+	// zonePendingErrorCode is returned for zones that aren't active yet.
+	zonePendingErrorCode = 1097
+)
 
 func resourceCloudFlareRecord() *schema.Resource {
+	return resourceCloudFlareRecordOf(nil)
+}
+
+// resourceCloudFlareRecordOf is the record resource of provider, whose
+// configuration has the defaults of records that leave proxied unset.
+func resourceCloudFlareRecordOf(provider *schema.Provider) *schema.Resource {
 	return &schema.Resource{
 		Create:   resourceCloudFlareRecordCreate,
 		Read:     resourceCloudFlareRecordRead,
@@ -24,7 +64,8 @@ func resourceCloudFlareRecord() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 
@@ -46,57 +87,185 @@ func resourceCloudFlareRecord() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// value is computed for records with a data block, whose value
+			// the API composes from their data.
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"data"},
+				DiffSuppressFunc: suppressRecordValueDiff,
+			},
+
+			"data": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"value", "priority"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"proto": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"weight": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"target": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"flags": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateCAAFlags,
+						},
+						"tag": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateCAATag,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
 			},
 
 			"ttl": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Computed: true,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validateRecordTTL,
+				DiffSuppressFunc: suppressProxiedTTLDiff(provider),
 			},
 
 			"priority": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DiffSuppressFunc: suppressRecordDataPriorityDiff,
 			},
 
+			// proxied is a string so that records that leave it unset, and get
+			// the provider's default, can be told apart from records that set
+			// it to false. It is stored as "true" or "false".
 			"proxied": {
-				Default:  false,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateBoolString,
+				StateFunc:    normalizeBoolString,
+			},
+
+			"proxiable": {
 				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"created_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"modified_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// settings is computed as records that don't set it keep whatever
+			// they have, e.g. from before it could be configured.
+			"settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"flatten_cname": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
 			},
 
 			"zone_id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
+				ForceNew: true,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateRecordTag,
+				},
+				Set: schema.HashString,
 			},
 		},
 	}
 }
 
 func resourceCloudFlareRecordCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cloudflare.API)
+	client := meta.(*CloudFlareClient)
+
+	zoneID, domain, err := client.resourceZone(d)
+	if err != nil {
+		return err
+	}
+	d.Set("zone_id", zoneID)
+	d.Set("domain", domain)
 
 	subdomain := d.Get("subdomain").(string)
-	domain := d.Get("domain").(string)
 	newRecord := cloudflare.DNSRecord{
 		Type:     d.Get("type").(string),
 		Name:     recordName(subdomain, domain),
-		Content:  d.Get("value").(string),
-		Proxied:  d.Get("proxied").(bool),
+		Content:  strings.TrimSpace(d.Get("value").(string)),
+		Proxied:  client.recordProxied(d.Get("proxied").(string), domain),
+		ZoneID:   zoneID,
 		ZoneName: domain,
 	}
 
-	if priority, ok := d.GetOk("priority"); ok {
-		newRecord.Priority = priority.(int)
-	}
+	// A priority of 0 is valid, so it is read even if it is unset.
+	// validateRecordPriority rejects it on records without a priority.
+	newRecord.Priority = d.Get("priority").(int)
 
-	if ttl, ok := d.GetOk("ttl"); ok {
+	if ttl, ok := d.GetOk("ttl"); ok && !newRecord.Proxied {
 		newRecord.TTL = ttl.(int)
 	}
 
+	data, err := recordDataFromResourceData(d, newRecord.Name)
+	if err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
+	}
+	if data != nil {
+		newRecord.Content = ""
+		newRecord.Data = data
+	}
+
 	// Validate value based on type
 	if err := validateRecordName(newRecord.Type, newRecord.Content); err != nil {
 		return fmt.Errorf("Error validating record name %q: %s", newRecord.Name, err)
@@ -107,129 +276,486 @@ func resourceCloudFlareRecordCreate(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("Error validating record type %q: %s", newRecord.Type, err)
 	}
 
-	zoneID, err := client.ZoneIDByName(newRecord.ZoneName)
-	if err != nil {
-		return fmt.Errorf("Error finding zone %q: %s", newRecord.ZoneName, err)
+	if err := validateNSRecordSubdomain(newRecord.Type, subdomain); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
 	}
 
-	d.Set("zone_id", zoneID)
-	newRecord.ZoneID = zoneID
+	if err := validateSRVRecordName(newRecord.Type, subdomain, d.Get("data.0.service").(string), d.Get("data.0.proto").(string)); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
+	}
+
+	if err := validateRecordPriority(newRecord.Type, newRecord.Priority); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
+	}
+
+	if err := validateProxiedRecordTTL(d.Get("proxied").(string), d.Get("ttl").(int)); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
+	}
+
+	if err := validateDeprecatedRecordName(d.Get("name").(string), subdomain, domain); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
+	}
+
+	if err := validateCNAMETarget(newRecord.Type, newRecord.Name, newRecord.Content); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
+	}
+
+	if err := validatePTRTarget(newRecord.Type, newRecord.Content); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
+	}
+
+	if err := validateTXTContent(newRecord.Type, newRecord.Content); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", newRecord.Name, err)
+	}
+
+	if err := client.checkProxiedRecordTarget(newRecord); err != nil {
+		return err
+	}
+
+	if err := client.checkRecordTTL(newRecord); err != nil {
+		return err
+	}
+
+	client.checkNSDelegation(newRecord)
 
 	log.Printf("[DEBUG] CloudFlare Record create configuration: %#v", newRecord)
 
-	r, err := client.CreateDNSRecord(zoneID, newRecord)
+	record := dnsRecord{
+		DNSRecord: newRecord,
+		Priority:  recordPriority(newRecord),
+		Settings:  recordSettingsFromResourceData(d),
+		Comment:   d.Get("comment").(string),
+		Tags:      expandStringSet(d.Get("tags")),
+	}
+	r, err := client.createDNSRecord(zoneID, record)
+	if isRecordConflict(err) {
+		r, err = client.resolveDNSRecordConflict(zoneID, record, err)
+	}
 	if err != nil {
-		return fmt.Errorf("Failed to create record: %s", err)
+		return fmt.Errorf("Failed to create record: %s", recordWriteError(newRecord, err))
 	}
 
 	// In the Event that the API returns an empty DNS Record, we verify that the
 	// ID returned is not the default ""
-	if r.Result.ID == "" {
+	if r.ID == "" {
 		return fmt.Errorf("Failed to find record in Creat response; Record was empty")
 	}
 
-	d.SetId(r.Result.ID)
+	d.SetId(r.ID)
 
 	log.Printf("[INFO] CloudFlare Record ID: %s", d.Id())
 
+	client.waitForRecordWrite(zoneID, r.DNSRecord)
+
 	return resourceCloudFlareRecordRead(d, meta)
 }
 
 func resourceCloudFlareRecordRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cloudflare.API)
-	domain := d.Get("domain").(string)
+	client := meta.(*CloudFlareClient)
 
-	zoneID, err := client.ZoneIDByName(domain)
+	// The zone is only looked up when it isn't in the state yet, e.g. for
+	// records being imported. domain and zone_id force a new record, so
+	// they always name the same zone.
+	zoneID, domain, err := client.resourceZone(d)
 	if err != nil {
-		return fmt.Errorf("Error finding zone %q: %s", domain, err)
+		return err
 	}
 
-	record, err := client.DNSRecord(zoneID, d.Id())
-	if err != nil && strings.Contains(err.Error(), recordNotFoundMessage) {
+	// Only a record that is gone is removed from the state. Any other error,
+	// such as a 5xx or 429 left after retries, fails the refresh instead, as
+	// the record would otherwise be planned for creation again.
+	var record dnsRecord
+	err = client.apiRequest("GET", "/zones/"+zoneID+"/dns_records/"+d.Id(), nil, &record)
+	if isRecordNotFound(err) {
+		log.Printf("[INFO] CloudFlare Record %s no longer exists", d.Id())
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return err
+		return fmt.Errorf("Error reading CloudFlare Record %q: %s", d.Id(), err)
 	}
 
 	d.SetId(record.ID)
 	d.Set("type", record.Type)
 	d.Set("subdomain", subdomainName(record.Name, domain))
-	d.Set("value", record.Content)
+	d.Set("value", strings.TrimSpace(record.Content))
 	d.Set("ttl", record.TTL)
-	d.Set("priority", record.Priority)
-	d.Set("proxied", record.Proxied)
+	if record.Priority != nil {
+		d.Set("priority", *record.Priority)
+	} else {
+		d.Set("priority", 0)
+	}
+	// Records of types that can't be proxied are read as not proxied,
+	// whatever the API has for them, so that imported records plan clean.
+	proxiable := recordTypeProxiable(record.Type)
+	d.Set("proxied", strconv.FormatBool(record.Proxied && proxiable))
+	d.Set("proxiable", record.Proxiable && proxiable)
+	d.Set("created_on", formatRecordTime(record.CreatedOn))
+	d.Set("modified_on", formatRecordTime(record.ModifiedOn))
 	d.Set("zone_id", zoneID)
+	d.Set("domain", domain)
+	d.Set("comment", record.Comment)
+	if err := d.Set("tags", schema.NewSet(schema.HashString, stringsToInterfaces(record.Tags))); err != nil {
+		return fmt.Errorf("Error setting tags: %s", err)
+	}
+
+	// Records written with a value are read back with one, even if the
+	// API has data for them.
+	if len(d.Get("data").([]interface{})) > 0 {
+		data, err := flattenRecordData(record.Type, record.Data)
+		if err != nil {
+			return fmt.Errorf("Error reading data of CloudFlare Record %q: %s", d.Id(), err)
+		}
+		if err := d.Set("data", data); err != nil {
+			return fmt.Errorf("Error setting data: %s", err)
+		}
+	}
+
+	// A CNAME that its zone flattens can read back as flattened whatever it
+	// was written with, so its configured setting is kept.
+	record.ZoneID, record.ZoneName = zoneID, domain
+	if !client.zoneFlattensCNAME(record.DNSRecord) {
+		if err := d.Set("settings", flattenRecordSettings(record.Settings)); err != nil {
+			return fmt.Errorf("Error setting settings: %s", err)
+		}
+	}
 
 	return nil
 }
 
 func resourceCloudFlareRecordUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cloudflare.API)
+	client := meta.(*CloudFlareClient)
+
+	zoneID, domain, err := client.resourceZone(d)
+	if err != nil {
+		return err
+	}
+
+	// A change to only the comment or tags is sent as a PATCH of just them,
+	// which leaves the rest of the record, and anything reading it, alone.
+	if recordMetadataChangedOnly(d) {
+		_, err := client.updateDNSRecordMetadata(zoneID, d.Id(), d.Get("comment").(string), expandStringSet(d.Get("tags")))
+		if err != nil {
+			return fmt.Errorf("Failed to update CloudFlare Record: %s", err)
+		}
+		return resourceCloudFlareRecordRead(d, meta)
+	}
 
 	subdomain := d.Get("subdomain").(string)
-	domain := d.Get("domain").(string)
 	updateRecord := cloudflare.DNSRecord{
 		ID:       d.Id(),
 		Type:     d.Get("type").(string),
 		Name:     recordName(subdomain, domain),
-		Content:  d.Get("value").(string),
+		Content:  strings.TrimSpace(d.Get("value").(string)),
+		ZoneID:   zoneID,
 		ZoneName: domain,
 		Proxied:  false,
 	}
 
-	if priority, ok := d.GetOk("priority"); ok {
-		updateRecord.Priority = priority.(int)
-	}
+	// A priority of 0 is valid, so it is read even if it is unset.
+	// validateRecordPriority rejects it on records without a priority.
+	updateRecord.Priority = d.Get("priority").(int)
 
-	if proxied, ok := d.GetOk("proxied"); ok {
-		updateRecord.Proxied = proxied.(bool)
-	}
+	updateRecord.Proxied = client.recordProxied(d.Get("proxied").(string), domain)
 
-	if ttl, ok := d.GetOk("ttl"); ok {
+	if ttl, ok := d.GetOk("ttl"); ok && !updateRecord.Proxied {
 		updateRecord.TTL = ttl.(int)
 	}
 
-	zoneID, err := client.ZoneIDByName(updateRecord.ZoneName)
+	data, err := recordDataFromResourceData(d, updateRecord.Name)
 	if err != nil {
-		return fmt.Errorf("Error finding zone %q: %s", updateRecord.ZoneName, err)
+		return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
+	}
+	if data != nil {
+		updateRecord.Content = ""
+		updateRecord.Data = data
+	}
+
+	if err := validateNSRecordSubdomain(updateRecord.Type, subdomain); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
+	}
+
+	if err := validateSRVRecordName(updateRecord.Type, subdomain, d.Get("data.0.service").(string), d.Get("data.0.proto").(string)); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
+	}
+
+	if err := validateRecordPriority(updateRecord.Type, updateRecord.Priority); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
+	}
+
+	// A ttl removed from the configuration is still in the state, so ttl
+	// is only checked when it changes.
+	if ttl, ok := d.GetOk("ttl"); ok && d.HasChange("ttl") {
+		if err := validateProxiedRecordTTL(d.Get("proxied").(string), ttl.(int)); err != nil {
+			return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
+		}
+	}
+
+	if err := validateDeprecatedRecordName(d.Get("name").(string), subdomain, domain); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
+	}
+
+	if err := validateCNAMETarget(updateRecord.Type, updateRecord.Name, updateRecord.Content); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
+	}
+
+	if err := validatePTRTarget(updateRecord.Type, updateRecord.Content); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
+	}
+
+	if err := validateTXTContent(updateRecord.Type, updateRecord.Content); err != nil {
+		return fmt.Errorf("Error validating record %q: %s", updateRecord.Name, err)
 	}
 
-	updateRecord.ZoneID = zoneID
+	if err := client.checkProxiedRecordTarget(updateRecord); err != nil {
+		return err
+	}
+
+	if err := client.checkRecordTTL(updateRecord); err != nil {
+		return err
+	}
+
+	if d.HasChange("subdomain") {
+		client.checkNSDelegation(updateRecord)
+	}
 
 	log.Printf("[DEBUG] CloudFlare Record update configuration: %#v", updateRecord)
-	err = client.UpdateDNSRecord(zoneID, d.Id(), updateRecord)
+	r, err := client.updateDNSRecord(zoneID, d.Id(), dnsRecord{
+		DNSRecord: updateRecord,
+		Priority:  recordPriority(updateRecord),
+		Settings:  recordSettingsFromResourceData(d),
+		Comment:   d.Get("comment").(string),
+		Tags:      expandStringSet(d.Get("tags")),
+	})
 	if err != nil {
-		return fmt.Errorf("Failed to update CloudFlare Record: %s", err)
+		return fmt.Errorf("Failed to update CloudFlare Record: %s", recordWriteError(updateRecord, err))
 	}
 
+	client.waitForRecordWrite(zoneID, r.DNSRecord)
+
 	return resourceCloudFlareRecordRead(d, meta)
 }
 
+// recordMetadataChangedOnly reports whether the comment or tags of the
+// record are all that changed.
+func recordMetadataChangedOnly(d *schema.ResourceData) bool {
+	for k := range resourceCloudFlareRecord().Schema {
+		if k != "comment" && k != "tags" && d.HasChange(k) {
+			return false
+		}
+	}
+	return d.HasChange("comment") || d.HasChange("tags")
+}
+
 func resourceCloudFlareRecordDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cloudflare.API)
-	domain := d.Get("domain").(string)
+	client := meta.(*CloudFlareClient)
 
-	zoneID, err := client.ZoneIDByName(domain)
+	zoneID, domain, err := client.resourceZone(d)
 	if err != nil {
-		return fmt.Errorf("Error finding zone %q: %s", domain, err)
+		return err
 	}
 
 	log.Printf("[INFO] Deleting CloudFlare Record: %s, %s", domain, d.Id())
 
-	err = client.DeleteDNSRecord(zoneID, d.Id())
-	if err == nil || strings.Contains(err.Error(), recordNotFoundMessage) {
+	err = client.deleteDNSRecord(zoneID, d.Id())
+	if err == nil || isRecordNotFound(err) {
 		return nil
 	}
 	return fmt.Errorf("Error deleting CloudFlare Record: %s", err)
 }
 
+// formatRecordTime formats the time a record was created or modified on in
+// RFC 3339, or as "" if the API didn't return it.
+func formatRecordTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// suppressProxiedTTLDiff ignores changes to ttl on proxied records, including
+// those proxied by the defaults of provider. CloudFlare forces the TTL of a
+// proxied record to 1 (automatic), so ttl is only authoritative while the
+// record is not proxied. A ttl that validateProxiedRecordTTL rejects still
+// shows, so that applying it reports the error.
+func suppressProxiedTTLDiff(provider *schema.Provider) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		ttl, _ := strconv.Atoi(new)
+		if validateProxiedRecordTTL(d.Get("proxied").(string), ttl) != nil {
+			return false
+		}
+
+		var client *CloudFlareClient
+		if provider != nil {
+			client, _ = provider.Meta().(*CloudFlareClient)
+		}
+		return client.recordProxied(d.Get("proxied").(string), d.Get("domain").(string))
+	}
+}
+
+// suppressRecordValueDiff ignores the changes to value that CloudFlare
+// doesn't keep: whitespace around it, how an IPv6 address is written, the
+// trailing dot and case of the hostname a record points to, and how the
+// value of a TXT record is split into quoted strings.
+func suppressRecordValueDiff(k, old, new string, d *schema.ResourceData) bool {
+	old, new = strings.TrimSpace(old), strings.TrimSpace(new)
+	switch d.Get("type").(string) {
+	case "TXT", "SPF":
+		return txtValue(old) == txtValue(new)
+	case "AAAA":
+		if oldIP, newIP := net.ParseIP(old), net.ParseIP(new); oldIP != nil && newIP != nil {
+			return oldIP.Equal(newIP)
+		}
+	case "CNAME", "MX", "NS", "PTR":
+		return strings.EqualFold(strings.TrimSuffix(old, "."), strings.TrimSuffix(new, "."))
+	}
+	return old == new
+}
+
+// txtValue returns the text a TXT record holds, with the strings it is
+// split into joined and their quotes and escapes removed. CloudFlare splits
+// long values into strings of its own, so "abc" "def" and "abcdef" hold the
+// same text. Values that are neither a single string nor a sequence of
+// quoted strings are returned as they are.
+func txtValue(value string) string {
+	if !strings.HasPrefix(value, `"`) {
+		quoted := `"` + value + `"`
+		if text := txtValue(quoted); text != quoted {
+			return text
+		}
+		return value
+	}
+
+	var text []byte
+	for i := 0; i < len(value); {
+		switch value[i] {
+		case ' ', '\t':
+			i++
+			continue
+		case '"':
+		default:
+			return value
+		}
+
+		end := unescapedQuote(value, i+1)
+		if end < 0 {
+			return value
+		}
+		for j := i + 1; j < end; j++ {
+			if value[j] == '\\' {
+				// \DDD is a byte given in decimal, any other escape is the
+				// character escaped.
+				if j+3 < end && isDigits(value[j+1:j+4]) {
+					n, _ := strconv.Atoi(value[j+1 : j+4])
+					text = append(text, byte(n))
+					j += 3
+					continue
+				}
+				j++
+			}
+			text = append(text, value[j])
+		}
+		i = end + 1
+	}
+	return string(text)
+}
+
+// waitForRecordWrite waits for reads of a record to return the content it
+// was written with, as they can briefly return what it was before the write.
+// Reading the record straight away would otherwise store stale content in
+// the state, showing up as a diff in the next plan. It gives up after
+// recordConsistencyTimeout, leaving the read after it to report whatever
+// the record then is.
+func (client *CloudFlareClient) waitForRecordWrite(zoneID string, written cloudflare.DNSRecord) {
+	deadline := time.Now().Add(recordConsistencyTimeout)
+	for {
+		record, err := client.DNSRecord(zoneID, written.ID)
+		if err != nil || record.Content == written.Content {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			log.Printf("[WARN] CloudFlare Record %s still has content %q rather than %q after %s",
+				written.ID, record.Content, written.Content, recordConsistencyTimeout)
+			return
+		}
+
+		log.Printf("[DEBUG] CloudFlare Record %s doesn't have its new content yet, reading it again", written.ID)
+		time.Sleep(recordConsistencyPollInterval)
+	}
+}
+
+// recordProxied is whether a record of domain is proxied, given its proxied
+// argument. Records that leave proxied unset get the provider's default for
+// the zone, or aren't proxied without a configured client.
+func (client *CloudFlareClient) recordProxied(proxied, domain string) bool {
+	if proxied != "" {
+		b, _ := strconv.ParseBool(proxied)
+		return b
+	}
+	if client == nil {
+		return false
+	}
+
+	if b, ok := client.defaultProxiedByZone[domain]; ok {
+		return b
+	}
+	return client.defaultProxied
+}
+
+// isRecordNotFound reports whether err is the API's response for a record
+// that doesn't exist. The message of an API error varies by endpoint and
+// has changed before, so it is only looked at for errors that aren't.
+func isRecordNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(*apiError); ok {
+		return isNotFound(err) || hasErrorCode(err, recordNotFoundErrorCode) || hasErrorCode(err, recordInvalidIDErrorCode)
+	}
+	for _, message := range recordNotFoundMessages {
+		if strings.Contains(strings.ToLower(err.Error()), strings.ToLower(message)) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordWriteError explains the write errors that users can't fix by
+// changing the record.
+func recordWriteError(record cloudflare.DNSRecord, err error) error {
+	switch {
+	case hasErrorCode(err, recordLockedErrorCode):
+		return fmt.Errorf("record %q is locked because another Cloudflare product, such as Email Routing, manages it, "+
+			"so it can't be edited here. Change it in that product, or remove it from the configuration: %s", record.Name, err)
+	case isRecordConflict(err):
+		return fmt.Errorf("record %q conflicts with an existing record. Import the existing record, or set the "+
+			"provider's dns_conflict_strategy to adopt or recreate it: %s", record.Name, err)
+	case hasErrorCode(err, zonePendingErrorCode):
+		return fmt.Errorf("zone %q is pending. Point the domain at the Cloudflare name servers assigned to the zone "+
+			"and apply again once the zone is active: %s", record.ZoneName, err)
+	}
+	return err
+}
+
+func normalizeBoolString(v interface{}) string {
+	b, _ := strconv.ParseBool(v.(string))
+	return strconv.FormatBool(b)
+}
+
+// subdomainName returns the part of fullName below domain. Names are
+// compared by whole labels and regardless of case, so that, in the reverse
+// zone 2.0.192.in-addr.arpa, the record 12.0.192.in-addr.arpa isn't taken
+// for the subdomain "1".
 func subdomainName(fullName, domain string) string {
-	return strings.TrimSuffix(
-		strings.TrimSuffix(fullName, domain),
-		".",
-	)
+	fullName = strings.TrimSuffix(fullName, ".")
+	if strings.EqualFold(fullName, domain) {
+		return ""
+	}
+	if suffix := "." + domain; len(fullName) > len(suffix) && strings.EqualFold(fullName[len(fullName)-len(suffix):], suffix) {
+		return fullName[:len(fullName)-len(suffix)]
+	}
+	return fullName
 }
 
 func recordName(subdomain, domain string) string {
@@ -240,26 +766,43 @@ func recordName(subdomain, domain string) string {
 }
 
 func importRecord(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*cloudflare.API)
+	client := meta.(*CloudFlareClient)
 	tokens := strings.Split(d.Id(), "|")
-	if len(tokens) != 3 {
-		return nil, fmt.Errorf("expecting subdomain|domain|type, got %q", d.Id())
+	if len(tokens) == 1 {
+		return importRecordByID(d, meta)
+	}
+	if len(tokens) != 3 && len(tokens) != 4 {
+		return nil, fmt.Errorf("expecting subdomain|domain|type, subdomain|domain|type|id or zone_id/id, got %q", d.Id())
 	}
 	subdomain, domain, recordType := tokens[0], tokens[1], tokens[2]
 	zoneID, err := client.ZoneIDByName(domain)
 	if err != nil {
 		return nil, fmt.Errorf("error finding zone %q: %s", domain, err)
 	}
-	filter := cloudflare.DNSRecord{
-		Name: fmt.Sprintf("%s.%s", subdomain, domain),
-		Type: recordType,
-	}
-	records, err := client.DNSRecords(zoneID, filter)
+	filter := url.Values{}
+	filter.Set("name", recordName(subdomain, domain))
+	filter.Set("type", recordType)
+	records, err := client.listDNSRecords(zoneID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("error filtering DNS records: %q", err)
 	}
-	if len(records) != 1 {
-		return nil, fmt.Errorf("expected 1 record, got %d", len(records))
+
+	// The record ID picks one of several records with the same name and type.
+	if len(tokens) == 4 {
+		var matching []cloudflare.DNSRecord
+		for _, record := range records {
+			if record.ID == tokens[3] {
+				matching = append(matching, record)
+			}
+		}
+		records = matching
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("expected 1 record, got 0")
+	}
+	if len(records) > 1 {
+		return nil, fmt.Errorf("expected 1 record, got %d: %s. Add the ID of the one to import, as subdomain|domain|type|id", len(records), describeRecords(records))
 	}
 	d.SetId(records[0].ID)
 	if err := d.Set("domain", domain); err != nil {
@@ -270,3 +813,37 @@ func importRecord(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceD
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// describeRecords lists records by ID and value, for errors asking to pick
+// one of them.
+func describeRecords(records []cloudflare.DNSRecord) string {
+	described := make([]string, 0, len(records))
+	for _, record := range records {
+		described = append(described, fmt.Sprintf("%s (%s)", record.ID, record.Content))
+	}
+	return strings.Join(described, ", ")
+}
+
+// importRecordByID imports the record given as zone_id/id, which names it
+// exactly even where the zone has several records of its name and type.
+func importRecordByID(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*CloudFlareClient)
+	tokens := strings.Split(d.Id(), "/")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return nil, fmt.Errorf("expecting subdomain|domain|type, subdomain|domain|type|id or zone_id/id, got %q", d.Id())
+	}
+	zoneID, recordID := tokens[0], tokens[1]
+
+	record, err := client.DNSRecord(zoneID, recordID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding record %q in zone %q: %s", recordID, zoneID, err)
+	}
+	d.SetId(record.ID)
+	if err := d.Set("zone_id", zoneID); err != nil {
+		return nil, fmt.Errorf("error setting zone_id %v", err)
+	}
+	if err := resourceCloudFlareRecordRead(d, meta); err != nil {
+		return nil, fmt.Errorf("error importing record %q: %s", record.ID, err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -27,7 +27,7 @@ func migrateCloudFlareRecordStateV0toV1(is *terraform.InstanceState, meta interf
 	}
 
 	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
-	client := meta.(*cloudflare.API)
+	client := meta.(*CloudFlareClient)
 
 	// look up new id based on attributes
 	domain := is.Attributes["domain"]
@@ -8,7 +8,6 @@ import (
 	"net/url"
 	"testing"
 
-	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -18,12 +17,7 @@ func TestCloudFlareRecordMigrateState(t *testing.T) {
 	defer ts.Close()
 
 	// Create a CloudFlare client, overriding the BaseURL
-	cfMeta, err := cloudflare.New(
-		"sometoken",
-		"someemail",
-		mockHTTPClient(ts.URL),
-	)
-
+	cfMeta, err := testClient(ts.URL)
 	if err != nil {
 		t.Fatalf("Error building CloudFlare API: %s", err)
 	}
@@ -198,14 +192,18 @@ type endpoint struct {
 	Body     string
 }
 
-// HTTPClient accepts a custom *http.Client for making API calls.
-// This function is used as a callback of sorts to override any of the client
-// options that you can't directly set on the struct
-func mockHTTPClient(testURL string) cloudflare.Option {
-	return func(api *cloudflare.API) error {
-		api.BaseURL = testURL
-		return nil
+// testClient builds a provider client that talks to the mock server at
+// testURL instead of the CloudFlare API.
+func testClient(testURL string) (*CloudFlareClient, error) {
+	config := Config{
+		Email:      "someemail",
+		Token:      "sometoken",
+		APIBaseURL: testURL,
+		// Mocked errors are returned as they are rather than retried.
+		RetryStatusCodes: []int{},
 	}
+
+	return config.Client()
 }
 
 const zoneResponse = `
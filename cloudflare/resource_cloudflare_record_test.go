@@ -2,19 +2,28 @@ package cloudflare
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccCloudFlareRecord_Basic(t *testing.T) {
 	var record cloudflare.DNSRecord
-	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
 
 	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
@@ -25,11 +34,50 @@ func TestAccCloudFlareRecord_Basic(t *testing.T) {
 					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
 					testAccCheckCloudFlareRecordAttributes(&record),
 					resource.TestCheckResourceAttr(
-						"cloudflare_record.foobar", "name", "terraform"),
+						"cloudflare_record.foobar", "subdomain", "terraform"),
 					resource.TestCheckResourceAttr(
 						"cloudflare_record.foobar", "domain", domain),
 					resource.TestCheckResourceAttr(
 						"cloudflare_record.foobar", "value", "192.168.0.10"),
+					resource.TestCheckResourceAttr(
+						"cloudflare_record.foobar", "proxiable", "true"),
+					resource.TestCheckResourceAttrSet(
+						"cloudflare_record.foobar", "created_on"),
+					resource.TestCheckResourceAttrSet(
+						"cloudflare_record.foobar", "modified_on"),
+				),
+			},
+		},
+	})
+}
+
+// Removing a comment or tags clears them, and records without them, which
+// the API returns as null, plan clean.
+func TestAccCloudFlareRecord_CommentAndTags(t *testing.T) {
+	var record cloudflare.DNSRecord
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigCommentAndTags, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "comment", "owned by platform, OPS-1234"),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "tags.#", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigBasic, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "comment", ""),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "tags.#", "0"),
 				),
 			},
 		},
@@ -64,9 +112,11 @@ func TestAccCloudFlareRecord_Apex(t *testing.T) {
 
 func TestAccCloudFlareRecord_Proxied(t *testing.T) {
 	var record cloudflare.DNSRecord
-	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
 
 	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
@@ -78,7 +128,7 @@ func TestAccCloudFlareRecord_Proxied(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"cloudflare_record.foobar", "domain", domain),
 					resource.TestCheckResourceAttr(
-						"cloudflare_record.foobar", "name", "terraform"),
+						"cloudflare_record.foobar", "subdomain", "terraform"),
 					resource.TestCheckResourceAttr(
 						"cloudflare_record.foobar", "proxied", "true"),
 					resource.TestCheckResourceAttr(
@@ -91,11 +141,308 @@ func TestAccCloudFlareRecord_Proxied(t *testing.T) {
 	})
 }
 
+// Creating a proxied record with a ttl fails before it reaches the API,
+// which only accepts 1 (automatic) for proxied records.
+func TestAccCloudFlareRecord_ProxiedTTL(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigTTL, domain, 100000),
+				ExpectError: regexp.MustCompile(`ttl.* must be 1 for automatic, or between 30 and 86400 seconds, got 100000`),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigProxiedTTL, domain, domain),
+				ExpectError: regexp.MustCompile("ttl 3600 can't be set on a record with proxied = true"),
+			},
+		},
+	})
+}
+
+func TestCloudFlareRecordTTLDiff(t *testing.T) {
+	cases := map[string]struct {
+		Proxied   bool
+		TTL       int
+		ExpectTTL bool
+	}{
+		"proxied":   {Proxied: true, TTL: 1, ExpectTTL: false},
+		"unproxied": {Proxied: false, TTL: 3600, ExpectTTL: true},
+		// Shown so that applying it reports that it can't be set.
+		"proxied with a ttl": {Proxied: true, TTL: 3600, ExpectTTL: true},
+	}
+
+	for tn, tc := range cases {
+		state := &terraform.InstanceState{
+			ID: "123456",
+			Attributes: map[string]string{
+				"domain":    "example.com",
+				"subdomain": "terraform",
+				"type":      "A",
+				"value":     "192.168.0.10",
+				"ttl":       "300",
+				"proxied":   fmt.Sprintf("%t", tc.Proxied),
+			},
+		}
+
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"domain":    "example.com",
+			"subdomain": "terraform",
+			"type":      "A",
+			"value":     "192.168.0.10",
+			"ttl":       tc.TTL,
+			"proxied":   tc.Proxied,
+		})
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		diff, err := resourceCloudFlareRecord().Diff(state, terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		hasTTL := false
+		if diff != nil {
+			_, hasTTL = diff.Attributes["ttl"]
+		}
+		if hasTTL != tc.ExpectTTL {
+			t.Fatalf("%s: expected ttl diff %t, got %#v", tn, tc.ExpectTTL, diff)
+		}
+	}
+}
+
+func TestCloudFlareRecordTTLDiff_DefaultProxied(t *testing.T) {
+	cases := map[string]struct {
+		Provider  map[string]interface{}
+		ExpectTTL bool
+	}{
+		"proxied by default": {
+			Provider:  map[string]interface{}{"default_proxied": true},
+			ExpectTTL: false,
+		},
+		"proxied by default for the zone": {
+			Provider:  map[string]interface{}{"default_proxied_by_zone": map[string]interface{}{"example.com": true}},
+			ExpectTTL: false,
+		},
+		"not proxied by default": {
+			Provider:  map[string]interface{}{"default_proxied_by_zone": map[string]interface{}{"example.org": true}},
+			ExpectTTL: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		providerConfig := map[string]interface{}{"email": "user@example.com", "token": "key"}
+		for k, v := range tc.Provider {
+			providerConfig[k] = v
+		}
+		raw, err := config.NewRawConfig(providerConfig)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		provider := Provider().(*schema.Provider)
+		if err := provider.Configure(terraform.NewResourceConfig(raw)); err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		// A new record, which leaves proxied unset.
+		raw, err = config.NewRawConfig(map[string]interface{}{
+			"domain":    "example.com",
+			"subdomain": "terraform",
+			"type":      "A",
+			"value":     "192.168.0.10",
+			"ttl":       3600,
+		})
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		diff, err := provider.ResourcesMap["cloudflare_record"].Diff(nil, terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		if _, hasTTL := diff.Attributes["ttl"]; hasTTL != tc.ExpectTTL {
+			t.Fatalf("%s: expected ttl diff %t, got %#v", tn, tc.ExpectTTL, diff)
+		}
+	}
+}
+
+func TestCloudFlareRecordValueWhitespaceDiff(t *testing.T) {
+	cases := map[string]struct {
+		Type       string
+		Old        string
+		New        string
+		ExpectDiff bool
+	}{
+		"trailing newline":    {"A", "192.168.0.10", "192.168.0.10\n", false},
+		"surrounding spaces":  {"A", "192.168.0.10", "  192.168.0.10 ", false},
+		"changed address":     {"A", "192.168.0.10", "192.168.0.11 ", true},
+		"trailing TXT space":  {"TXT", "v=spf1 -all", "v=spf1 -all ", false},
+		"internal TXT spaces": {"TXT", "v=spf1 -all", "v=spf1  -all", true},
+		"PTR trailing dot":    {"PTR", "host.example.com", "host.example.com.", false},
+		"changed PTR target":  {"PTR", "host.example.com", "mail.example.com.", true},
+		"expanded IPv6":       {"AAAA", "2001:db8::1", "2001:0db8:0000:0000:0000:0000:0000:0001", false},
+		"IPv6 case":           {"AAAA", "2001:db8::a", "2001:DB8::A", false},
+		"changed IPv6":        {"AAAA", "2001:db8::1", "2001:0db8::2", true},
+		"CNAME trailing dot":  {"CNAME", "example.com", "example.com.", false},
+		"CNAME case":          {"CNAME", "example.com", "Example.COM", false},
+		"changed CNAME":       {"CNAME", "example.com", "example.org.", true},
+		"MX trailing dot":     {"MX", "mx.example.com", "mx.example.com.", false},
+		"TXT case":            {"TXT", "v=spf1 -all", "V=SPF1 -all", true},
+		"TXT trailing dot":    {"TXT", "example.com", "example.com.", true},
+		"TXT resplit":         {"TXT", `"v=DKIM1; " "p=abc" "def"`, `"v=DKIM1; p=" "abcdef"`, false},
+		"TXT quoted":          {"TXT", `"v=spf1 -all"`, "v=spf1 -all", false},
+		"TXT escaped quote":   {"TXT", `"say \"hi\""`, `say \"hi\"`, false},
+		"TXT decimal escape":  {"TXT", `"a\059b"`, `"a;b"`, false},
+		"TXT changed string":  {"TXT", `"v=DKIM1; " "p=abc"`, `"v=DKIM1; " "p=abd"`, true},
+		"TXT split spaces":    {"TXT", `"a b"`, `"a" "b"`, true},
+	}
+
+	for tn, tc := range cases {
+		state := &terraform.InstanceState{
+			ID: "123456",
+			Attributes: map[string]string{
+				"domain":    "example.com",
+				"subdomain": "terraform",
+				"type":      tc.Type,
+				"value":     tc.Old,
+				"ttl":       "1",
+				"proxied":   "false",
+			},
+		}
+
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"domain":    "example.com",
+			"subdomain": "terraform",
+			"type":      tc.Type,
+			"value":     tc.New,
+			"proxied":   false,
+		})
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		diff, err := resourceCloudFlareRecord().Diff(state, terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		hasValue := false
+		if diff != nil {
+			_, hasValue = diff.Attributes["value"]
+		}
+		if hasValue != tc.ExpectDiff {
+			t.Fatalf("%s: expected value diff %t, got %#v", tn, tc.ExpectDiff, diff)
+		}
+	}
+}
+
+func TestAccCloudFlareRecord_ValueWhitespace(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			// The record reads back trimmed without leaving a diff.
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigValue, domain, "A", "192.168.0.10\\n  "),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", "192.168.0.10"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigValue, domain, "TXT", " v=spf1  include:_spf.example.com  -all "),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", "v=spf1  include:_spf.example.com  -all"),
+				),
+			},
+		},
+	})
+}
+
+// Values CloudFlare writes differently, such as expanded IPv6 addresses or
+// hostnames with a trailing dot, read back without leaving a diff.
+func TestAccCloudFlareRecord_CanonicalValue(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigValue, domain, "AAAA", "2001:0db8:0000:0000:0000:0000:0000:0001"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", "2001:db8::1"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigValue, domain, "CNAME", "Target."+domain+"."),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", "target."+domain),
+				),
+			},
+		},
+	})
+}
+
+// A DKIM key longer than a TXT string, given as one string or split, which
+// CloudFlare splits into strings of its own, reads back without leaving a
+// diff.
+func TestAccCloudFlareRecord_LongTXT(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	key := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA", 9)[:382]
+	value := `\"` + key[:200] + `\" \"` + key[200:] + `\"`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigValue, domain, "TXT", key),
+				Check:  testAccCheckCloudFlareRecordTXTValue("cloudflare_record.foobar", key),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigValue, domain, "TXT", value),
+				Check:  testAccCheckCloudFlareRecordTXTValue("cloudflare_record.foobar", key),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareRecordTXTValue(n, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources[n]
+		if text := txtValue(rs.Primary.Attributes["value"]); text != expected {
+			return fmt.Errorf("expected the record to hold the %d byte value, got %q", len(expected), text)
+		}
+		return nil
+	}
+}
+
 func TestAccCloudFlareRecord_Updated(t *testing.T) {
 	var record cloudflare.DNSRecord
-	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
 
 	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
@@ -106,7 +453,7 @@ func TestAccCloudFlareRecord_Updated(t *testing.T) {
 					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
 					testAccCheckCloudFlareRecordAttributes(&record),
 					resource.TestCheckResourceAttr(
-						"cloudflare_record.foobar", "name", "terraform"),
+						"cloudflare_record.foobar", "subdomain", "terraform"),
 					resource.TestCheckResourceAttr(
 						"cloudflare_record.foobar", "domain", domain),
 					resource.TestCheckResourceAttr(
@@ -119,7 +466,7 @@ func TestAccCloudFlareRecord_Updated(t *testing.T) {
 					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
 					testAccCheckCloudFlareRecordAttributesUpdated(&record),
 					resource.TestCheckResourceAttr(
-						"cloudflare_record.foobar", "name", "terraform"),
+						"cloudflare_record.foobar", "subdomain", "terraform"),
 					resource.TestCheckResourceAttr(
 						"cloudflare_record.foobar", "domain", domain),
 					resource.TestCheckResourceAttr(
@@ -130,76 +477,536 @@ func TestAccCloudFlareRecord_Updated(t *testing.T) {
 	})
 }
 
-func TestAccCloudFlareRecord_forceNewRecord(t *testing.T) {
-	var afterCreate, afterUpdate cloudflare.DNSRecord
-	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+func TestAccCloudFlareRecord_MXPriorityZero(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
 
 	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigBasic, domain),
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigMX, domain, "mx1."+domain, 0),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &afterCreate),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", "mx1."+domain),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "priority", "0"),
 				),
 			},
 			resource.TestStep{
-				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigForceNew, domain, domain),
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigMX, domain, "mx2."+domain, 0),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &afterUpdate),
-					testAccCheckCloudFlareRecordRecreated(t, &afterCreate, &afterUpdate),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", "mx2."+domain),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "priority", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigMX, domain, "mx2."+domain, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "priority", "10"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigMX, domain, "mx2."+domain, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "priority", "0"),
 				),
 			},
 		},
 	})
 }
 
-func testAccCheckCloudFlareRecordRecreated(t *testing.T,
-	before, after *cloudflare.DNSRecord) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		if before.ID == after.ID {
-			t.Fatalf("Expected change of Record Ids, but both were %v", before.ID)
-		}
-		return nil
-	}
+func TestAccCloudFlareRecord_PriorityOnA(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigPriorityOnA, domain),
+				ExpectError: regexp.MustCompile("A records don't have a priority"),
+			},
+		},
+	})
 }
 
-func testAccCheckCloudFlareRecordDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudflare.API)
+func TestAccCloudFlareRecord_Conflicts(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "cloudflare_record" {
-			continue
-		}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigApexCNAMELoop, domain, domain),
+				ExpectError: regexp.MustCompile("points at itself"),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigDeprecatedName, domain),
+				ExpectError: regexp.MustCompile(`name "www" is deprecated and ignored`),
+			},
+		},
+	})
+}
 
-		_, err := client.DNSRecord(rs.Primary.Attributes["zone_id"], rs.Primary.ID)
-		if err == nil {
-			return fmt.Errorf("Record still exists")
-		}
-	}
+func TestAccCloudFlareRecord_CNAMEFlattening(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
 
-	return nil
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			// Record-level flattening is read back.
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigFlattenCNAME, domain, "terraform", domain, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "settings.0.flatten_cname", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigFlattenCNAME, domain, "terraform", domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "settings.0.flatten_cname", "false"),
+				),
+			},
+			// The zone flattens its apex, so the apex CNAME reads back as
+			// flattened without leaving a diff.
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigFlattenCNAME, domain, "", "www."+domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "settings.0.flatten_cname", "false"),
+				),
+			},
+		},
+	})
 }
 
-func testAccCheckCloudFlareRecordAttributes(record *cloudflare.DNSRecord) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-
-		if record.Content != "192.168.0.10" {
-			return fmt.Errorf("Bad content: %s", record.Content)
-		}
+func TestAccCloudFlareRecord_ZoneID(t *testing.T) {
+	var record cloudflare.DNSRecord
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
 
-		return nil
-	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: isUnitTest,
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigZoneID, zoneID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "zone_id", zoneID),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "domain", domain),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "subdomain", "terraform"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigZoneIDAndDomain, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "zone_id", zoneID),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "domain", domain),
+				),
+			},
+		},
+	})
 }
 
-func testAccCheckCloudFlareRecordAttributesUpdated(record *cloudflare.DNSRecord) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
+func TestAccCloudFlareRecord_SRVData(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
 
-		if record.Content != "192.168.0.11" {
-			return fmt.Errorf("Bad content: %s", record.Content)
-		}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigSRVData, domain, 5060, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "subdomain", "_sip._tcp.terraform"),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", "5 5060 sip."+domain),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "data.0.priority", "10"),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "data.0.port", "5060"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigSRVData, domain, 5061, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", "5 5061 sip."+domain),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "data.0.port", "5061"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFlareRecord_SRVDataName(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: isUnitTest,
+		PreCheck:   func() { testAccPreCheck(t) },
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigSRVDataName, domain, "_tcp._sip.terraform", domain),
+				ExpectError: regexp.MustCompile("must start with the service and proto of its data"),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigSRVDataName, domain, "sip.tcp.terraform", domain),
+				ExpectError: regexp.MustCompile("must start with _service._proto"),
+			},
+		},
+	})
+}
+
+func TestAccCloudFlareRecord_CAAData(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigCAAData, domain, "issue"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", `0 issue "letsencrypt.org"`),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "data.0.flags", "0"),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "data.0.tag", "issue"),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "data.0.value", "letsencrypt.org"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigCAAData, domain, "issuewild"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "value", `0 issuewild "letsencrypt.org"`),
+					resource.TestCheckResourceAttr("cloudflare_record.foobar", "data.0.tag", "issuewild"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFlareRecord_DataConflicts(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: isUnitTest,
+		PreCheck:   func() { testAccPreCheck(t) },
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigDataForType, domain, "TXT"),
+				ExpectError: regexp.MustCompile("data is only supported for SRV and CAA records, not TXT records"),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigNoValue, domain),
+				ExpectError: regexp.MustCompile("one of value or data must be set"),
+			},
+		},
+	})
+}
+
+func TestAccCloudFlareRecord_TTLBelowPlanMinimum(t *testing.T) {
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigTTL, domain, 60),
+				ExpectError: regexp.MustCompile("ttl 60 of record .* is below the free plan minimum of 120"),
+			},
+		},
+	})
+}
+
+func TestAccCloudFlareRecord_Import(t *testing.T) {
+	var record cloudflare.DNSRecord
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigBasic, domain),
+				Check:  testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+			},
+			resource.TestStep{
+				ResourceName:      "cloudflare_record.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("terraform|%s|A", domain),
+			},
+			resource.TestStep{
+				ResourceName:        "cloudflare_record.foobar",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: fmt.Sprintf("terraform|%s|A|", domain),
+			},
+		},
+	})
+}
+
+// TestAccCloudFlareRecord_ImportByID imports a record by its zone and record
+// IDs, which names it exactly where its name and type don't.
+func TestAccCloudFlareRecord_ImportByID(t *testing.T) {
+	var record cloudflare.DNSRecord
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: isUnitTest,
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigBasic, domain),
+				Check:  testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+			},
+			resource.TestStep{
+				ResourceName:        "cloudflare_record.foobar",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: os.Getenv("CLOUDFLARE_ZONE_ID") + "/",
+			},
+		},
+	})
+}
+
+func TestCloudFlareRecord_ImportByIDErrors(t *testing.T) {
+	api := newMockDNSAPI(t, "example.com")
+	ts := httptest.NewServer(api)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	for _, id := range []string{mockZoneID, mockZoneID + "/", "/" + fmt.Sprintf("%032x", 1), mockZoneID + "/a/b", mockZoneID + "/" + fmt.Sprintf("%032x", 1)} {
+		d := resourceCloudFlareRecord().Data(nil)
+		d.SetId(id)
+		if _, err := importRecord(d, client); err == nil {
+			t.Fatalf("expected importing %q to fail", id)
+		}
+	}
+}
+
+// Turning off proxied must apply the configured ttl, which is ignored while
+// the record is proxied.
+func TestAccCloudFlareRecord_ProxiedUpdate(t *testing.T) {
+	var record cloudflare.DNSRecord
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigProxied, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+					resource.TestCheckResourceAttr(
+						"cloudflare_record.foobar", "proxied", "true"),
+					resource.TestCheckResourceAttr(
+						"cloudflare_record.foobar", "ttl", "1"),
+				),
+			},
+			// Existing proxied records can't set a ttl either.
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckCloudFlareRecordConfigProxiedTTL, domain, domain),
+				ExpectError: regexp.MustCompile("ttl 3600 can't be set on a record with proxied = true"),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigUnproxiedTTL, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+					resource.TestCheckResourceAttr(
+						"cloudflare_record.foobar", "proxied", "false"),
+					resource.TestCheckResourceAttr(
+						"cloudflare_record.foobar", "ttl", "3600"),
+				),
+			},
+			// The ttl is removed as the record is proxied again, which
+			// leaves it in the state until the record is read.
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigProxied, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+					resource.TestCheckResourceAttr(
+						"cloudflare_record.foobar", "proxied", "true"),
+					resource.TestCheckResourceAttr(
+						"cloudflare_record.foobar", "ttl", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFlareRecord_DefaultProxiedByZone(t *testing.T) {
+	var record cloudflare.DNSRecord
+	domain, isUnitTest, closeAPI := testAccRecordAPI(t)
+	defer closeAPI()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:   isUnitTest,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigDefaultProxiedByZone, domain, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &record),
+					resource.TestCheckResourceAttr(
+						"cloudflare_record.foobar", "proxied", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlareRecordProxied(t *testing.T) {
+	client := &CloudFlareClient{
+		defaultProxied:       false,
+		defaultProxiedByZone: map[string]bool{"example.com": true, "example.net": false},
+	}
+
+	cases := []struct {
+		proxied, domain string
+		expected        bool
+	}{
+		{"", "example.com", true},
+		{"", "example.net", false},
+		{"", "example.org", false},
+		{"false", "example.com", false},
+		{"true", "example.net", true},
+	}
+
+	for _, c := range cases {
+		if got := client.recordProxied(c.proxied, c.domain); got != c.expected {
+			t.Fatalf("proxied %q in %s: expected %t, got %t", c.proxied, c.domain, c.expected, got)
+		}
+	}
+
+	client.defaultProxied = true
+	if !client.recordProxied("", "example.org") {
+		t.Fatal("records of zones without an override should get default_proxied")
+	}
+	if client.recordProxied("", "example.net") {
+		t.Fatal("the zone's default should win over default_proxied")
+	}
+}
+
+func TestAccCloudFlareRecord_forceNewRecord(t *testing.T) {
+	var afterCreate, afterUpdate cloudflare.DNSRecord
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigBasic, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &afterCreate),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRecordConfigForceNew, domain, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareRecordExists("cloudflare_record.foobar", &afterUpdate),
+					testAccCheckCloudFlareRecordRecreated(t, &afterCreate, &afterUpdate),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareRecordRecreated(t *testing.T,
+	before, after *cloudflare.DNSRecord) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID == after.ID {
+			t.Fatalf("Expected change of Record Ids, but both were %v", before.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCloudFlareRecordDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_record" {
+			continue
+		}
+
+		_, err := client.DNSRecord(rs.Primary.Attributes["zone_id"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Record still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCloudFlareRecordAttributes(record *cloudflare.DNSRecord) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		if record.Content != "192.168.0.10" {
+			return fmt.Errorf("Bad content: %s", record.Content)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudFlareRecordAttributesUpdated(record *cloudflare.DNSRecord) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		if record.Content != "192.168.0.11" {
+			return fmt.Errorf("Bad content: %s", record.Content)
+		}
 
 		return nil
 	}
@@ -216,7 +1023,7 @@ func testAccCheckCloudFlareRecordExists(n string, record *cloudflare.DNSRecord)
 			return fmt.Errorf("No Record ID is set")
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*CloudFlareClient)
 		foundRecord, err := client.DNSRecord(rs.Primary.Attributes["zone_id"], rs.Primary.ID)
 		if err != nil {
 			return err
@@ -236,36 +1043,136 @@ const testAccCheckCloudFlareRecordConfigBasic = `
 resource "cloudflare_record" "foobar" {
 	domain = "%s"
 
-	name = "terraform"
+	subdomain = "terraform"
 	value = "192.168.0.10"
 	type = "A"
 	ttl = 3600
 }`
 
-const testAccCheckCloudFlareRecordConfigApex = `
+const testAccCheckCloudFlareRecordConfigCommentAndTags = `
 resource "cloudflare_record" "foobar" {
 	domain = "%s"
-	name = "@"
+
+	subdomain = "terraform"
 	value = "192.168.0.10"
 	type = "A"
 	ttl = 3600
+	comment = "owned by platform, OPS-1234"
+	tags = ["team:platform", "production"]
 }`
 
-const testAccCheckCloudFlareRecordConfigProxied = `
+const testAccCheckCloudFlareRecordConfigPriorityOnA = `
 resource "cloudflare_record" "foobar" {
 	domain = "%s"
 
-	name = "terraform"
-	value = "%s"
-	type = "CNAME"
-	proxied = true
+	subdomain = "terraform"
+	value = "192.168.0.10"
+	type = "A"
+	priority = 10
 }`
 
-const testAccCheckCloudFlareRecordConfigNewValue = `
+const testAccCheckCloudFlareRecordConfigApexCNAMELoop = `
 resource "cloudflare_record" "foobar" {
 	domain = "%s"
 
-	name = "terraform"
+	value = "%s"
+	type = "CNAME"
+}`
+
+const testAccCheckCloudFlareRecordConfigDeprecatedName = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+
+	name = "www"
+	value = "192.168.0.10"
+	type = "A"
+}`
+
+const testAccCheckCloudFlareRecordConfigFlattenCNAME = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+
+	subdomain = "%s"
+	value = "%s"
+	type = "CNAME"
+
+	settings {
+		flatten_cname = %t
+	}
+}`
+
+const testAccCheckCloudFlareRecordConfigTTL = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+
+	subdomain = "terraform"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = %d
+}`
+
+const testAccCheckCloudFlareRecordConfigApex = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	name = "@"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+}`
+
+const testAccCheckCloudFlareRecordConfigProxied = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+
+	subdomain = "terraform"
+	value = "%s"
+	type = "CNAME"
+	proxied = true
+}`
+
+const testAccCheckCloudFlareRecordConfigProxiedTTL = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+
+	subdomain = "terraform"
+	value = "%s"
+	type = "CNAME"
+	proxied = true
+	ttl = 3600
+}`
+
+const testAccCheckCloudFlareRecordConfigUnproxiedTTL = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+
+	subdomain = "terraform"
+	value = "%s"
+	type = "CNAME"
+	proxied = false
+	ttl = 3600
+}`
+
+const testAccCheckCloudFlareRecordConfigDefaultProxiedByZone = `
+provider "cloudflare" {
+	default_proxied = false
+	default_proxied_by_zone = {
+		"%s" = true
+	}
+}
+
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+
+	subdomain = "terraform"
+	value = "%s"
+	type = "CNAME"
+}`
+
+const testAccCheckCloudFlareRecordConfigNewValue = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+
+	subdomain = "terraform"
 	value = "192.168.0.11"
 	type = "A"
 	ttl = 3600
@@ -275,8 +1182,772 @@ const testAccCheckCloudFlareRecordConfigForceNew = `
 resource "cloudflare_record" "foobar" {
 	domain = "%s"
 
-	name = "terraform"
+	subdomain = "terraform"
 	value = "%s"
 	type = "CNAME"
 	ttl = 3600
 }`
+
+func TestCloudFlareRecordCreate_Domain(t *testing.T) {
+	var lookups int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/zones" {
+			lookups++
+		}
+		switch {
+		case r.URL.Path == "/zones/1234567890" && r.Method == "GET":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "1234567890", "name": "example.com"}}`)
+		case r.URL.Path == "/zones" && r.URL.Query().Get("name") == "example.com":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+		case r.URL.Path == "/zones":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": []}`)
+		case r.URL.Path == "/zones/1234567890/dns_records" && r.Method == "POST",
+			r.URL.Path == "/zones/1234567890/dns_records/372e67954025e0ba6aaa6d586b9e0b59":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "A", "name": "terraform.example.com", "content": "192.168.0.10", "ttl": 3600, "zone_id": "1234567890"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]struct {
+		Domain      string
+		ZoneID      string
+		ExpectError string
+	}{
+		"known":           {Domain: "example.com"},
+		"unknown":         {Domain: "exmaple.com", ExpectError: `Error finding zone "exmaple.com"`},
+		"zone_id":         {ZoneID: "1234567890"},
+		"zone_id, domain": {Domain: "example.com", ZoneID: "1234567890"},
+		"unknown zone_id": {ZoneID: "0987654321", ExpectError: `Error finding zone "0987654321"`},
+		"neither":         {ExpectError: "one of domain or zone_id must be set"},
+	}
+
+	for tn, tc := range cases {
+		lookups = 0
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, map[string]interface{}{
+			"domain":    tc.Domain,
+			"zone_id":   tc.ZoneID,
+			"subdomain": "terraform",
+			"type":      "A",
+			"value":     "192.168.0.10",
+			"ttl":       3600,
+		})
+
+		err := resourceCloudFlareRecordCreate(d, client)
+		if tc.ZoneID != "" && lookups != 0 {
+			t.Fatalf("%s: expected the zone not to be looked up by name, got %d lookups", tn, lookups)
+		}
+		if tc.ExpectError == "" {
+			if err != nil {
+				t.Fatalf("%s: err: %s", tn, err)
+			}
+			if d.Id() != "372e67954025e0ba6aaa6d586b9e0b59" || d.Get("zone_id") != "1234567890" {
+				t.Fatalf("%s: bad state: %s, %s", tn, d.Id(), d.Get("zone_id"))
+			}
+			if d.Get("domain") != "example.com" || d.Get("subdomain") != "terraform" {
+				t.Fatalf("%s: bad state: %s, %s", tn, d.Get("domain"), d.Get("subdomain"))
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.ExpectError) {
+			t.Fatalf("%s: expected error containing %q, got: %v", tn, tc.ExpectError, err)
+		}
+		if d.Id() != "" {
+			t.Fatalf("%s: expected no record to be created, got %s", tn, d.Id())
+		}
+	}
+}
+
+func TestCloudFlareRecordWrite_Rejected(t *testing.T) {
+	var code int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/zones":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+		case r.Method == "POST" || r.Method == "PUT":
+			writeMockError(w, http.StatusBadRequest, code, "DNS record is read-only")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]struct {
+		Code        int
+		ExpectError string
+	}{
+		"locked":  {Code: recordLockedErrorCode, ExpectError: `record "terraform.example.com" is locked because another Cloudflare product`},
+		"pending": {Code: zonePendingErrorCode, ExpectError: `zone "example.com" is pending`},
+		"other":   {Code: 1004, ExpectError: "HTTP status 400: DNS record is read-only (1004)"},
+	}
+
+	for tn, tc := range cases {
+		code = tc.Code
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, map[string]interface{}{
+			"domain":    "example.com",
+			"subdomain": "terraform",
+			"type":      "MX",
+			"value":     "route1.mx.cloudflare.net",
+			"priority":  10,
+		})
+
+		err := resourceCloudFlareRecordCreate(d, client)
+		if err == nil || !strings.Contains(err.Error(), "Failed to create record: "+tc.ExpectError) {
+			t.Fatalf("%s: expected create error containing %q, got: %v", tn, tc.ExpectError, err)
+		}
+
+		d.SetId("372e67954025e0ba6aaa6d586b9e0b59")
+		err = resourceCloudFlareRecordUpdate(d, client)
+		if err == nil || !strings.Contains(err.Error(), "Failed to update CloudFlare Record: "+tc.ExpectError) {
+			t.Fatalf("%s: expected update error containing %q, got: %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestCloudFlareRecordUpdate_EventualConsistency(t *testing.T) {
+	defer func(interval time.Duration) { recordConsistencyPollInterval = interval }(recordConsistencyPollInterval)
+	recordConsistencyPollInterval = time.Millisecond
+
+	var reads int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const record = `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "A", "name": "terraform.example.com", "content": %q, "ttl": 3600, "zone_id": "1234567890"}}`
+		switch {
+		case r.URL.Path == "/zones":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+		case r.URL.Path == "/zones/1234567890/dns_records/372e67954025e0ba6aaa6d586b9e0b59" && r.Method == "PUT":
+			fmt.Fprintf(w, record, "192.168.0.11")
+		case r.URL.Path == "/zones/1234567890/dns_records/372e67954025e0ba6aaa6d586b9e0b59":
+			// The first reads after the update still return the old content.
+			reads++
+			content := "192.168.0.10"
+			if reads > 2 {
+				content = "192.168.0.11"
+			}
+			fmt.Fprintf(w, record, content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, map[string]interface{}{
+		"domain":    "example.com",
+		"subdomain": "terraform",
+		"type":      "A",
+		"value":     "192.168.0.11",
+		"ttl":       3600,
+	})
+	d.SetId("372e67954025e0ba6aaa6d586b9e0b59")
+
+	if err := resourceCloudFlareRecordUpdate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v := d.Get("value"); v != "192.168.0.11" {
+		t.Fatalf("expected the updated value in state, got %q", v)
+	}
+	if reads != 4 {
+		t.Fatalf("expected the record to be read until it was updated and then once more, got %d reads", reads)
+	}
+}
+
+func TestCloudFlareRecordRead_ZoneIDInState(t *testing.T) {
+	var zoneLookups int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/zones":
+			zoneLookups++
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+		case "/zones/1234567890/dns_records/372e67954025e0ba6aaa6d586b9e0b59":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "A", "name": "terraform.example.com", "content": "192.168.0.10", "ttl": 3600, "zone_id": "1234567890"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]struct {
+		ZoneID      string
+		ZoneLookups int
+	}{
+		"in state":     {ZoneID: "1234567890", ZoneLookups: 0},
+		"not in state": {ZoneID: "", ZoneLookups: 1},
+	}
+
+	for tn, tc := range cases {
+		zoneLookups = 0
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, map[string]interface{}{
+			"domain":  "example.com",
+			"zone_id": tc.ZoneID,
+		})
+		d.SetId("372e67954025e0ba6aaa6d586b9e0b59")
+
+		if err := resourceCloudFlareRecordRead(d, client); err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if zoneLookups != tc.ZoneLookups {
+			t.Fatalf("%s: expected %d zone lookups, got %d", tn, tc.ZoneLookups, zoneLookups)
+		}
+		if d.Get("zone_id") != "1234567890" || d.Get("value") != "192.168.0.10" {
+			t.Fatalf("%s: bad state: %s, %s", tn, d.Get("zone_id"), d.Get("value"))
+		}
+	}
+}
+
+func TestCloudFlareRecordRead_Errors(t *testing.T) {
+	var status, code int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMockError(w, status, code, "mocked error")
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]struct {
+		Status int
+		Code   int
+		Gone   bool
+	}{
+		"not found":       {Status: http.StatusNotFound, Code: recordNotFoundErrorCode, Gone: true},
+		"invalid record":  {Status: http.StatusBadRequest, Code: recordNotFoundErrorCode, Gone: true},
+		"invalid id":      {Status: http.StatusBadRequest, Code: recordInvalidIDErrorCode, Gone: true},
+		"server error":    {Status: http.StatusInternalServerError, Code: 10000, Gone: false},
+		"rate limited":    {Status: http.StatusTooManyRequests, Code: 10000, Gone: false},
+		"service failure": {Status: http.StatusServiceUnavailable, Code: 10000, Gone: false},
+		"bad request":     {Status: http.StatusBadRequest, Code: 10000, Gone: false},
+	}
+
+	for tn, tc := range cases {
+		status, code = tc.Status, tc.Code
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, map[string]interface{}{
+			"domain":  "example.com",
+			"zone_id": "1234567890",
+		})
+		d.SetId("372e67954025e0ba6aaa6d586b9e0b59")
+
+		err := resourceCloudFlareRecordRead(d, client)
+		if tc.Gone {
+			if err != nil {
+				t.Fatalf("%s: err: %s", tn, err)
+			}
+			if d.Id() != "" {
+				t.Fatalf("%s: expected the record to be removed from the state", tn)
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Fatalf("%s: expected an error", tn)
+		}
+		if d.Id() != "372e67954025e0ba6aaa6d586b9e0b59" {
+			t.Fatalf("%s: expected the record to stay in the state, got ID %q", tn, d.Id())
+		}
+	}
+}
+
+func TestIsRecordNotFound(t *testing.T) {
+	cases := map[string]struct {
+		Err      error
+		NotFound bool
+	}{
+		"nil":                {Err: nil},
+		"404":                {Err: &apiError{StatusCode: http.StatusNotFound}, NotFound: true},
+		"81044":              {Err: &apiError{StatusCode: http.StatusBadRequest, Errors: []cloudflare.ResponseInfo{{Code: 81044}}}, NotFound: true},
+		"1032":               {Err: &apiError{StatusCode: http.StatusBadRequest, Errors: []cloudflare.ResponseInfo{{Code: 1032}}}, NotFound: true},
+		"other code":         {Err: &apiError{StatusCode: http.StatusBadRequest, Errors: []cloudflare.ResponseInfo{{Code: 9000, Message: "Record does not exist"}}}},
+		"unstructured":       {Err: fmt.Errorf("error from makeRequest: HTTP status 400: Invalid dns record identifier"), NotFound: true},
+		"unstructured other": {Err: fmt.Errorf("error from makeRequest: HTTP status 500: internal error")},
+	}
+
+	for tn, tc := range cases {
+		if got := isRecordNotFound(tc.Err); got != tc.NotFound {
+			t.Fatalf("%s: expected isRecordNotFound to be %t, got %t", tn, tc.NotFound, got)
+		}
+	}
+}
+
+func TestCloudFlareRecordRead_CNAMEFlattening(t *testing.T) {
+	cases := map[string]struct {
+		Flattening string
+		Name       string
+		Flattened  bool
+	}{
+		"zone flattens all":           {Flattening: "flatten_all", Name: "terraform.example.com", Flattened: false},
+		"zone flattens apex":          {Flattening: "flatten_at_root", Name: "example.com", Flattened: false},
+		"zone flattens other records": {Flattening: "flatten_at_root", Name: "terraform.example.com", Flattened: true},
+		"zone flattens none":          {Flattening: "flatten_none", Name: "terraform.example.com", Flattened: true},
+	}
+
+	for tn, tc := range cases {
+		var settingRequests int
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/zones/1234567890/settings/cname_flattening":
+				settingRequests++
+				fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "cname_flattening", "value": %q}}`, tc.Flattening)
+			case "/zones/1234567890/dns_records/372e67954025e0ba6aaa6d586b9e0b59":
+				fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "CNAME", "name": %q, "content": "target.example.net", "ttl": 1, "zone_id": "1234567890", "settings": {"flatten_cname": true}}}`, tc.Name)
+			default:
+				writeMockError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+			}
+		}))
+
+		client, err := testClient(ts.URL)
+		if err != nil {
+			t.Fatalf("Error building CloudFlare API: %s", err)
+		}
+
+		// The record was configured without flattening.
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, map[string]interface{}{
+			"domain":   "example.com",
+			"zone_id":  "1234567890",
+			"type":     "CNAME",
+			"settings": []interface{}{map[string]interface{}{"flatten_cname": false}},
+		})
+		d.SetId("372e67954025e0ba6aaa6d586b9e0b59")
+
+		for i := 0; i < 2; i++ {
+			if err := resourceCloudFlareRecordRead(d, client); err != nil {
+				t.Fatalf("%s: err: %s", tn, err)
+			}
+		}
+		ts.Close()
+
+		if got := d.Get("settings.0.flatten_cname").(bool); got != tc.Flattened {
+			t.Fatalf("%s: expected flatten_cname %t, got %t", tn, tc.Flattened, got)
+		}
+		if settingRequests != 1 {
+			t.Fatalf("%s: expected the zone setting to be fetched once, got %d requests", tn, settingRequests)
+		}
+	}
+}
+
+// TestCloudFlareRecord_ImportUnproxiable checks that records of types that
+// can't be proxied import as not proxied, even if the API says otherwise,
+// and plan clean afterwards.
+func TestCloudFlareRecord_ImportUnproxiable(t *testing.T) {
+	api := newMockDNSAPI(t, "example.com")
+	api.save(dnsRecord{DNSRecord: cloudflare.DNSRecord{
+		ID:      fmt.Sprintf("%032x", 100),
+		Type:    "TXT",
+		Name:    "terraform.example.com",
+		Content: "v=spf1 -all",
+		Proxied: true,
+	}})
+	ts := httptest.NewServer(api)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := resourceCloudFlareRecord().Data(nil)
+	d.SetId("terraform|example.com|TXT")
+	imported, err := importRecord(d, client)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	d = imported[0]
+
+	if got := d.Get("proxied"); got != "false" {
+		t.Fatalf("expected proxied %q, got %q", "false", got)
+	}
+	if d.Get("proxiable").(bool) {
+		t.Fatalf("expected proxiable to be false")
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"domain":    "example.com",
+		"subdomain": "terraform",
+		"type":      "TXT",
+		"value":     "v=spf1 -all",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := resourceCloudFlareRecord().Diff(d.State(), terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff after import, got %#v", diff)
+	}
+}
+
+// TestCloudFlareRecord_ImportPaginated checks that importing finds records
+// past the first page of those with the same name and type, and lists the
+// records to choose from when the import ID matches several.
+func TestCloudFlareRecord_ImportPaginated(t *testing.T) {
+	api := newMockDNSAPI(t, "example.com")
+	for i := 1; i <= 25; i++ {
+		api.save(dnsRecord{DNSRecord: cloudflare.DNSRecord{
+			ID:      fmt.Sprintf("%032x", i),
+			Type:    "A",
+			Name:    "terraform.example.com",
+			Content: fmt.Sprintf("192.0.2.%d", i),
+		}})
+	}
+	ts := httptest.NewServer(api)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	last := fmt.Sprintf("%032x", 25)
+	d := resourceCloudFlareRecord().Data(nil)
+	d.SetId("terraform|example.com|A|" + last)
+	imported, err := importRecord(d, client)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got := imported[0].Get("value"); imported[0].Id() != last || got != "192.0.2.25" {
+		t.Fatalf("expected record %s with value 192.0.2.25, got %s with %q", last, imported[0].Id(), got)
+	}
+
+	d = resourceCloudFlareRecord().Data(nil)
+	d.SetId("terraform|example.com|A")
+	_, err = importRecord(d, client)
+	if err == nil {
+		t.Fatalf("expected importing one of several records to fail")
+	}
+	for _, expected := range []string{"got 25", last + " (192.0.2.25)", fmt.Sprintf("%032x", 1) + " (192.0.2.1)"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected the error to contain %q, got %q", expected, err)
+		}
+	}
+}
+
+func TestSubdomainName(t *testing.T) {
+	cases := map[string]struct {
+		Name      string
+		Domain    string
+		Subdomain string
+	}{
+		"subdomain":           {"terraform.example.com", "example.com", "terraform"},
+		"apex":                {"example.com", "example.com", ""},
+		"trailing dot":        {"terraform.example.com.", "example.com", "terraform"},
+		"case":                {"Terraform.EXAMPLE.com", "example.com", "Terraform"},
+		"reverse":             {"1.2.0.192.in-addr.arpa", "2.0.192.in-addr.arpa", "1"},
+		"reverse apex":        {"2.0.192.in-addr.arpa", "2.0.192.in-addr.arpa", ""},
+		"reverse label":       {"12.0.192.in-addr.arpa", "2.0.192.in-addr.arpa", "12.0.192.in-addr.arpa"},
+		"reverse ipv6":        {"1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", "8.b.d.0.1.0.0.2.ip6.arpa", "1.0.0.0"},
+		"reverse two labels":  {"10.1.2.0.192.in-addr.arpa", "2.0.192.in-addr.arpa", "10.1"},
+		"outside of the zone": {"terraform.example.org", "example.com", "terraform.example.org"},
+	}
+
+	for tn, tc := range cases {
+		if got := subdomainName(tc.Name, tc.Domain); got != tc.Subdomain {
+			t.Fatalf("%s: expected subdomain %q, got %q", tn, tc.Subdomain, got)
+		}
+		if tc.Subdomain != tc.Name && !strings.EqualFold(recordName(tc.Subdomain, tc.Domain), strings.TrimSuffix(tc.Name, ".")) {
+			t.Fatalf("%s: expected %q to round-trip, got %q", tn, tc.Name, recordName(tc.Subdomain, tc.Domain))
+		}
+	}
+}
+
+// PTR records in reverse zones are created, read and updated in place,
+// their target's trailing dot, which CloudFlare drops, causing no diff.
+func TestCloudFlareRecord_PTR(t *testing.T) {
+	api := newMockDNSAPI(t, "2.0.192.in-addr.arpa")
+	ts := httptest.NewServer(api)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	c := map[string]interface{}{
+		"domain":    "2.0.192.in-addr.arpa",
+		"subdomain": "10",
+		"type":      "PTR",
+		"value":     "host.example.com.",
+		"ttl":       3600,
+	}
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, c)
+	if err := resourceCloudFlareRecordCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	id := d.Id()
+	if got := api.records[id].Name; got != "10.2.0.192.in-addr.arpa" {
+		t.Fatalf("expected record 10.2.0.192.in-addr.arpa, got %q", got)
+	}
+
+	expectNoDiff := func(step string) {
+		d = resourceCloudFlareRecord().Data(d.State())
+		if err := resourceCloudFlareRecordRead(d, client); err != nil {
+			t.Fatalf("%s: err: %s", step, err)
+		}
+		if got := d.Get("subdomain"); got != "10" {
+			t.Fatalf("%s: expected subdomain 10, got %q", step, got)
+		}
+
+		raw, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatalf("%s: err: %s", step, err)
+		}
+		diff, err := resourceCloudFlareRecord().Diff(d.State(), terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("%s: err: %s", step, err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Fatalf("%s: expected no diff, got %#v", step, diff)
+		}
+	}
+	expectNoDiff("create")
+
+	c["value"] = "mail.example.com."
+	d = schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, c)
+	d.SetId(id)
+	if err := resourceCloudFlareRecordUpdate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != id || d.Get("value") != "mail.example.com" {
+		t.Fatalf("expected record %s to be updated in place, got %s with value %q", id, d.Id(), d.Get("value"))
+	}
+	expectNoDiff("update")
+}
+
+func TestCloudFlareRecordCreate_ConflictStrategy(t *testing.T) {
+	existingA := func(id, content string) dnsRecord {
+		return dnsRecord{DNSRecord: cloudflare.DNSRecord{ID: id, Type: "A", Name: "terraform.example.com", Content: content, TTL: 120}}
+	}
+
+	cases := map[string]struct {
+		Strategy    string
+		Existing    []dnsRecord
+		Type        string
+		Value       string
+		ExpectError string
+		// Adopted is the ID of the existing record the resource should
+		// manage, if any.
+		Adopted string
+		// Remaining are the records of the zone after the create.
+		Remaining int
+	}{
+		"default": {
+			Existing:    []dnsRecord{existingA("a1", "192.168.0.10")},
+			Type:        "A",
+			Value:       "192.168.0.10",
+			ExpectError: "dns_conflict_strategy",
+			Remaining:   1,
+		},
+		"error": {
+			Strategy:    "error",
+			Existing:    []dnsRecord{existingA("a1", "192.168.0.10")},
+			Type:        "A",
+			Value:       "192.168.0.10",
+			ExpectError: "conflicts with an existing record",
+			Remaining:   1,
+		},
+		"adopt": {
+			Strategy:  "adopt",
+			Existing:  []dnsRecord{existingA("a1", "192.168.0.10"), existingA("a2", "192.168.0.11")},
+			Type:      "A",
+			Value:     "192.168.0.10",
+			Adopted:   "a1",
+			Remaining: 2,
+		},
+		"adopt ambiguous": {
+			Strategy:    "adopt",
+			Existing:    []dnsRecord{existingA("a1", "192.168.0.10"), existingA("a2", "192.168.0.11")},
+			Type:        "CNAME",
+			Value:       "target.example.net",
+			ExpectError: "can't tell which to adopt",
+			Remaining:   2,
+		},
+		"recreate": {
+			Strategy:  "recreate",
+			Existing:  []dnsRecord{existingA("a1", "192.168.0.10"), existingA("a2", "192.168.0.11")},
+			Type:      "A",
+			Value:     "192.168.0.10",
+			Remaining: 2,
+		},
+		"recreate over other types": {
+			Strategy:  "recreate",
+			Existing:  []dnsRecord{existingA("a1", "192.168.0.10"), existingA("a2", "192.168.0.11")},
+			Type:      "CNAME",
+			Value:     "target.example.net",
+			Remaining: 1,
+		},
+	}
+
+	for tn, tc := range cases {
+		api := newMockDNSAPI(t, "example.com")
+		for _, record := range tc.Existing {
+			api.save(record)
+		}
+		ts := httptest.NewServer(api)
+
+		client, err := testClient(ts.URL)
+		if err != nil {
+			t.Fatalf("Error building CloudFlare API: %s", err)
+		}
+		client.dnsConflictStrategy = tc.Strategy
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareRecord().Schema, map[string]interface{}{
+			"zone_id":   mockZoneID,
+			"domain":    "example.com",
+			"subdomain": "terraform",
+			"type":      tc.Type,
+			"value":     tc.Value,
+			"ttl":       3600,
+		})
+		err = resourceCloudFlareRecordCreate(d, client)
+		ts.Close()
+
+		if tc.ExpectError != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectError) {
+				t.Fatalf("%s: expected error containing %q, got: %v", tn, tc.ExpectError, err)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		if len(api.records) != tc.Remaining {
+			t.Fatalf("%s: expected %d records in the zone, got %d", tn, tc.Remaining, len(api.records))
+		}
+		if tc.ExpectError != "" {
+			continue
+		}
+
+		if tc.Adopted != "" && d.Id() != tc.Adopted {
+			t.Fatalf("%s: expected record %q to be adopted, got %q", tn, tc.Adopted, d.Id())
+		}
+		if tc.Adopted == "" && (d.Id() == "a1" || d.Id() == "a2") {
+			t.Fatalf("%s: expected a new record, got existing record %q", tn, d.Id())
+		}
+		if record := api.records[d.Id()]; record.Type != tc.Type || record.Content != tc.Value || record.TTL != 3600 {
+			t.Fatalf("%s: expected the record to match the configuration, got %#v", tn, record.DNSRecord)
+		}
+	}
+}
+
+const testAccCheckCloudFlareRecordConfigZoneID = `
+resource "cloudflare_record" "foobar" {
+	zone_id = "%s"
+	subdomain = "terraform"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+}`
+
+const testAccCheckCloudFlareRecordConfigZoneIDAndDomain = `
+resource "cloudflare_record" "foobar" {
+	zone_id = "%s"
+	domain = "%s"
+	subdomain = "terraform"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+}`
+
+const testAccCheckCloudFlareRecordConfigSRVData = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "_sip._tcp.terraform"
+	type = "SRV"
+
+	data {
+		service = "_sip"
+		proto = "_tcp"
+		priority = 10
+		weight = 5
+		port = %d
+		target = "sip.%s"
+	}
+}`
+
+const testAccCheckCloudFlareRecordConfigSRVDataName = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "%s"
+	type = "SRV"
+
+	data {
+		service = "_sip"
+		proto = "_tcp"
+		priority = 10
+		weight = 5
+		port = 5060
+		target = "sip.%s"
+	}
+}`
+
+const testAccCheckCloudFlareRecordConfigDataForType = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "terraform"
+	type = "%s"
+
+	data {
+		target = "example.com"
+	}
+}`
+
+const testAccCheckCloudFlareRecordConfigNoValue = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "terraform"
+	type = "A"
+}`
+
+const testAccCheckCloudFlareRecordConfigCAAData = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "terraform"
+	type = "CAA"
+
+	data {
+		flags = 0
+		tag = "%s"
+		value = "letsencrypt.org"
+	}
+}`
+
+const testAccCheckCloudFlareRecordConfigMX = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "terraform"
+	type = "MX"
+	value = "%s"
+	priority = %d
+}`
+
+const testAccCheckCloudFlareRecordConfigValue = `
+resource "cloudflare_record" "foobar" {
+	domain = "%s"
+	subdomain = "terraform"
+	type = "%s"
+	value = "%s"
+}`
@@ -0,0 +1,285 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// registrarDomain is a domain registered with Cloudflare Registrar. Domains
+// are registered and transferred outside Terraform, so only their settings
+// are managed here.
+type registrarDomain struct {
+	Name        string                     `json:"name,omitempty"`
+	AutoRenew   bool                       `json:"auto_renew"`
+	Locked      bool                       `json:"locked"`
+	Privacy     bool                       `json:"privacy"`
+	ExpiresAt   string                     `json:"expires_at,omitempty"`
+	NameServers []string                   `json:"name_servers,omitempty"`
+	Registrant  *registrarDomainContact    `json:"registrant_contact,omitempty"`
+	TransferIn  *registrarDomainTransferIn `json:"transfer_in,omitempty"`
+}
+
+type registrarDomainContact struct {
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Organization string `json:"organization"`
+	Email        string `json:"email"`
+	Country      string `json:"country"`
+}
+
+// registrarDomainTransferIn is the state of each step of a transfer to
+// Cloudflare, e.g. "pending" or "ok".
+type registrarDomainTransferIn struct {
+	UnlockDomain      string `json:"unlock_domain"`
+	DisablePrivacy    string `json:"disable_privacy"`
+	EnterAuthCode     string `json:"enter_auth_code"`
+	ApproveTransfer   string `json:"approve_transfer"`
+	AcceptFOA         string `json:"accept_foa"`
+	CanCancelTransfer bool   `json:"can_cancel_transfer"`
+}
+
+func resourceCloudFlareRegistrarDomain() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareRegistrarDomainCreate,
+		Read:   resourceCloudFlareRegistrarDomainRead,
+		Update: resourceCloudFlareRegistrarDomainUpdate,
+		Delete: resourceCloudFlareRegistrarDomainDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"auto_renew": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"privacy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name_servers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"registrant": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"first_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"organization": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"transfer_in": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unlock_domain": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"disable_privacy": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enter_auth_code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"approve_transfer": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"accept_foa": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"can_cancel_transfer": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareRegistrarDomainCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+	domainName := d.Get("domain_name").(string)
+
+	// The domain has to be registered with Cloudflare already; creating the
+	// resource only takes over its settings.
+	err := client.apiRequest("GET", registrarDomainURI(accountID, domainName), nil, nil)
+	if isNotFound(err) {
+		return fmt.Errorf("Domain %q isn't registered with Cloudflare Registrar in account %q. "+
+			"Register or transfer it first", domainName, accountID)
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding registrar domain %q: %s", domainName, err)
+	}
+
+	if err := updateRegistrarDomain(d, client); err != nil {
+		return err
+	}
+
+	d.SetId(domainName)
+
+	log.Printf("[INFO] CloudFlare Registrar Domain ID: %s", d.Id())
+
+	return resourceCloudFlareRegistrarDomainRead(d, meta)
+}
+
+func resourceCloudFlareRegistrarDomainRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var domain registrarDomain
+	err := client.apiRequest("GET", registrarDomainURI(accountID, d.Id()), nil, &domain)
+	if isNotFound(err) {
+		log.Printf("[INFO] Registrar domain %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding registrar domain %q: %s", d.Id(), err)
+	}
+
+	d.Set("domain_name", d.Id())
+	d.Set("auto_renew", domain.AutoRenew)
+	d.Set("locked", domain.Locked)
+	d.Set("privacy", domain.Privacy)
+	d.Set("expires_at", domain.ExpiresAt)
+
+	if err := d.Set("name_servers", stringsToInterfaces(domain.NameServers)); err != nil {
+		return fmt.Errorf("Error setting name_servers: %s", err)
+	}
+	if err := d.Set("registrant", flattenRegistrarDomainContact(domain.Registrant)); err != nil {
+		return fmt.Errorf("Error setting registrant: %s", err)
+	}
+	if err := d.Set("transfer_in", flattenRegistrarDomainTransferIn(domain.TransferIn)); err != nil {
+		return fmt.Errorf("Error setting transfer_in: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareRegistrarDomainUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := updateRegistrarDomain(d, meta.(*CloudFlareClient)); err != nil {
+		return err
+	}
+
+	return resourceCloudFlareRegistrarDomainRead(d, meta)
+}
+
+func resourceCloudFlareRegistrarDomainDelete(d *schema.ResourceData, meta interface{}) error {
+	// Domains can't be deregistered through the API, so the domain keeps
+	// its last settings.
+	log.Printf("[INFO] Removing registrar domain %s from state", d.Id())
+
+	return nil
+}
+
+func updateRegistrarDomain(d *schema.ResourceData, client *CloudFlareClient) error {
+	accountID := d.Get("account_id").(string)
+	domainName := d.Get("domain_name").(string)
+	domain := registrarDomain{
+		AutoRenew: d.Get("auto_renew").(bool),
+		Locked:    d.Get("locked").(bool),
+		Privacy:   d.Get("privacy").(bool),
+	}
+
+	log.Printf("[DEBUG] CloudFlare Registrar Domain %s configuration: %#v", domainName, domain)
+
+	if err := client.apiRequest("PUT", registrarDomainURI(accountID, domainName), domain, nil); err != nil {
+		return fmt.Errorf("Error updating registrar domain %q: %s", domainName, err)
+	}
+	return nil
+}
+
+func registrarDomainURI(accountID, domainName string) string {
+	return "/accounts/" + accountID + "/registrar/domains/" + domainName
+}
+
+func flattenRegistrarDomainContact(contact *registrarDomainContact) []interface{} {
+	if contact == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"first_name":   contact.FirstName,
+		"last_name":    contact.LastName,
+		"organization": contact.Organization,
+		"email":        contact.Email,
+		"country":      contact.Country,
+	}}
+}
+
+func flattenRegistrarDomainTransferIn(transfer *registrarDomainTransferIn) []interface{} {
+	if transfer == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"unlock_domain":       transfer.UnlockDomain,
+		"disable_privacy":     transfer.DisablePrivacy,
+		"enter_auth_code":     transfer.EnterAuthCode,
+		"approve_transfer":    transfer.ApproveTransfer,
+		"accept_foa":          transfer.AcceptFOA,
+		"can_cancel_transfer": transfer.CanCancelTransfer,
+	}}
+}
@@ -0,0 +1,66 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareRegistrarDomain_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	domainName := os.Getenv("CLOUDFLARE_REGISTRAR_DOMAIN")
+	name := "cloudflare_registrar_domain.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+			testAccPreCheckRegistrarDomain(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRegistrarDomainConfig, accountID, domainName, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "domain_name", domainName),
+					resource.TestCheckResourceAttr(name, "auto_renew", "true"),
+					resource.TestCheckResourceAttr(name, "locked", "true"),
+					resource.TestCheckResourceAttrSet(name, "expires_at"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRegistrarDomainConfig, accountID, domainName, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "auto_renew", "false"),
+					resource.TestCheckResourceAttr(name, "locked", "false"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+			// Leave the domain as it was found.
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareRegistrarDomainConfig, accountID, domainName, true, true),
+			},
+		},
+	})
+}
+
+func testAccPreCheckRegistrarDomain(t *testing.T) {
+	if v := os.Getenv("CLOUDFLARE_REGISTRAR_DOMAIN"); v == "" {
+		t.Fatal("CLOUDFLARE_REGISTRAR_DOMAIN must be set for this acceptance test. It is a domain registered with Cloudflare Registrar in the account.")
+	}
+}
+
+const testAccCheckCloudFlareRegistrarDomainConfig = `
+resource "cloudflare_registrar_domain" "foobar" {
+	account_id = "%s"
+	domain_name = "%s"
+	auto_renew = %t
+	locked = %t
+}`
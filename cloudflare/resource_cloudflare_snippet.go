@@ -0,0 +1,361 @@
+package cloudflare
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"path"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// snippet is the metadata of a snippet, JavaScript run on the requests of
+// a zone that match its snippet rules.
+type snippet struct {
+	SnippetName string `json:"snippet_name"`
+	MainModule  string `json:"main_module,omitempty"`
+	CreatedOn   string `json:"created_on,omitempty"`
+	ModifiedOn  string `json:"modified_on,omitempty"`
+}
+
+// snippetMetadata is the "metadata" part of a snippet upload.
+type snippetMetadata struct {
+	MainModule string `json:"main_module"`
+}
+
+func resourceCloudFlareSnippet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareSnippetCreate,
+		Read:   resourceCloudFlareSnippetRead,
+		Update: resourceCloudFlareSnippetUpdate,
+		Delete: resourceCloudFlareSnippetDelete,
+		Importer: &schema.ResourceImporter{
+			State: importZoneScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSnippetName,
+			},
+
+			// main_module is the name of the file the snippet runs; the
+			// others are modules it imports.
+			"main_module": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"files": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"content": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+				Set: snippetFileHash,
+			},
+
+			"created_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"modified_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareSnippetCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	if err := resourceCloudFlareSnippetUpload(d, meta); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+
+	log.Printf("[INFO] CloudFlare Snippet ID: %s", d.Id())
+
+	return resourceCloudFlareSnippetRead(d, meta)
+}
+
+func resourceCloudFlareSnippetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	var s snippet
+	err := client.apiRequest("GET", snippetURI(zoneID, d.Id()), nil, &s)
+	if isNotFound(err) {
+		log.Printf("[INFO] Snippet %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding snippet %q: %s", d.Id(), err)
+	}
+
+	deployed, err := client.snippetFiles(zoneID, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error finding the files of snippet %q: %s", d.Id(), err)
+	}
+
+	// The content of each file is compared by hash, so that only files
+	// changed outside of Terraform are reported.
+	deployedHashes := snippetFileHashes(deployed)
+	for name, hash := range snippetFileHashes(expandSnippetFiles(d.Get("files").(*schema.Set))) {
+		if deployedHash, ok := deployedHashes[name]; ok && deployedHash != hash {
+			log.Printf("[INFO] File %q of snippet %s changed outside of Terraform", name, d.Id())
+		}
+	}
+
+	d.Set("name", s.SnippetName)
+	d.Set("main_module", snippetMainModule(s, d.Get("main_module").(string), deployed))
+	d.Set("created_on", s.CreatedOn)
+	d.Set("modified_on", s.ModifiedOn)
+
+	if err := d.Set("files", flattenSnippetFiles(deployed)); err != nil {
+		return fmt.Errorf("Error setting files: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareSnippetUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceCloudFlareSnippetUpload(d, meta); err != nil {
+		return err
+	}
+
+	return resourceCloudFlareSnippetRead(d, meta)
+}
+
+func resourceCloudFlareSnippetDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Snippet: %s, %s", zoneID, d.Id())
+
+	err := client.apiRequest("DELETE", snippetURI(zoneID, d.Id()), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting snippet %q: %s", d.Id(), err)
+}
+
+// resourceCloudFlareSnippetUpload uploads all the files of the snippet in
+// one request, which replaces whatever was deployed before, including a
+// main module of another name.
+func resourceCloudFlareSnippetUpload(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+	name := d.Get("name").(string)
+	mainModule := d.Get("main_module").(string)
+	files := expandSnippetFiles(d.Get("files").(*schema.Set))
+
+	if err := checkSnippet(mainModule, files); err != nil {
+		return err
+	}
+
+	body, contentType, err := snippetUploadBody(mainModule, files)
+	if err != nil {
+		return fmt.Errorf("Error building upload of snippet %q: %s", name, err)
+	}
+
+	log.Printf("[DEBUG] CloudFlare Snippet upload: %s, main module %s, files %v", name, mainModule, snippetFileHashes(files))
+
+	if err := client.apiRequestWithBody("PUT", snippetURI(zoneID, name), contentType, body, nil); err != nil {
+		return fmt.Errorf("Error uploading snippet %q for zone %q: %s", name, zoneID, err)
+	}
+	return nil
+}
+
+func snippetURI(zoneID, name string) string {
+	return "/zones/" + zoneID + "/snippets/" + name
+}
+
+// checkSnippet ensures that the main module is one of the files.
+func checkSnippet(mainModule string, files map[string]string) error {
+	if _, ok := files[mainModule]; !ok {
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("main_module %q must be the name of one of the files, got: %v", mainModule, names)
+	}
+	return nil
+}
+
+// snippetUploadBody builds the multipart form snippets are uploaded as: the
+// metadata naming the main module, followed by a part for each file, the
+// main module first.
+func snippetUploadBody(mainModule string, files map[string]string) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	metadata, err := json.Marshal(snippetMetadata{MainModule: mainModule})
+	if err != nil {
+		return nil, "", err
+	}
+	if err := w.WriteField("metadata", string(metadata)); err != nil {
+		return nil, "", err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if name != mainModule {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range append([]string{mainModule}, names...) {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, name, name))
+		header.Set("Content-Type", snippetFileContentType(name))
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write([]byte(files[name])); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, w.FormDataContentType(), nil
+}
+
+func snippetFileContentType(name string) string {
+	switch path.Ext(name) {
+	case ".js", ".mjs":
+		return "application/javascript+module"
+	case ".wasm":
+		return "application/wasm"
+	}
+	return "application/octet-stream"
+}
+
+// snippetFiles fetches the deployed files of a snippet from the snippet
+// content API, which returns them as a multipart form.
+func (client *CloudFlareClient) snippetFiles(zoneID, name string) (map[string]string, error) {
+	raw, contentType, err := client.apiRawRequest("GET", snippetURI(zoneID, name)+"/content")
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return nil, fmt.Errorf("expected multipart content, got %q", contentType)
+	}
+
+	files := map[string]string{}
+	r := multipart.NewReader(bytes.NewReader(raw), params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err != nil {
+			if err == io.EOF {
+				return files, nil
+			}
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		files[part.FormName()] = string(content)
+	}
+}
+
+// snippetMainModule returns the main module of a deployed snippet. The
+// metadata doesn't always name it, in which case the current main module is
+// kept while it is still deployed, and a snippet of a single file is
+// assumed to run that file. Otherwise it is left empty, so that uploading
+// the configured main module again is planned.
+func snippetMainModule(s snippet, current string, deployed map[string]string) string {
+	if s.MainModule != "" {
+		return s.MainModule
+	}
+	if _, ok := deployed[current]; ok {
+		return current
+	}
+	if len(deployed) == 1 {
+		for name := range deployed {
+			return name
+		}
+	}
+	return ""
+}
+
+func expandSnippetFiles(set *schema.Set) map[string]string {
+	files := map[string]string{}
+	for _, v := range set.List() {
+		m := v.(map[string]interface{})
+		files[m["name"].(string)] = m["content"].(string)
+	}
+	return files
+}
+
+func flattenSnippetFiles(files map[string]string) *schema.Set {
+	set := schema.NewSet(snippetFileHash, nil)
+	for name, content := range files {
+		set.Add(map[string]interface{}{
+			"name":    name,
+			"content": content,
+		})
+	}
+	return set
+}
+
+// snippetFileHashes returns the SHA-256 of the content of each file.
+func snippetFileHashes(files map[string]string) map[string]string {
+	hashes := make(map[string]string, len(files))
+	for name, content := range files {
+		hashes[name] = snippetContentHash(content)
+	}
+	return hashes
+}
+
+func snippetContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// snippetFileHash hashes a file by its name and the hash of its content,
+// so files whose content changed are replaced in the set.
+func snippetFileHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s-%s", m["name"], snippetContentHash(m["content"].(string))))
+}
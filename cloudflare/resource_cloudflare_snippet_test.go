@@ -0,0 +1,282 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareSnippet_MultiFile(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "cloudflare_snippet.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareSnippetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareSnippetConfig, zoneID, "main.js", "main.js"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform_snippet"),
+					resource.TestCheckResourceAttr(name, "main_module", "main.js"),
+					resource.TestCheckResourceAttr(name, "files.#", "2"),
+					resource.TestCheckResourceAttrSet(name, "created_on"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareSnippetConfig, zoneID, "index.js", "index.js"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "main_module", "index.js"),
+					resource.TestCheckResourceAttr(name, "files.#", "2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: zoneID + "/",
+			},
+		},
+	})
+}
+
+// mockSnippetAPI serves the snippet and snippet content APIs for a single
+// snippet of zone "abc".
+type mockSnippetAPI struct {
+	t          *testing.T
+	name       string
+	mainModule string
+	files      map[string]string
+}
+
+func (api *mockSnippetAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uri := snippetURI("abc", api.name)
+	switch {
+	case r.URL.Path == uri && r.Method == "PUT":
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			api.t.Errorf("invalid upload: %s", err)
+		}
+		var metadata snippetMetadata
+		if err := json.Unmarshal([]byte(r.FormValue("metadata")), &metadata); err != nil {
+			api.t.Errorf("invalid metadata: %s", err)
+		}
+		api.mainModule = metadata.MainModule
+		api.files = map[string]string{}
+		for name, headers := range r.MultipartForm.File {
+			f, err := headers[0].Open()
+			if err != nil {
+				api.t.Errorf("invalid file %q: %s", name, err)
+				continue
+			}
+			content, _ := ioutil.ReadAll(f)
+			f.Close()
+			api.files[name] = string(content)
+		}
+		writeTestResult(w, api.snippet())
+	case r.URL.Path == uri && r.Method == "GET" && api.files != nil:
+		writeTestResult(w, api.snippet())
+	case r.URL.Path == uri+"/content" && r.Method == "GET" && api.files != nil:
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", mw.FormDataContentType())
+		for name, content := range api.files {
+			part, _ := mw.CreateFormFile(name, name)
+			part.Write([]byte(content))
+		}
+		mw.Close()
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 1000, "message": "not found"}], "messages": [], "result": null}`)
+	}
+}
+
+// snippet doesn't name the main module, as the API doesn't either.
+func (api *mockSnippetAPI) snippet() snippet {
+	return snippet{SnippetName: api.name, CreatedOn: "2024-01-01T00:00:00Z", ModifiedOn: "2024-01-01T00:00:00Z"}
+}
+
+func testSnippetConfig(mainModule string) map[string]interface{} {
+	return map[string]interface{}{
+		"zone_id":     "abc",
+		"name":        "rewrite",
+		"main_module": mainModule,
+		"files": []interface{}{
+			map[string]interface{}{"name": mainModule, "content": `import { rewrite } from "./rewrite.js"; export default { fetch: rewrite };`},
+			map[string]interface{}{"name": "rewrite.js", "content": `export function rewrite(request) { return fetch(request); }`},
+		},
+	}
+}
+
+func TestCloudFlareSnippet_MultiFile(t *testing.T) {
+	api := &mockSnippetAPI{t: t, name: "rewrite"}
+	ts := httptest.NewServer(api)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	c := testSnippetConfig("main.js")
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareSnippet().Schema, c)
+	if err := resourceCloudFlareSnippetCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if api.mainModule != "main.js" || len(api.files) != 2 {
+		t.Fatalf("bad upload: main module %q, files %v", api.mainModule, api.files)
+	}
+	if d.Id() != "rewrite" || d.Get("main_module") != "main.js" || d.Get("files.#") != 2 {
+		t.Fatalf("bad state: id %q, main_module %v, files.# %v", d.Id(), d.Get("main_module"), d.Get("files.#"))
+	}
+
+	raw, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := resourceCloudFlareSnippet().Diff(d.State(), terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff after create, got %#v", diff)
+	}
+
+	// Renaming the main module uploads it under its new name only.
+	renamed := testSnippetConfig("index.js")
+	d = schema.TestResourceDataRaw(t, resourceCloudFlareSnippet().Schema, renamed)
+	d.SetId("rewrite")
+	if err := resourceCloudFlareSnippetUpdate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, ok := api.files["main.js"]; ok || api.mainModule != "index.js" || len(api.files) != 2 {
+		t.Fatalf("bad upload after rename: main module %q, files %v", api.mainModule, api.files)
+	}
+	if d.Get("main_module") != "index.js" {
+		t.Fatalf("expected main_module %q, got %v", "index.js", d.Get("main_module"))
+	}
+}
+
+func TestCloudFlareSnippetRead_ContentDrift(t *testing.T) {
+	api := &mockSnippetAPI{t: t, name: "rewrite"}
+	ts := httptest.NewServer(api)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	c := testSnippetConfig("main.js")
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareSnippet().Schema, c)
+	if err := resourceCloudFlareSnippetCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	api.files["rewrite.js"] = `export function rewrite(request) { return new Response("changed"); }`
+	d = resourceCloudFlareSnippet().Data(d.State())
+	if err := resourceCloudFlareSnippetRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if files := expandSnippetFiles(d.Get("files").(*schema.Set)); files["rewrite.js"] != api.files["rewrite.js"] {
+		t.Fatalf("expected the deployed content to be read, got %q", files["rewrite.js"])
+	}
+
+	raw, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := resourceCloudFlareSnippet().Diff(d.State(), terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Fatalf("expected a diff after the content changed outside of Terraform")
+	}
+}
+
+func TestCloudFlareSnippet_Import(t *testing.T) {
+	api := &mockSnippetAPI{t: t, name: "rewrite", files: map[string]string{
+		"main.js": `export default { fetch(request) { return fetch(request); } };`,
+	}}
+	ts := httptest.NewServer(api)
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := resourceCloudFlareSnippet().Data(nil)
+	d.SetId("abc/rewrite")
+	imported, err := importZoneScopedResource(d, client)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	d = imported[0]
+	if err := resourceCloudFlareSnippetRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Get("zone_id") != "abc" || d.Get("name") != "rewrite" || d.Get("main_module") != "main.js" {
+		t.Fatalf("bad state: zone_id %v, name %v, main_module %v", d.Get("zone_id"), d.Get("name"), d.Get("main_module"))
+	}
+	if files := expandSnippetFiles(d.Get("files").(*schema.Set)); files["main.js"] != api.files["main.js"] {
+		t.Fatalf("expected the files to be read from the content API, got %v", files)
+	}
+}
+
+func TestCheckSnippet(t *testing.T) {
+	files := map[string]string{"main.js": "", "rewrite.js": ""}
+	if err := checkSnippet("main.js", files); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := checkSnippet("index.js", files); err == nil {
+		t.Fatalf("expected a main module that isn't one of the files to be rejected")
+	}
+}
+
+func testAccCheckCloudFlareSnippetDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_snippet" {
+			continue
+		}
+
+		if err := client.apiRequest("GET", snippetURI(rs.Primary.Attributes["zone_id"], rs.Primary.ID), nil, nil); err == nil {
+			return fmt.Errorf("Snippet still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareSnippetConfig = `
+resource "cloudflare_snippet" "foobar" {
+	zone_id = "%s"
+	name = "terraform_snippet"
+	main_module = "%s"
+
+	files {
+		name = "%s"
+		content = "import { rewrite } from \"./rewrite.js\"; export default { fetch: rewrite };"
+	}
+
+	files {
+		name = "rewrite.js"
+		content = "export function rewrite(request) { return fetch(request); }"
+	}
+}`
@@ -0,0 +1,117 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dispatchNamespace holds the workers a Workers for Platforms dispatch worker
+// routes requests to, typically one per customer of a platform.
+type dispatchNamespace struct {
+	NamespaceID   string `json:"namespace_id,omitempty"`
+	NamespaceName string `json:"namespace_name,omitempty"`
+	CreatedOn     string `json:"created_on,omitempty"`
+	ModifiedOn    string `json:"modified_on,omitempty"`
+}
+
+func resourceCloudFlareWorkersForPlatformsDispatchNamespace() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareWorkersForPlatformsDispatchNamespaceCreate,
+		Read:   resourceCloudFlareWorkersForPlatformsDispatchNamespaceRead,
+		Delete: resourceCloudFlareWorkersForPlatformsDispatchNamespaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"namespace_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"modified_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareWorkersForPlatformsDispatchNamespaceCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] CloudFlare Dispatch Namespace create configuration: %s, %s", accountID, name)
+
+	var created dispatchNamespace
+	if err := client.apiRequest("POST", dispatchNamespacesURI(accountID), map[string]string{"name": name}, &created); err != nil {
+		return fmt.Errorf("Error creating dispatch namespace %q for account %q: %s", name, accountID, err)
+	}
+
+	// Namespaces are addressed by name rather than by namespace_id.
+	d.SetId(name)
+
+	log.Printf("[INFO] CloudFlare Dispatch Namespace ID: %s", d.Id())
+
+	return resourceCloudFlareWorkersForPlatformsDispatchNamespaceRead(d, meta)
+}
+
+func resourceCloudFlareWorkersForPlatformsDispatchNamespaceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var namespace dispatchNamespace
+	err := client.apiRequest("GET", dispatchNamespacesURI(accountID)+"/"+d.Id(), nil, &namespace)
+	if isNotFound(err) {
+		log.Printf("[INFO] Dispatch namespace %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding dispatch namespace %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", namespace.NamespaceName)
+	d.Set("namespace_id", namespace.NamespaceID)
+	d.Set("created_on", namespace.CreatedOn)
+	d.Set("modified_on", namespace.ModifiedOn)
+
+	return nil
+}
+
+func resourceCloudFlareWorkersForPlatformsDispatchNamespaceDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Dispatch Namespace: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", dispatchNamespacesURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting dispatch namespace %q: %s", d.Id(), err)
+}
+
+func dispatchNamespacesURI(accountID string) string {
+	return "/accounts/" + accountID + "/workers/dispatch/namespaces"
+}
@@ -0,0 +1,64 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareWorkersForPlatformsDispatchNamespace_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	namespaceName := "terraform-acctest"
+	name := "cloudflare_workers_for_platforms_dispatch_namespace.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareWorkersForPlatformsDispatchNamespaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareWorkersForPlatformsDispatchNamespaceConfig, accountID, namespaceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", namespaceName),
+					resource.TestCheckResourceAttrSet(name, "namespace_id"),
+					resource.TestCheckResourceAttrSet(name, "created_on"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareWorkersForPlatformsDispatchNamespaceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_workers_for_platforms_dispatch_namespace" {
+			continue
+		}
+
+		uri := dispatchNamespacesURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Dispatch namespace still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareWorkersForPlatformsDispatchNamespaceConfig = `
+resource "cloudflare_workers_for_platforms_dispatch_namespace" "foobar" {
+	account_id = "%s"
+	name = "%s"
+}`
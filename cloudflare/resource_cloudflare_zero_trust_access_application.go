@@ -0,0 +1,502 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessApplication is an application protected by Cloudflare Access.
+type accessApplication struct {
+	ID                     string             `json:"id,omitempty"`
+	AUD                    string             `json:"aud,omitempty"`
+	Name                   string             `json:"name"`
+	Domain                 string             `json:"domain,omitempty"`
+	Type                   string             `json:"type,omitempty"`
+	SessionDuration        string             `json:"session_duration,omitempty"`
+	AutoRedirectToIdentity bool               `json:"auto_redirect_to_identity"`
+	AllowedIdPs            []string           `json:"allowed_idps"`
+	CORSHeaders            *accessCORSHeaders `json:"cors_headers,omitempty"`
+	SaasApp                *accessSaasApp     `json:"saas_app,omitempty"`
+	Policies               []accessAppPolicy  `json:"policies"`
+	Tags                   []string           `json:"tags"`
+}
+
+// accessAppPolicy attaches a reusable Access policy to an application.
+// Policies are evaluated in order of precedence, lowest first.
+type accessAppPolicy struct {
+	ID         string `json:"id"`
+	Precedence int    `json:"precedence"`
+}
+
+type accessCORSHeaders struct {
+	AllowedMethods   []string `json:"allowed_methods"`
+	AllowedOrigins   []string `json:"allowed_origins"`
+	AllowedHeaders   []string `json:"allowed_headers"`
+	AllowAllMethods  bool     `json:"allow_all_methods"`
+	AllowAllOrigins  bool     `json:"allow_all_origins"`
+	AllowAllHeaders  bool     `json:"allow_all_headers"`
+	AllowCredentials bool     `json:"allow_credentials"`
+	MaxAge           int      `json:"max_age"`
+}
+
+// accessSaasApp is the SAML configuration of a SaaS application. The
+// IdP fields are generated by Cloudflare for the service provider to use.
+type accessSaasApp struct {
+	SPEntityID         string                `json:"sp_entity_id"`
+	ConsumerServiceURL string                `json:"consumer_service_url"`
+	NameIDFormat       string                `json:"name_id_format"`
+	CustomAttributes   []accessSaasAttribute `json:"custom_attributes"`
+	IdPEntityID        string                `json:"idp_entity_id,omitempty"`
+	SSOEndpoint        string                `json:"sso_endpoint,omitempty"`
+	PublicKey          string                `json:"public_key,omitempty"`
+}
+
+type accessSaasAttribute struct {
+	Name       string                    `json:"name"`
+	NameFormat string                    `json:"name_format,omitempty"`
+	Source     accessSaasAttributeSource `json:"source"`
+}
+
+type accessSaasAttributeSource struct {
+	Name string `json:"name"`
+}
+
+func resourceCloudFlareZeroTrustAccessApplication() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessApplicationCreate,
+		Read:   resourceCloudFlareZeroTrustAccessApplicationRead,
+		Update: resourceCloudFlareZeroTrustAccessApplicationUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessApplicationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "self_hosted",
+				ValidateFunc: validateAccessApplicationType,
+			},
+
+			"session_duration": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "24h",
+			},
+
+			"auto_redirect_to_identity": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"allowed_idps": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"policies": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"aud": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cors_headers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allowed_methods": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"allowed_origins": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"allowed_headers": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"allow_all_methods": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"allow_all_origins": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"allow_all_headers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"allow_credentials": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"max_age": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"saas_app": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sp_entity_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"consumer_service_url": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name_id_format": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "email",
+							ValidateFunc: validateAccessSaasNameIDFormat,
+						},
+						"custom_attribute": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"name_format": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"source": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"idp_entity_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sso_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessApplicationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	app := accessApplicationFromResourceData(d)
+	if err := checkAccessApplication(app); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Access Application create configuration: %#v", app)
+
+	var created accessApplication
+	if err := client.apiRequest("POST", accessApplicationsURI(accountID), app, &created); err != nil {
+		return fmt.Errorf("Error creating Access application for account %q: %s", accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find Access application in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Access Application ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustAccessApplicationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessApplicationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var app accessApplication
+	err := client.apiRequest("GET", accessApplicationsURI(accountID)+"/"+d.Id(), nil, &app)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access application %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access application %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", app.Name)
+	d.Set("domain", app.Domain)
+	d.Set("type", app.Type)
+	d.Set("session_duration", app.SessionDuration)
+	d.Set("auto_redirect_to_identity", app.AutoRedirectToIdentity)
+	d.Set("aud", app.AUD)
+
+	if err := d.Set("allowed_idps", schema.NewSet(schema.HashString, stringsToInterfaces(app.AllowedIdPs))); err != nil {
+		return fmt.Errorf("Error setting allowed_idps: %s", err)
+	}
+
+	if err := d.Set("tags", schema.NewSet(schema.HashString, stringsToInterfaces(app.Tags))); err != nil {
+		return fmt.Errorf("Error setting tags: %s", err)
+	}
+
+	if err := d.Set("policies", flattenAccessAppPolicies(app.Policies)); err != nil {
+		return fmt.Errorf("Error setting policies: %s", err)
+	}
+
+	if err := d.Set("cors_headers", flattenAccessCORSHeaders(app.CORSHeaders)); err != nil {
+		return fmt.Errorf("Error setting cors_headers: %s", err)
+	}
+
+	if err := d.Set("saas_app", flattenAccessSaasApp(app.SaasApp)); err != nil {
+		return fmt.Errorf("Error setting saas_app: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessApplicationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	app := accessApplicationFromResourceData(d)
+	if err := checkAccessApplication(app); err != nil {
+		return err
+	}
+
+	accessApplicationPoliciesMu.Lock()
+	defer accessApplicationPoliciesMu.Unlock()
+
+	uri := accessApplicationsURI(accountID) + "/" + d.Id()
+
+	// Policies left out of the configuration may be attached by
+	// cloudflare_zero_trust_access_application_policy_attachment resources,
+	// so they are kept as they are, precedence included.
+	if !d.HasChange("policies") {
+		var current accessApplication
+		if err := client.apiRequest("GET", uri, nil, &current); err != nil {
+			return fmt.Errorf("Error finding Access application %q: %s", d.Id(), err)
+		}
+		app.Policies = current.Policies
+	}
+
+	log.Printf("[DEBUG] CloudFlare Access Application update configuration: %#v", app)
+
+	if err := client.apiRequest("PUT", uri, app, nil); err != nil {
+		return fmt.Errorf("Error updating Access application %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustAccessApplicationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessApplicationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Access Application: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", accessApplicationsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Access application %q: %s", d.Id(), err)
+}
+
+func accessApplicationsURI(accountID string) string {
+	return "/accounts/" + accountID + "/access/apps"
+}
+
+// checkAccessApplication ensures that SaaS applications are configured with
+// saas_app and that other applications have a domain instead.
+func checkAccessApplication(app accessApplication) error {
+	if app.Type == "saas" && app.SaasApp == nil {
+		return fmt.Errorf("Access application %q of type \"saas\" requires a saas_app block", app.Name)
+	}
+	if app.Type != "saas" && app.SaasApp != nil {
+		return fmt.Errorf("Access application %q has a saas_app block but its type is %q, not \"saas\"", app.Name, app.Type)
+	}
+	if app.Type != "saas" && app.Domain == "" {
+		return fmt.Errorf("Access application %q of type %q requires a domain", app.Name, app.Type)
+	}
+	return nil
+}
+
+func accessApplicationFromResourceData(d *schema.ResourceData) accessApplication {
+	app := accessApplication{
+		Name:                   d.Get("name").(string),
+		Domain:                 d.Get("domain").(string),
+		Type:                   d.Get("type").(string),
+		SessionDuration:        d.Get("session_duration").(string),
+		AutoRedirectToIdentity: d.Get("auto_redirect_to_identity").(bool),
+		AllowedIdPs:            expandStringSet(d.Get("allowed_idps")),
+		Policies:               expandAccessAppPolicies(d.Get("policies").([]interface{})),
+		Tags:                   expandStringSet(d.Get("tags")),
+	}
+
+	if v, ok := d.GetOk("cors_headers"); ok {
+		app.CORSHeaders = expandAccessCORSHeaders(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("saas_app"); ok {
+		app.SaasApp = expandAccessSaasApp(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	return app
+}
+
+// expandAccessAppPolicies gives the policies of an application their
+// precedence from their order in the configuration.
+func expandAccessAppPolicies(ids []interface{}) []accessAppPolicy {
+	policies := make([]accessAppPolicy, 0, len(ids))
+	for i, id := range ids {
+		policies = append(policies, accessAppPolicy{ID: id.(string), Precedence: i + 1})
+	}
+	return policies
+}
+
+func flattenAccessAppPolicies(policies []accessAppPolicy) []interface{} {
+	sorted := append([]accessAppPolicy(nil), policies...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Precedence < sorted[j].Precedence })
+
+	ids := make([]interface{}, 0, len(sorted))
+	for _, policy := range sorted {
+		ids = append(ids, policy.ID)
+	}
+	return ids
+}
+
+func expandAccessCORSHeaders(m map[string]interface{}) *accessCORSHeaders {
+	return &accessCORSHeaders{
+		AllowedMethods:   expandStringSet(m["allowed_methods"]),
+		AllowedOrigins:   expandStringSet(m["allowed_origins"]),
+		AllowedHeaders:   expandStringSet(m["allowed_headers"]),
+		AllowAllMethods:  m["allow_all_methods"].(bool),
+		AllowAllOrigins:  m["allow_all_origins"].(bool),
+		AllowAllHeaders:  m["allow_all_headers"].(bool),
+		AllowCredentials: m["allow_credentials"].(bool),
+		MaxAge:           m["max_age"].(int),
+	}
+}
+
+func flattenAccessCORSHeaders(headers *accessCORSHeaders) []interface{} {
+	if headers == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"allowed_methods":   schema.NewSet(schema.HashString, stringsToInterfaces(headers.AllowedMethods)),
+		"allowed_origins":   schema.NewSet(schema.HashString, stringsToInterfaces(headers.AllowedOrigins)),
+		"allowed_headers":   schema.NewSet(schema.HashString, stringsToInterfaces(headers.AllowedHeaders)),
+		"allow_all_methods": headers.AllowAllMethods,
+		"allow_all_origins": headers.AllowAllOrigins,
+		"allow_all_headers": headers.AllowAllHeaders,
+		"allow_credentials": headers.AllowCredentials,
+		"max_age":           headers.MaxAge,
+	}}
+}
+
+func expandAccessSaasApp(m map[string]interface{}) *accessSaasApp {
+	app := &accessSaasApp{
+		SPEntityID:         m["sp_entity_id"].(string),
+		ConsumerServiceURL: m["consumer_service_url"].(string),
+		NameIDFormat:       m["name_id_format"].(string),
+		CustomAttributes:   []accessSaasAttribute{},
+	}
+
+	for _, v := range m["custom_attribute"].([]interface{}) {
+		attribute := v.(map[string]interface{})
+		app.CustomAttributes = append(app.CustomAttributes, accessSaasAttribute{
+			Name:       attribute["name"].(string),
+			NameFormat: attribute["name_format"].(string),
+			Source:     accessSaasAttributeSource{Name: attribute["source"].(string)},
+		})
+	}
+
+	return app
+}
+
+func flattenAccessSaasApp(app *accessSaasApp) []interface{} {
+	if app == nil {
+		return []interface{}{}
+	}
+
+	attributes := make([]interface{}, 0, len(app.CustomAttributes))
+	for _, attribute := range app.CustomAttributes {
+		attributes = append(attributes, map[string]interface{}{
+			"name":        attribute.Name,
+			"name_format": attribute.NameFormat,
+			"source":      attribute.Source.Name,
+		})
+	}
+
+	return []interface{}{map[string]interface{}{
+		"sp_entity_id":         app.SPEntityID,
+		"consumer_service_url": app.ConsumerServiceURL,
+		"name_id_format":       app.NameIDFormat,
+		"custom_attribute":     attributes,
+		"idp_entity_id":        app.IdPEntityID,
+		"sso_endpoint":         app.SSOEndpoint,
+		"public_key":           app.PublicKey,
+	}}
+}
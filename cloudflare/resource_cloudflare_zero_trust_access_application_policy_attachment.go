@@ -0,0 +1,188 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessApplicationPoliciesMu serialises changes to the policies of Access
+// applications. Attachments update an application by reading it and writing
+// it back, so concurrent attachments to the same application would
+// otherwise drop each other's policies.
+var accessApplicationPoliciesMu sync.Mutex
+
+func resourceCloudFlareZeroTrustAccessApplicationPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentCreate,
+		Read:   resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentRead,
+		Update: resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"application_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"precedence": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	appID := d.Get("application_id").(string)
+	policyID := d.Get("policy_id").(string)
+
+	if err := attachAccessApplicationPolicy(d, meta); err != nil {
+		return err
+	}
+
+	d.SetId(appID + "/" + policyID)
+
+	log.Printf("[INFO] CloudFlare Access Application Policy Attachment ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+	appID := d.Get("application_id").(string)
+	policyID := d.Get("policy_id").(string)
+
+	var app accessApplication
+	err := client.apiRequest("GET", accessApplicationsURI(accountID)+"/"+appID, nil, &app)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access application %s no longer exists", appID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access application %q: %s", appID, err)
+	}
+
+	for _, policy := range app.Policies {
+		if policy.ID == policyID {
+			d.Set("precedence", policy.Precedence)
+			return nil
+		}
+	}
+
+	log.Printf("[INFO] Access policy %s is no longer attached to application %s", policyID, appID)
+	d.SetId("")
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := attachAccessApplicationPolicy(d, meta); err != nil {
+		return err
+	}
+
+	return resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	accountID := d.Get("account_id").(string)
+	appID := d.Get("application_id").(string)
+	policyID := d.Get("policy_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Access Application Policy Attachment: %s, %s", accountID, d.Id())
+
+	err := updateAccessApplicationPolicies(meta.(*CloudFlareClient), accountID, appID, func(policies []accessAppPolicy) []accessAppPolicy {
+		remaining := make([]accessAppPolicy, 0, len(policies))
+		for _, policy := range policies {
+			if policy.ID != policyID {
+				remaining = append(remaining, policy)
+			}
+		}
+		return remaining
+	})
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error detaching Access policy %q from application %q: %s", policyID, appID, err)
+}
+
+// resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentImport imports
+// an attachment given as "account_id/application_id/policy_id".
+func resourceCloudFlareZeroTrustAccessApplicationPolicyAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tokens := strings.SplitN(d.Id(), "/", 3)
+	if len(tokens) != 3 || tokens[0] == "" || tokens[1] == "" || tokens[2] == "" {
+		return nil, fmt.Errorf("expecting account_id/application_id/policy_id, got %q", d.Id())
+	}
+
+	d.Set("account_id", tokens[0])
+	d.Set("application_id", tokens[1])
+	d.Set("policy_id", tokens[2])
+	d.SetId(tokens[1] + "/" + tokens[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+// attachAccessApplicationPolicy attaches the policy of an attachment to its
+// application with the configured precedence, or moves it if it is already
+// attached.
+func attachAccessApplicationPolicy(d *schema.ResourceData, meta interface{}) error {
+	accountID := d.Get("account_id").(string)
+	appID := d.Get("application_id").(string)
+	attached := accessAppPolicy{ID: d.Get("policy_id").(string), Precedence: d.Get("precedence").(int)}
+
+	log.Printf("[DEBUG] CloudFlare Access Application Policy Attachment configuration: %s, %#v", appID, attached)
+
+	err := updateAccessApplicationPolicies(meta.(*CloudFlareClient), accountID, appID, func(policies []accessAppPolicy) []accessAppPolicy {
+		updated := []accessAppPolicy{attached}
+		for _, policy := range policies {
+			if policy.ID != attached.ID {
+				updated = append(updated, policy)
+			}
+		}
+		return updated
+	})
+	if err != nil {
+		return fmt.Errorf("Error attaching Access policy %q to application %q: %s", attached.ID, appID, err)
+	}
+	return nil
+}
+
+// updateAccessApplicationPolicies applies update to the policies of an
+// Access application, leaving the rest of the application, and policies
+// attached by other resources, as they are.
+func updateAccessApplicationPolicies(client *CloudFlareClient, accountID, appID string, update func([]accessAppPolicy) []accessAppPolicy) error {
+	accessApplicationPoliciesMu.Lock()
+	defer accessApplicationPoliciesMu.Unlock()
+
+	uri := accessApplicationsURI(accountID) + "/" + appID
+
+	var app accessApplication
+	if err := client.apiRequest("GET", uri, nil, &app); err != nil {
+		return err
+	}
+
+	app.Policies = update(app.Policies)
+	sort.SliceStable(app.Policies, func(i, j int) bool { return app.Policies[i].Precedence < app.Policies[j].Precedence })
+
+	return client.apiRequest("PUT", uri, app, nil)
+}
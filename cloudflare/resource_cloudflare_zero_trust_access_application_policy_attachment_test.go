@@ -0,0 +1,210 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessApplicationPolicyAttachment_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "cloudflare_zero_trust_access_application_policy_attachment.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckCloudFlareZeroTrustAccessApplicationDestroy,
+			testAccCheckCloudFlareZeroTrustAccessPolicyDestroy,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessApplicationPolicyAttachmentConfig, accountID, accountID, domain, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "precedence", "1"),
+					testAccCheckCloudFlareZeroTrustAccessApplicationPolicyCount("cloudflare_zero_trust_access_application.foobar", 1),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessApplicationPolicyAttachmentConfigDetached, accountID, accountID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareZeroTrustAccessApplicationPolicyCount("cloudflare_zero_trust_access_application.foobar", 0),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudFlareZeroTrustAccessApplicationPolicyAttachment_KeepsOtherPolicies(t *testing.T) {
+	app := accessApplication{
+		ID:       "app",
+		Name:     "staging",
+		Domain:   "staging.example.com",
+		Type:     "self_hosted",
+		Policies: []accessAppPolicy{{ID: "other", Precedence: 2}},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != accessApplicationsURI("abc")+"/app" {
+			writeMockError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+			return
+		}
+		if r.Method == "PUT" {
+			var updated accessApplication
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Errorf("invalid application: %s", err)
+			}
+			updated.ID = app.ID
+			app = updated
+		}
+		writeTestResult(w, app)
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	r := resourceCloudFlareZeroTrustAccessApplicationPolicyAttachment()
+	d := r.TestResourceData()
+	d.Set("account_id", "abc")
+	d.Set("application_id", "app")
+	d.Set("policy_id", "policy")
+	d.Set("precedence", 1)
+
+	if err := r.Create(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "app/policy" {
+		t.Fatalf("expected ID app/policy, got %q", d.Id())
+	}
+	expected := []accessAppPolicy{{ID: "policy", Precedence: 1}, {ID: "other", Precedence: 2}}
+	if !reflect.DeepEqual(app.Policies, expected) {
+		t.Fatalf("expected policies %#v, got %#v", expected, app.Policies)
+	}
+
+	// Updating the application without configuring its policies must keep
+	// the attachments as they are.
+	appResource := resourceCloudFlareZeroTrustAccessApplication()
+	appState := appResource.TestResourceData()
+	appState.SetId("app")
+	appState.Set("account_id", "abc")
+	appState.Set("name", "staging")
+	appState.Set("domain", "staging.example.com")
+	appState.Set("type", "self_hosted")
+	if err := appResource.Update(appState, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(app.Policies, expected) {
+		t.Fatalf("expected the application update to keep policies %#v, got %#v", expected, app.Policies)
+	}
+
+	d.Set("precedence", 3)
+	if err := r.Update(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected = []accessAppPolicy{{ID: "other", Precedence: 2}, {ID: "policy", Precedence: 3}}
+	if !reflect.DeepEqual(app.Policies, expected) {
+		t.Fatalf("expected policies %#v, got %#v", expected, app.Policies)
+	}
+
+	if err := r.Delete(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected = []accessAppPolicy{{ID: "other", Precedence: 2}}
+	if !reflect.DeepEqual(app.Policies, expected) {
+		t.Fatalf("expected policies %#v, got %#v", expected, app.Policies)
+	}
+
+	// Once detached, the attachment is gone from the state.
+	if err := r.Read(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the detached policy to be removed from the state, got %q", d.Id())
+	}
+}
+
+func TestCloudFlareZeroTrustAccessApplicationPolicyAttachment_Import(t *testing.T) {
+	r := resourceCloudFlareZeroTrustAccessApplicationPolicyAttachment()
+
+	d := r.TestResourceData()
+	d.SetId("abc/app/policy")
+	if _, err := r.Importer.State(d, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "app/policy" || d.Get("account_id") != "abc" || d.Get("application_id") != "app" || d.Get("policy_id") != "policy" {
+		t.Fatalf("unexpected import of abc/app/policy: %q, %#v", d.Id(), d.State().Attributes)
+	}
+
+	for _, id := range []string{"abc/app", "abc//policy", "app/policy/"} {
+		d := r.TestResourceData()
+		d.SetId(id)
+		if _, err := r.Importer.State(d, nil); err == nil {
+			t.Fatalf("expected %q not to be imported", id)
+		}
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustAccessApplicationPolicyCount(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*CloudFlareClient)
+		var app accessApplication
+		uri := accessApplicationsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, &app); err != nil {
+			return err
+		}
+		if len(app.Policies) != count {
+			return fmt.Errorf("expected %d policies attached to the Access application, got %d", count, len(app.Policies))
+		}
+		return nil
+	}
+}
+
+const testAccCheckCloudFlareZeroTrustAccessApplicationPolicyAttachmentConfigDetached = `
+resource "cloudflare_zero_trust_access_policy" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	decision = "allow"
+
+	include {
+		email_domain = ["example.com"]
+	}
+}
+
+resource "cloudflare_zero_trust_access_application" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	domain = "terraform-acctest.%s"
+}`
+
+const testAccCheckCloudFlareZeroTrustAccessApplicationPolicyAttachmentConfig = testAccCheckCloudFlareZeroTrustAccessApplicationPolicyAttachmentConfigDetached + `
+
+resource "cloudflare_zero_trust_access_application_policy_attachment" "foobar" {
+	account_id = "%s"
+	application_id = "${cloudflare_zero_trust_access_application.foobar.id}"
+	policy_id = "${cloudflare_zero_trust_access_policy.foobar.id}"
+	precedence = 1
+}`
@@ -0,0 +1,123 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessApplication_SaaS(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_access_application.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessApplicationConfigSaaS, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "type", "saas"),
+					resource.TestCheckResourceAttr(name, "saas_app.#", "1"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.sp_entity_id", "terraform.example.com"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.name_id_format", "email"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_attribute.0.source", "department"),
+					resource.TestCheckResourceAttrSet(name, "saas_app.0.idp_entity_id"),
+					resource.TestCheckResourceAttrSet(name, "saas_app.0.sso_endpoint"),
+					resource.TestCheckResourceAttrSet(name, "saas_app.0.public_key"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestAccessSaasAppRoundTrip(t *testing.T) {
+	app := &accessSaasApp{
+		SPEntityID:         "terraform.example.com",
+		ConsumerServiceURL: "https://terraform.example.com/saml/consume",
+		NameIDFormat:       "id",
+		CustomAttributes: []accessSaasAttribute{{
+			Name:       "department",
+			NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic",
+			Source:     accessSaasAttributeSource{Name: "department"},
+		}},
+	}
+
+	flattened := flattenAccessSaasApp(app)[0].(map[string]interface{})
+	if got := expandAccessSaasApp(flattened); !reflect.DeepEqual(got, app) {
+		t.Fatalf("expected %#v, got %#v", app, got)
+	}
+}
+
+func TestCheckAccessApplication(t *testing.T) {
+	saas := &accessSaasApp{SPEntityID: "terraform.example.com"}
+
+	cases := []struct {
+		app   accessApplication
+		valid bool
+	}{
+		{accessApplication{Name: "a", Type: "saas", SaasApp: saas}, true},
+		{accessApplication{Name: "b", Type: "saas"}, false},
+		{accessApplication{Name: "c", Type: "self_hosted", Domain: "c.example.com"}, true},
+		{accessApplication{Name: "d", Type: "self_hosted"}, false},
+		{accessApplication{Name: "e", Type: "self_hosted", Domain: "e.example.com", SaasApp: saas}, false},
+	}
+
+	for _, c := range cases {
+		err := checkAccessApplication(c.app)
+		if c.valid && err != nil {
+			t.Fatalf("%s should be valid: %s", c.app.Name, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%s should not be valid", c.app.Name)
+		}
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustAccessApplicationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_access_application" {
+			continue
+		}
+
+		uri := accessApplicationsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Access application still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustAccessApplicationConfigSaaS = `
+resource "cloudflare_zero_trust_access_application" "foobar" {
+	account_id = "%s"
+	name = "terraform"
+	type = "saas"
+
+	saas_app {
+		sp_entity_id = "terraform.example.com"
+		consumer_service_url = "https://terraform.example.com/saml/consume"
+
+		custom_attribute {
+			name = "department"
+			source = "department"
+		}
+	}
+}`
@@ -0,0 +1,152 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessCustomPage replaces a page Access shows users, such as the one shown
+// when they are denied access.
+type accessCustomPage struct {
+	UID        string `json:"uid,omitempty"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	CustomHTML string `json:"custom_html,omitempty"`
+	AppCount   int    `json:"app_count,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustAccessCustomPage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessCustomPageCreate,
+		Read:   resourceCloudFlareZeroTrustAccessCustomPageRead,
+		Update: resourceCloudFlareZeroTrustAccessCustomPageUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessCustomPageDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAccessCustomPageType,
+			},
+
+			"custom_html": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"app_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"uid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessCustomPageCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	page := accessCustomPageFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Access Custom Page create configuration: %#v", page)
+
+	var created accessCustomPage
+	if err := client.apiRequest("POST", accessCustomPagesURI(accountID), page, &created); err != nil {
+		return fmt.Errorf("Error creating Access custom page for account %q: %s", accountID, err)
+	}
+
+	if created.UID == "" {
+		return fmt.Errorf("Failed to find Access custom page in create response; UID was empty")
+	}
+
+	d.SetId(created.UID)
+
+	log.Printf("[INFO] CloudFlare Access Custom Page ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustAccessCustomPageRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessCustomPageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var page accessCustomPage
+	err := client.apiRequest("GET", accessCustomPagesURI(accountID)+"/"+d.Id(), nil, &page)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access custom page %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access custom page %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", page.Name)
+	d.Set("type", page.Type)
+	d.Set("custom_html", page.CustomHTML)
+	d.Set("app_count", page.AppCount)
+	d.Set("uid", page.UID)
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessCustomPageUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	page := accessCustomPageFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Access Custom Page update configuration: %#v", page)
+
+	if err := client.apiRequest("PUT", accessCustomPagesURI(accountID)+"/"+d.Id(), page, nil); err != nil {
+		return fmt.Errorf("Error updating Access custom page %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustAccessCustomPageRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessCustomPageDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Access Custom Page: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", accessCustomPagesURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Access custom page %q: %s", d.Id(), err)
+}
+
+func accessCustomPagesURI(accountID string) string {
+	return "/accounts/" + accountID + "/access/custom_pages"
+}
+
+func accessCustomPageFromResourceData(d *schema.ResourceData) accessCustomPage {
+	return accessCustomPage{
+		Name:       d.Get("name").(string),
+		Type:       d.Get("type").(string),
+		CustomHTML: d.Get("custom_html").(string),
+		AppCount:   d.Get("app_count").(int),
+	}
+}
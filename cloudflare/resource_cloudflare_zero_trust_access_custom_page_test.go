@@ -0,0 +1,72 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessCustomPage_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_access_custom_page.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustAccessCustomPageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessCustomPageConfig, accountID, "<html><body><h1>Access denied</h1></body></html>"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform"),
+					resource.TestCheckResourceAttr(name, "type", "forbidden"),
+					resource.TestCheckResourceAttr(name, "custom_html", "<html><body><h1>Access denied</h1></body></html>"),
+					resource.TestCheckResourceAttrSet(name, "uid"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessCustomPageConfig, accountID, "<html><body><h1>Ask IT for access</h1></body></html>"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "custom_html", "<html><body><h1>Ask IT for access</h1></body></html>"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareZeroTrustAccessCustomPageDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_access_custom_page" {
+			continue
+		}
+
+		uri := accessCustomPagesURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Access custom page still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustAccessCustomPageConfig = `
+resource "cloudflare_zero_trust_access_custom_page" "foobar" {
+	account_id = "%s"
+	name = "terraform"
+	type = "forbidden"
+	custom_html = "%s"
+}`
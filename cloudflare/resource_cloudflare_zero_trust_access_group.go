@@ -0,0 +1,167 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessGroup is a reusable set of Access rules. Policies refer to the users
+// it matches with a group rule.
+type accessGroup struct {
+	ID      string       `json:"id,omitempty"`
+	Name    string       `json:"name"`
+	Include []accessRule `json:"include"`
+	Exclude []accessRule `json:"exclude"`
+	Require []accessRule `json:"require"`
+}
+
+func resourceCloudFlareZeroTrustAccessGroup() *schema.Resource {
+	// A group has no members unless it includes someone.
+	include := accessRulesSchema()
+	include.Optional = false
+	include.Required = true
+
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessGroupCreate,
+		Read:   resourceCloudFlareZeroTrustAccessGroupRead,
+		Update: resourceCloudFlareZeroTrustAccessGroupUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccessScopedObject,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone_id"},
+			},
+
+			"zone_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"account_id"},
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"include": include,
+
+			"exclude": accessRulesSchema(),
+
+			"require": accessRulesSchema(),
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/groups")
+	if err != nil {
+		return err
+	}
+
+	group := accessGroupFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Access Group create configuration: %#v", group)
+
+	var created accessGroup
+	if err := client.apiRequest("POST", uri, group, &created); err != nil {
+		return fmt.Errorf("Error creating Access group %q: %s", group.Name, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find Access group in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Access Group ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustAccessGroupRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/groups/"+d.Id())
+	if err != nil {
+		return err
+	}
+
+	var group accessGroup
+	err = client.apiRequest("GET", uri, nil, &group)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access group %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access group %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", group.Name)
+
+	if err := d.Set("include", flattenAccessRules(group.Include)); err != nil {
+		return fmt.Errorf("Error setting include: %s", err)
+	}
+	if err := d.Set("exclude", flattenAccessRules(group.Exclude)); err != nil {
+		return fmt.Errorf("Error setting exclude: %s", err)
+	}
+	if err := d.Set("require", flattenAccessRules(group.Require)); err != nil {
+		return fmt.Errorf("Error setting require: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/groups/"+d.Id())
+	if err != nil {
+		return err
+	}
+
+	group := accessGroupFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Access Group update configuration: %#v", group)
+
+	if err := client.apiRequest("PUT", uri, group, nil); err != nil {
+		return fmt.Errorf("Error updating Access group %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustAccessGroupRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/groups/"+d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting CloudFlare Access Group: %s", d.Id())
+
+	err = client.apiRequest("DELETE", uri, nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Access group %q: %s", d.Id(), err)
+}
+
+func accessGroupFromResourceData(d *schema.ResourceData) accessGroup {
+	return accessGroup{
+		Name:    d.Get("name").(string),
+		Include: expandAccessRules(d.Get("include")),
+		Exclude: expandAccessRules(d.Get("exclude")),
+		Require: expandAccessRules(d.Get("require")),
+	}
+}
@@ -0,0 +1,71 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessGroup_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_access_group.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustAccessGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessGroupConfig, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "include.0.email_domain.0", "example.com"),
+					resource.TestCheckResourceAttr(name, "exclude.0.email.0", "contractor@example.com"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: "account/" + accountID + "/",
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareZeroTrustAccessGroupDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_access_group" {
+			continue
+		}
+
+		uri := "/accounts/" + rs.Primary.Attributes["account_id"] + "/access/groups/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Access group still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustAccessGroupConfig = `
+resource "cloudflare_zero_trust_access_group" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+
+	include {
+		email_domain = ["example.com"]
+	}
+
+	exclude {
+		email = ["contractor@example.com"]
+	}
+}`
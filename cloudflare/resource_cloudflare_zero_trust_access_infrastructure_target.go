@@ -0,0 +1,226 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// infrastructureTarget is a server that Access for Infrastructure controls
+// SSH and other access to, addressed by IP within a virtual network.
+type infrastructureTarget struct {
+	ID       string                 `json:"id,omitempty"`
+	Hostname string                 `json:"hostname"`
+	IP       infrastructureTargetIP `json:"ip"`
+}
+
+type infrastructureTargetIP struct {
+	IPv4 *infrastructureTargetAddress `json:"ipv4,omitempty"`
+	IPv6 *infrastructureTargetAddress `json:"ipv6,omitempty"`
+}
+
+type infrastructureTargetAddress struct {
+	IPAddr           string `json:"ip_addr"`
+	VirtualNetworkID string `json:"virtual_network_id,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustAccessInfrastructureTarget() *schema.Resource {
+	address := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip_addr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateIPAddress,
+			},
+			"virtual_network_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessInfrastructureTargetCreate,
+		Read:   resourceCloudFlareZeroTrustAccessInfrastructureTargetRead,
+		Update: resourceCloudFlareZeroTrustAccessInfrastructureTargetUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessInfrastructureTargetDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ip": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipv4": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     address,
+						},
+						"ipv6": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     address,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessInfrastructureTargetCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	target := infrastructureTargetFromResourceData(d)
+	if err := checkInfrastructureTarget(target); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Infrastructure Target create configuration: %#v", target)
+
+	var created infrastructureTarget
+	if err := client.apiRequest("POST", infrastructureTargetsURI(accountID), target, &created); err != nil {
+		return fmt.Errorf("Error creating infrastructure target %q for account %q: %s", target.Hostname, accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find infrastructure target in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Infrastructure Target ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustAccessInfrastructureTargetRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessInfrastructureTargetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var target infrastructureTarget
+	err := client.apiRequest("GET", infrastructureTargetsURI(accountID)+"/"+d.Id(), nil, &target)
+	if isNotFound(err) {
+		log.Printf("[INFO] Infrastructure target %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding infrastructure target %q: %s", d.Id(), err)
+	}
+
+	d.Set("hostname", target.Hostname)
+	if err := d.Set("ip", flattenInfrastructureTargetIP(target.IP)); err != nil {
+		return fmt.Errorf("Error setting ip: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessInfrastructureTargetUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	target := infrastructureTargetFromResourceData(d)
+	if err := checkInfrastructureTarget(target); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Infrastructure Target update configuration: %#v", target)
+
+	if err := client.apiRequest("PUT", infrastructureTargetsURI(accountID)+"/"+d.Id(), target, nil); err != nil {
+		return fmt.Errorf("Error updating infrastructure target %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustAccessInfrastructureTargetRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessInfrastructureTargetDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Infrastructure Target: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", infrastructureTargetsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting infrastructure target %q: %s", d.Id(), err)
+}
+
+func infrastructureTargetsURI(accountID string) string {
+	return "/accounts/" + accountID + "/infrastructure/targets"
+}
+
+// checkInfrastructureTarget ensures that the target has an address, which
+// the schema can't require as either ipv4 or ipv6 will do.
+func checkInfrastructureTarget(target infrastructureTarget) error {
+	if target.IP.IPv4 == nil && target.IP.IPv6 == nil {
+		return fmt.Errorf("Infrastructure target %q requires an ipv4 or ipv6 block", target.Hostname)
+	}
+	return nil
+}
+
+func infrastructureTargetFromResourceData(d *schema.ResourceData) infrastructureTarget {
+	target := infrastructureTarget{
+		Hostname: d.Get("hostname").(string),
+	}
+
+	// An empty ip block is read as nil rather than an empty map.
+	if ip, ok := d.Get("ip").([]interface{})[0].(map[string]interface{}); ok {
+		target.IP.IPv4 = expandInfrastructureTargetAddress(ip["ipv4"].([]interface{}))
+		target.IP.IPv6 = expandInfrastructureTargetAddress(ip["ipv6"].([]interface{}))
+	}
+
+	return target
+}
+
+func expandInfrastructureTargetAddress(l []interface{}) *infrastructureTargetAddress {
+	if len(l) == 0 {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	return &infrastructureTargetAddress{
+		IPAddr:           m["ip_addr"].(string),
+		VirtualNetworkID: m["virtual_network_id"].(string),
+	}
+}
+
+func flattenInfrastructureTargetIP(ip infrastructureTargetIP) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"ipv4": flattenInfrastructureTargetAddress(ip.IPv4),
+		"ipv6": flattenInfrastructureTargetAddress(ip.IPv6),
+	}}
+}
+
+func flattenInfrastructureTargetAddress(address *infrastructureTargetAddress) []interface{} {
+	if address == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"ip_addr":            address.IPAddr,
+		"virtual_network_id": address.VirtualNetworkID,
+	}}
+}
@@ -0,0 +1,117 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessInfrastructureTarget_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_access_infrastructure_target.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustAccessInfrastructureTargetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessInfrastructureTargetConfigIPv4, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "hostname", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "ip.0.ipv4.0.ip_addr", "10.251.0.10"),
+					resource.TestCheckResourceAttrSet(name, "ip.0.ipv4.0.virtual_network_id"),
+					resource.TestCheckResourceAttr(name, "ip.0.ipv6.#", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessInfrastructureTargetConfigDualStack, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "ip.0.ipv4.0.ip_addr", "10.251.0.10"),
+					resource.TestCheckResourceAttr(name, "ip.0.ipv6.0.ip_addr", "fd00::10"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestCheckInfrastructureTarget(t *testing.T) {
+	address := &infrastructureTargetAddress{IPAddr: "10.251.0.10"}
+
+	cases := []struct {
+		target infrastructureTarget
+		valid  bool
+	}{
+		{infrastructureTarget{Hostname: "a", IP: infrastructureTargetIP{IPv4: address}}, true},
+		{infrastructureTarget{Hostname: "b", IP: infrastructureTargetIP{IPv6: address}}, true},
+		{infrastructureTarget{Hostname: "c", IP: infrastructureTargetIP{IPv4: address, IPv6: address}}, true},
+		{infrastructureTarget{Hostname: "d"}, false},
+	}
+
+	for _, c := range cases {
+		err := checkInfrastructureTarget(c.target)
+		if c.valid && err != nil {
+			t.Fatalf("%s should be valid: %s", c.target.Hostname, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%s should not be valid", c.target.Hostname)
+		}
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustAccessInfrastructureTargetDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_access_infrastructure_target" {
+			continue
+		}
+
+		uri := infrastructureTargetsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Infrastructure target still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustAccessInfrastructureTargetConfigIPv4 = `
+resource "cloudflare_zero_trust_access_infrastructure_target" "foobar" {
+	account_id = "%s"
+	hostname = "terraform-acctest"
+
+	ip {
+		ipv4 {
+			ip_addr = "10.251.0.10"
+		}
+	}
+}`
+
+const testAccCheckCloudFlareZeroTrustAccessInfrastructureTargetConfigDualStack = `
+resource "cloudflare_zero_trust_access_infrastructure_target" "foobar" {
+	account_id = "%s"
+	hostname = "terraform-acctest"
+
+	ip {
+		ipv4 {
+			ip_addr = "10.251.0.10"
+		}
+
+		ipv6 {
+			ip_addr = "fd00::10"
+		}
+	}
+}`
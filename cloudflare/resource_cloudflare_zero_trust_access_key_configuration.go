@@ -0,0 +1,139 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessKeysConfig is how often Access rotates the keys it signs the JWTs of
+// an account's applications with.
+type accessKeysConfig struct {
+	KeyRotationIntervalDays int    `json:"key_rotation_interval_days"`
+	LastKeyRotationAt       string `json:"last_key_rotation_at,omitempty"`
+	DaysUntilNextRotation   int    `json:"days_until_next_rotation,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustAccessKeyConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessKeyConfigurationCreate,
+		Read:   resourceCloudFlareZeroTrustAccessKeyConfigurationRead,
+		Update: resourceCloudFlareZeroTrustAccessKeyConfigurationUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessKeyConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"key_rotation_interval_days": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"rotate": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"last_key_rotation_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"days_until_next_rotation": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessKeyConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	accountID := d.Get("account_id").(string)
+
+	if err := updateAccessKeysConfig(d, meta.(*CloudFlareClient)); err != nil {
+		return err
+	}
+
+	d.SetId(accountID)
+
+	return resourceCloudFlareZeroTrustAccessKeyConfigurationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessKeyConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	var config accessKeysConfig
+	err := client.apiRequest("GET", accessKeysURI(accountID), nil, &config)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access key configuration for account %s not found", accountID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access key configuration for account %q: %s", accountID, err)
+	}
+
+	d.Set("account_id", accountID)
+	d.Set("key_rotation_interval_days", config.KeyRotationIntervalDays)
+	d.Set("last_key_rotation_at", config.LastKeyRotationAt)
+	d.Set("days_until_next_rotation", config.DaysUntilNextRotation)
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessKeyConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	if d.HasChange("key_rotation_interval_days") {
+		if err := updateAccessKeysConfig(d, client); err != nil {
+			return err
+		}
+	}
+
+	// rotate is only a trigger: any change to it rotates the keys.
+	if d.HasChange("rotate") {
+		log.Printf("[INFO] Rotating CloudFlare Access keys for account %s", accountID)
+
+		if err := client.apiRequest("POST", accessKeysURI(accountID)+"/rotate", nil, nil); err != nil {
+			return fmt.Errorf("Error rotating Access keys for account %q: %s", accountID, err)
+		}
+	}
+
+	return resourceCloudFlareZeroTrustAccessKeyConfigurationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessKeyConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	// Every account has a key configuration, so there is nothing to delete.
+	// Its keys keep being rotated at the last configured interval.
+	log.Printf("[INFO] Removing Access key configuration for account %s from state", d.Id())
+
+	return nil
+}
+
+func updateAccessKeysConfig(d *schema.ResourceData, client *CloudFlareClient) error {
+	accountID := d.Get("account_id").(string)
+	config := accessKeysConfig{
+		KeyRotationIntervalDays: d.Get("key_rotation_interval_days").(int),
+	}
+
+	log.Printf("[DEBUG] CloudFlare Access key configuration for account %s: %#v", accountID, config)
+
+	if err := client.apiRequest("PUT", accessKeysURI(accountID), config, nil); err != nil {
+		return fmt.Errorf("Error updating Access key configuration for account %q: %s", accountID, err)
+	}
+	return nil
+}
+
+func accessKeysURI(accountID string) string {
+	return "/accounts/" + accountID + "/access/keys"
+}
@@ -0,0 +1,85 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessKeyConfiguration_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_access_key_configuration.foobar"
+	var lastRotation string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessKeyConfigurationConfig, accountID, 60, "initial"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key_rotation_interval_days", "60"),
+					testAccCheckCloudFlareAccessKeyRotation(name, &lastRotation, false),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessKeyConfigurationConfig, accountID, 90, "initial"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key_rotation_interval_days", "90"),
+					testAccCheckCloudFlareAccessKeyRotation(name, &lastRotation, false),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessKeyConfigurationConfig, accountID, 90, "rotated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudFlareAccessKeyRotation(name, &lastRotation, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotate"},
+			},
+		},
+	})
+}
+
+// testAccCheckCloudFlareAccessKeyRotation checks whether the keys were
+// rotated since the last time it was called.
+func testAccCheckCloudFlareAccessKeyRotation(n string, lastRotation *string, rotated bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		last := rs.Primary.Attributes["last_key_rotation_at"]
+		previous := *lastRotation
+		*lastRotation = last
+
+		if previous == "" {
+			return nil
+		}
+		if rotated && last == previous {
+			return fmt.Errorf("Expected the keys to be rotated, but they were last rotated at %s", last)
+		}
+		if !rotated && last != previous {
+			return fmt.Errorf("Expected the keys not to be rotated, but they were rotated at %s", last)
+		}
+		return nil
+	}
+}
+
+const testAccCheckCloudFlareZeroTrustAccessKeyConfigurationConfig = `
+resource "cloudflare_zero_trust_access_key_configuration" "foobar" {
+	account_id = "%s"
+	key_rotation_interval_days = %d
+	rotate = "%s"
+}`
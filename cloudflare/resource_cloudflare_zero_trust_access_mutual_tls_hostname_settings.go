@@ -0,0 +1,222 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessMutualTLSHostnameSettings are the mTLS settings of the hostnames of
+// an account or zone. The API replaces them all at once.
+type accessMutualTLSHostnameSettings struct {
+	Settings []accessMutualTLSHostnameSetting `json:"settings"`
+}
+
+type accessMutualTLSHostnameSetting struct {
+	Hostname                    string `json:"hostname"`
+	ChinaNetwork                bool   `json:"china_network"`
+	ClientCertificateForwarding bool   `json:"client_certificate_forwarding"`
+}
+
+func resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettingsUpdate,
+		Read:   resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettingsRead,
+		Update: resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettingsUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccessScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone_id"},
+			},
+
+			"zone_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"account_id"},
+			},
+
+			"settings": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"china_network": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"client_certificate_forwarding": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessMutualTLSHostnameSettingsURI(d)
+	if err != nil {
+		return err
+	}
+
+	var settings []accessMutualTLSHostnameSetting
+	err = client.apiRequest("GET", uri, nil, &settings)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access mTLS hostname settings %s no longer exist", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access mTLS hostname settings %q: %s", d.Id(), err)
+	}
+
+	flattened := make([]interface{}, 0, len(settings))
+	for _, setting := range settings {
+		flattened = append(flattened, map[string]interface{}{
+			"hostname":                      setting.Hostname,
+			"china_network":                 setting.ChinaNetwork,
+			"client_certificate_forwarding": setting.ClientCertificateForwarding,
+		})
+	}
+	if err := d.Set("settings", flattened); err != nil {
+		return fmt.Errorf("Error setting settings: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessMutualTLSHostnameSettingsURI(d)
+	if err != nil {
+		return err
+	}
+
+	settings := accessMutualTLSHostnameSettings{Settings: []accessMutualTLSHostnameSetting{}}
+	for _, v := range d.Get("settings").(*schema.Set).List() {
+		setting := v.(map[string]interface{})
+		settings.Settings = append(settings.Settings, accessMutualTLSHostnameSetting{
+			Hostname:                    setting["hostname"].(string),
+			ChinaNetwork:                setting["china_network"].(bool),
+			ClientCertificateForwarding: setting["client_certificate_forwarding"].(bool),
+		})
+	}
+
+	log.Printf("[DEBUG] CloudFlare Access mTLS Hostname Settings update configuration: %#v", settings)
+
+	if err := client.apiRequest("PUT", uri, settings, nil); err != nil {
+		return fmt.Errorf("Error updating Access mTLS hostname settings: %s", err)
+	}
+
+	if accountID, ok := d.GetOk("account_id"); ok {
+		d.SetId(accountID.(string))
+	} else {
+		d.SetId(d.Get("zone_id").(string))
+	}
+
+	return resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettingsRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessMutualTLSHostnameSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessMutualTLSHostnameSettingsURI(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Removing CloudFlare Access mTLS Hostname Settings: %s", d.Id())
+
+	empty := accessMutualTLSHostnameSettings{Settings: []accessMutualTLSHostnameSetting{}}
+	err = client.apiRequest("PUT", uri, empty, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error removing Access mTLS hostname settings %q: %s", d.Id(), err)
+}
+
+// importAccessScopedResource imports the Access settings of an account,
+// "account/account_id", or of a zone, "zone/zone_id".
+func importAccessScopedResource(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	scope, id, err := parseAccessScopeImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set(scope, id)
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// importAccessScopedObject imports an Access object, such as a group, of an
+// account, "account/account_id/id", or of a zone, "zone/zone_id/id".
+func importAccessScopedObject(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	scope, id, err := parseAccessScopeImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := strings.SplitN(id, "/", 2)
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return nil, fmt.Errorf("expecting account/account_id/id or zone/zone_id/id, got %q", d.Id())
+	}
+
+	d.Set(scope, tokens[0])
+	d.SetId(tokens[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseAccessScopeImportID parses the ID of an Access resource that belongs
+// to either an account or a zone, returning the attribute holding the scope
+// and its ID.
+func parseAccessScopeImportID(importID string) (string, string, error) {
+	tokens := strings.SplitN(importID, "/", 2)
+	if len(tokens) == 2 && tokens[1] != "" {
+		switch tokens[0] {
+		case "account":
+			return "account_id", tokens[1], nil
+		case "zone":
+			return "zone_id", tokens[1], nil
+		}
+	}
+	return "", "", fmt.Errorf("expecting account/account_id or zone/zone_id, got %q", importID)
+}
+
+// accessMutualTLSHostnameSettingsURI is the settings of the account or zone
+// the resource belongs to.
+func accessMutualTLSHostnameSettingsURI(d *schema.ResourceData) (string, error) {
+	return accessScopedURI(d, "/access/certificates/settings")
+}
+
+// accessScopedURI is path below the account or zone an Access resource
+// belongs to, whichever of account_id and zone_id is set.
+func accessScopedURI(d *schema.ResourceData, path string) (string, error) {
+	if accountID, ok := d.GetOk("account_id"); ok {
+		return "/accounts/" + accountID.(string) + path, nil
+	}
+	if zoneID, ok := d.GetOk("zone_id"); ok {
+		return "/zones/" + zoneID.(string) + path, nil
+	}
+	return "", fmt.Errorf("One of account_id or zone_id must be set")
+}
@@ -0,0 +1,71 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareZeroTrustAccessMutualTLSHostnameSettings_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "cloudflare_zero_trust_access_mutual_tls_hostname_settings.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessMutualTLSHostnameSettingsConfig, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone_id", zoneID),
+					resource.TestCheckResourceAttr(name, "settings.#", "1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: "zone/",
+			},
+		},
+	})
+}
+
+func TestParseAccessScopeImportID(t *testing.T) {
+	cases := []struct {
+		importID, scope, id string
+		valid               bool
+	}{
+		{"account/1d5fdc9e88c8a8c4518b068cd94331fe", "account_id", "1d5fdc9e88c8a8c4518b068cd94331fe", true},
+		{"zone/023e105f4ecef8ad9ca31a8372d0c353", "zone_id", "023e105f4ecef8ad9ca31a8372d0c353", true},
+		{"1d5fdc9e88c8a8c4518b068cd94331fe", "", "", false},
+		{"accounts/1d5fdc9e88c8a8c4518b068cd94331fe", "", "", false},
+		{"zone/", "", "", false},
+	}
+
+	for _, c := range cases {
+		scope, id, err := parseAccessScopeImportID(c.importID)
+		if c.valid != (err == nil) {
+			t.Fatalf("%s: unexpected result %v", c.importID, err)
+		}
+		if scope != c.scope || id != c.id {
+			t.Fatalf("%s: expected %s %s, got %s %s", c.importID, c.scope, c.id, scope, id)
+		}
+	}
+}
+
+const testAccCheckCloudFlareZeroTrustAccessMutualTLSHostnameSettingsConfig = `
+resource "cloudflare_zero_trust_access_mutual_tls_hostname_settings" "foobar" {
+	zone_id = "%s"
+
+	settings {
+		hostname = "mtls.%s"
+		client_certificate_forwarding = true
+	}
+}`
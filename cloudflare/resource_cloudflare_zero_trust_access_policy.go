@@ -0,0 +1,164 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessPolicy is a reusable Access policy. It belongs to the account rather
+// than to an application, and applications reference it by ID.
+type accessPolicy struct {
+	ID              string       `json:"id,omitempty"`
+	Name            string       `json:"name"`
+	Decision        string       `json:"decision"`
+	SessionDuration string       `json:"session_duration,omitempty"`
+	Include         []accessRule `json:"include"`
+	Exclude         []accessRule `json:"exclude"`
+	Require         []accessRule `json:"require"`
+}
+
+func resourceCloudFlareZeroTrustAccessPolicy() *schema.Resource {
+	// A policy applies to no one unless it includes someone.
+	include := accessRulesSchema()
+	include.Optional = false
+	include.Required = true
+
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessPolicyCreate,
+		Read:   resourceCloudFlareZeroTrustAccessPolicyRead,
+		Update: resourceCloudFlareZeroTrustAccessPolicyUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"decision": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAccessPolicyDecision,
+			},
+
+			"session_duration": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"include": include,
+
+			"exclude": accessRulesSchema(),
+
+			"require": accessRulesSchema(),
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	policy := accessPolicyFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Access Policy create configuration: %#v", policy)
+
+	var created accessPolicy
+	if err := client.apiRequest("POST", accessPoliciesURI(accountID), policy, &created); err != nil {
+		return fmt.Errorf("Error creating Access policy for account %q: %s", accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find Access policy in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Access Policy ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustAccessPolicyRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var policy accessPolicy
+	err := client.apiRequest("GET", accessPoliciesURI(accountID)+"/"+d.Id(), nil, &policy)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access policy %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access policy %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", policy.Name)
+	d.Set("decision", policy.Decision)
+	d.Set("session_duration", policy.SessionDuration)
+
+	if err := d.Set("include", flattenAccessRules(policy.Include)); err != nil {
+		return fmt.Errorf("Error setting include: %s", err)
+	}
+	if err := d.Set("exclude", flattenAccessRules(policy.Exclude)); err != nil {
+		return fmt.Errorf("Error setting exclude: %s", err)
+	}
+	if err := d.Set("require", flattenAccessRules(policy.Require)); err != nil {
+		return fmt.Errorf("Error setting require: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	policy := accessPolicyFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Access Policy update configuration: %#v", policy)
+
+	if err := client.apiRequest("PUT", accessPoliciesURI(accountID)+"/"+d.Id(), policy, nil); err != nil {
+		return fmt.Errorf("Error updating Access policy %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustAccessPolicyRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Access Policy: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", accessPoliciesURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Access policy %q: %s", d.Id(), err)
+}
+
+func accessPoliciesURI(accountID string) string {
+	return "/accounts/" + accountID + "/access/policies"
+}
+
+func accessPolicyFromResourceData(d *schema.ResourceData) accessPolicy {
+	return accessPolicy{
+		Name:            d.Get("name").(string),
+		Decision:        d.Get("decision").(string),
+		SessionDuration: d.Get("session_duration").(string),
+		Include:         expandAccessRules(d.Get("include")),
+		Exclude:         expandAccessRules(d.Get("exclude")),
+		Require:         expandAccessRules(d.Get("require")),
+	}
+}
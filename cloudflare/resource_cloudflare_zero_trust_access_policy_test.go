@@ -0,0 +1,116 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessPolicy_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_access_policy.foobar"
+	appName := "cloudflare_zero_trust_access_application.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckCloudFlareZeroTrustAccessApplicationDestroy,
+			testAccCheckCloudFlareZeroTrustAccessPolicyDestroy,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessPolicyConfig, accountID, accountID, os.Getenv("CLOUDFLARE_DOMAIN")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "decision", "allow"),
+					resource.TestCheckResourceAttr(name, "include.0.email_domain.0", "example.com"),
+					resource.TestCheckResourceAttr(name, "exclude.0.ip.0", "192.0.2.0/24"),
+					resource.TestCheckResourceAttr(appName, "policies.#", "1"),
+					resource.TestCheckResourceAttrPair(appName, "policies.0", name, "id"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestAccessRulesRoundTrip(t *testing.T) {
+	rules := []accessRule{
+		{"everyone": map[string]interface{}{}},
+		{"email": map[string]interface{}{"email": "test@example.com"}},
+		{"email_domain": map[string]interface{}{"domain": "example.com"}},
+		{"ip": map[string]interface{}{"ip": "192.0.2.0/24"}},
+		{"group": map[string]interface{}{"id": "aa0a4aab-672b-4bdb-bc33-a59f1130a11f"}},
+		{"geo": map[string]interface{}{"country_code": "GB"}},
+	}
+
+	if got := expandAccessRules(flattenAccessRules(rules)); !reflect.DeepEqual(got, rules) {
+		t.Fatalf("expected %#v, got %#v", rules, got)
+	}
+}
+
+func TestExpandAccessAppPolicies(t *testing.T) {
+	policies := expandAccessAppPolicies([]interface{}{"b", "a"})
+	expected := []accessAppPolicy{{ID: "b", Precedence: 1}, {ID: "a", Precedence: 2}}
+	if !reflect.DeepEqual(policies, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, policies)
+	}
+
+	// The API may return policies in any order.
+	reversed := []accessAppPolicy{expected[1], expected[0]}
+	if got := flattenAccessAppPolicies(reversed); !reflect.DeepEqual(got, []interface{}{"b", "a"}) {
+		t.Fatalf("expected policies in order of precedence, got %#v", got)
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustAccessPolicyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_access_policy" {
+			continue
+		}
+
+		uri := accessPoliciesURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Access policy still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustAccessPolicyConfig = `
+resource "cloudflare_zero_trust_access_policy" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	decision = "allow"
+
+	include {
+		email_domain = ["example.com"]
+	}
+
+	exclude {
+		ip = ["192.0.2.0/24"]
+	}
+}
+
+resource "cloudflare_zero_trust_access_application" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	domain = "terraform-acctest.%s"
+	policies = ["${cloudflare_zero_trust_access_policy.foobar.id}"]
+}`
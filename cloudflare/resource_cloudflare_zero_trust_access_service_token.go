@@ -0,0 +1,222 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessServiceToken authenticates automated clients, rather than users, to
+// Access applications. Its secret is only returned when it is created.
+type accessServiceToken struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Duration     string `json:"duration,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	ExpiresAt    string `json:"expires_at,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustAccessServiceToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessServiceTokenCreate,
+		Read:   resourceCloudFlareZeroTrustAccessServiceTokenRead,
+		Update: resourceCloudFlareZeroTrustAccessServiceTokenUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessServiceTokenDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccessScopedObject,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone_id"},
+			},
+
+			"zone_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"account_id"},
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"duration": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAccessServiceTokenDuration,
+			},
+
+			"min_days_for_renewal": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+
+			// ready_for_renewal is set by Read once the token is within
+			// min_days_for_renewal of expiring. It differs from its default
+			// in the configuration from then on, which replaces the token.
+			"ready_for_renewal": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+
+			"client_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"client_secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessServiceTokenCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	if d.Get("ready_for_renewal").(bool) {
+		return fmt.Errorf("ready_for_renewal is set by the provider and can't be configured")
+	}
+
+	uri, err := accessScopedURI(d, "/access/service_tokens")
+	if err != nil {
+		return err
+	}
+
+	token := accessServiceToken{
+		Name:     d.Get("name").(string),
+		Duration: d.Get("duration").(string),
+	}
+	log.Printf("[DEBUG] CloudFlare Access Service Token create configuration: %#v", token)
+
+	var created accessServiceToken
+	if err := client.apiRequest("POST", uri, token, &created); err != nil {
+		return fmt.Errorf("Error creating Access service token %q: %s", token.Name, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find Access service token in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+	d.Set("client_secret", created.ClientSecret)
+
+	log.Printf("[INFO] CloudFlare Access Service Token ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustAccessServiceTokenRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessServiceTokenRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/service_tokens/"+d.Id())
+	if err != nil {
+		return err
+	}
+
+	var token accessServiceToken
+	err = client.apiRequest("GET", uri, nil, &token)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access service token %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access service token %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", token.Name)
+	d.Set("duration", token.Duration)
+	d.Set("client_id", token.ClientID)
+	d.Set("expires_at", token.ExpiresAt)
+
+	renew, err := accessServiceTokenReadyForRenewal(token.ExpiresAt, d.Get("min_days_for_renewal").(int), time.Now())
+	if err != nil {
+		return fmt.Errorf("Error reading expiry of Access service token %q: %s", d.Id(), err)
+	}
+	if renew {
+		log.Printf("[INFO] Access service token %s expires at %s, within %d days, so it will be replaced",
+			d.Id(), token.ExpiresAt, d.Get("min_days_for_renewal").(int))
+	}
+	d.Set("ready_for_renewal", renew)
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustAccessServiceTokenUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	// Only min_days_for_renewal changed, which isn't sent to the API.
+	if !d.HasChange("name") && !d.HasChange("duration") {
+		return resourceCloudFlareZeroTrustAccessServiceTokenRead(d, meta)
+	}
+
+	uri, err := accessScopedURI(d, "/access/service_tokens/"+d.Id())
+	if err != nil {
+		return err
+	}
+
+	token := accessServiceToken{
+		Name:     d.Get("name").(string),
+		Duration: d.Get("duration").(string),
+	}
+	log.Printf("[DEBUG] CloudFlare Access Service Token update configuration: %#v", token)
+
+	if err := client.apiRequest("PUT", uri, token, nil); err != nil {
+		return fmt.Errorf("Error updating Access service token %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustAccessServiceTokenRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessServiceTokenDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/service_tokens/"+d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting CloudFlare Access Service Token: %s", d.Id())
+
+	err = client.apiRequest("DELETE", uri, nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Access service token %q: %s", d.Id(), err)
+}
+
+// accessServiceTokenReadyForRenewal reports whether a token expiring at
+// expiresAt is within minDays of expiring at now. Tokens without an expiry,
+// or with no minDays, are never renewed.
+func accessServiceTokenReadyForRenewal(expiresAt string, minDays int, now time.Time) (bool, error) {
+	if expiresAt == "" || minDays <= 0 {
+		return false, nil
+	}
+
+	expiry, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return now.Add(time.Duration(minDays) * 24 * time.Hour).After(expiry), nil
+}
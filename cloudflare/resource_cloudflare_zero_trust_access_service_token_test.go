@@ -0,0 +1,184 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessServiceToken_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_access_service_token.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustAccessServiceTokenDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessServiceTokenConfig, accountID, "8760h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "duration", "8760h"),
+					resource.TestCheckResourceAttr(name, "ready_for_renewal", "false"),
+					resource.TestCheckResourceAttrSet(name, "client_id"),
+					resource.TestCheckResourceAttrSet(name, "client_secret"),
+					resource.TestCheckResourceAttrSet(name, "expires_at"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     "account/" + accountID + "/",
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func TestCloudFlareZeroTrustAccessServiceTokenRead_Renewal(t *testing.T) {
+	expiresIn := 10 * 24 * time.Hour
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/1234567890/access/service_tokens/abc" || r.Method != "GET" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeTestResult(w, accessServiceToken{
+			ID:        "abc",
+			Name:      "ci",
+			Duration:  "8760h",
+			ClientID:  "abc.access",
+			ExpiresAt: time.Now().Add(expiresIn).UTC().Format(time.RFC3339),
+		})
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := map[string]struct {
+		MinDays int
+		Renew   bool
+	}{
+		"not configured":   {0, false},
+		"outside the days": {5, false},
+		"within the days":  {30, true},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareZeroTrustAccessServiceToken().Schema, map[string]interface{}{
+			"account_id":           "1234567890",
+			"name":                 "ci",
+			"min_days_for_renewal": tc.MinDays,
+		})
+		d.SetId("abc")
+
+		if err := resourceCloudFlareZeroTrustAccessServiceTokenRead(d, client); err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+		if d.Get("ready_for_renewal").(bool) != tc.Renew {
+			t.Fatalf("%s: expected ready_for_renewal %t, got %t", tn, tc.Renew, d.Get("ready_for_renewal"))
+		}
+	}
+}
+
+func TestCloudFlareZeroTrustAccessServiceTokenDiff_Renewal(t *testing.T) {
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"account_id":           "1234567890",
+		"name":                 "ci",
+		"min_days_for_renewal": 30,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, renew := range []bool{false, true} {
+		state := &terraform.InstanceState{
+			ID: "abc",
+			Attributes: map[string]string{
+				"account_id":           "1234567890",
+				"name":                 "ci",
+				"duration":             "8760h",
+				"min_days_for_renewal": "30",
+				"ready_for_renewal":    fmt.Sprintf("%t", renew),
+			},
+		}
+
+		diff, err := resourceCloudFlareZeroTrustAccessServiceToken().Diff(state, terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if replaced := diff != nil && diff.RequiresNew(); replaced != renew {
+			t.Fatalf("expected the token to be replaced %t when ready for renewal is %t, got %#v", renew, renew, diff)
+		}
+	}
+}
+
+func TestAccessServiceTokenReadyForRenewal(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		ExpiresAt string
+		MinDays   int
+		Renew     bool
+	}{
+		{"2018-01-31T00:00:00Z", 30, false},
+		{"2018-01-30T23:59:59Z", 30, true},
+		{"2017-12-31T00:00:00Z", 1, true},
+		{"2018-01-02T00:00:00Z", 0, false},
+		{"", 30, false},
+	}
+
+	for _, c := range cases {
+		renew, err := accessServiceTokenReadyForRenewal(c.ExpiresAt, c.MinDays, now)
+		if err != nil {
+			t.Fatalf("%q: err: %s", c.ExpiresAt, err)
+		}
+		if renew != c.Renew {
+			t.Fatalf("expected a token expiring at %q to be ready for renewal within %d days %t, got %t", c.ExpiresAt, c.MinDays, c.Renew, renew)
+		}
+	}
+
+	if _, err := accessServiceTokenReadyForRenewal("next year", 30, now); err == nil {
+		t.Fatalf("expected an error for an invalid expiry")
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustAccessServiceTokenDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_access_service_token" {
+			continue
+		}
+
+		uri := "/accounts/" + rs.Primary.Attributes["account_id"] + "/access/service_tokens/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Access service token still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustAccessServiceTokenConfig = `
+resource "cloudflare_zero_trust_access_service_token" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	duration = "%s"
+	min_days_for_renewal = 30
+}`
@@ -0,0 +1,134 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessTag labels Access applications. Tags are identified by their name.
+type accessTag struct {
+	Name     string `json:"name"`
+	AppCount int    `json:"app_count,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustAccessTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustAccessTagCreate,
+		Read:   resourceCloudFlareZeroTrustAccessTagRead,
+		Update: resourceCloudFlareZeroTrustAccessTagUpdate,
+		Delete: resourceCloudFlareZeroTrustAccessTagDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"app_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustAccessTagCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	tag := accessTag{Name: d.Get("name").(string)}
+	log.Printf("[DEBUG] CloudFlare Access Tag create configuration: %#v", tag)
+
+	var created accessTag
+	if err := client.apiRequest("POST", accessTagsURI(accountID), tag, &created); err != nil {
+		return fmt.Errorf("Error creating Access tag %q for account %q: %s", tag.Name, accountID, err)
+	}
+
+	if created.Name == "" {
+		return fmt.Errorf("Failed to find Access tag in create response; name was empty")
+	}
+
+	d.SetId(created.Name)
+
+	log.Printf("[INFO] CloudFlare Access Tag ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustAccessTagRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessTagRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var tag accessTag
+	err := client.apiRequest("GET", accessTagURI(accountID, d.Id()), nil, &tag)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access tag %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access tag %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", tag.Name)
+	d.Set("app_count", tag.AppCount)
+
+	return nil
+}
+
+// resourceCloudFlareZeroTrustAccessTagUpdate renames a tag. As tags are
+// identified by their name, the tag's ID changes with it.
+func resourceCloudFlareZeroTrustAccessTagUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	tag := accessTag{Name: d.Get("name").(string)}
+	log.Printf("[DEBUG] CloudFlare Access Tag update configuration: %#v", tag)
+
+	var updated accessTag
+	if err := client.apiRequest("PUT", accessTagURI(accountID, d.Id()), tag, &updated); err != nil {
+		return fmt.Errorf("Error updating Access tag %q: %s", d.Id(), err)
+	}
+
+	if updated.Name != "" {
+		d.SetId(updated.Name)
+	} else {
+		d.SetId(tag.Name)
+	}
+
+	return resourceCloudFlareZeroTrustAccessTagRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustAccessTagDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Access Tag: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", accessTagURI(accountID, d.Id()), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Access tag %q: %s", d.Id(), err)
+}
+
+func accessTagsURI(accountID string) string {
+	return "/accounts/" + accountID + "/access/tags"
+}
+
+// accessTagURI escapes the name of the tag, which may contain spaces.
+func accessTagURI(accountID, name string) string {
+	return accessTagsURI(accountID) + "/" + url.PathEscape(name)
+}
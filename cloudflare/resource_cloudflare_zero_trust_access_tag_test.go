@@ -0,0 +1,90 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustAccessTag_Application(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	name := "cloudflare_zero_trust_access_tag.foobar"
+	app := "cloudflare_zero_trust_access_application.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckCloudFlareZeroTrustAccessTagDestroy,
+			testAccCheckCloudFlareZeroTrustAccessApplicationDestroy,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessTagConfig, accountID, "terraform", accountID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform"),
+					resource.TestCheckResourceAttr(app, "tags.#", "1"),
+					resource.TestCheckResourceAttr(app, "tags.969816526", "terraform"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustAccessTagConfig, accountID, "terraform renamed", accountID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform renamed"),
+					resource.TestCheckResourceAttr(app, "tags.#", "1"),
+					resource.TestCheckResourceAttr(name, "app_count", "1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestAccessTagURI(t *testing.T) {
+	expected := "/accounts/1234/access/tags/terraform%20renamed"
+	if got := accessTagURI("1234", "terraform renamed"); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustAccessTagDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_access_tag" {
+			continue
+		}
+
+		uri := accessTagURI(rs.Primary.Attributes["account_id"], rs.Primary.ID)
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Access tag still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustAccessTagConfig = `
+resource "cloudflare_zero_trust_access_tag" "foobar" {
+	account_id = "%s"
+	name = "%s"
+}
+
+resource "cloudflare_zero_trust_access_application" "foobar" {
+	account_id = "%s"
+	name = "terraform"
+	domain = "terraform.%s"
+	tags = ["${cloudflare_zero_trust_access_tag.foobar.name}"]
+}`
@@ -0,0 +1,301 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// deviceSettingsPolicy is a WARP client profile. Every account has a default
+// profile; custom profiles apply to the devices their match expression
+// selects, in order of precedence.
+type deviceSettingsPolicy struct {
+	PolicyID            string             `json:"policy_id,omitempty"`
+	Name                string             `json:"name,omitempty"`
+	Description         string             `json:"description,omitempty"`
+	Match               string             `json:"match,omitempty"`
+	Precedence          int                `json:"precedence,omitempty"`
+	Enabled             *bool              `json:"enabled,omitempty"`
+	Default             bool               `json:"default,omitempty"`
+	AllowModeSwitch     bool               `json:"allow_mode_switch"`
+	AllowUpdates        bool               `json:"allow_updates"`
+	AutoConnect         int                `json:"auto_connect"`
+	CaptivePortal       int                `json:"captive_portal"`
+	DisableAutoFallback bool               `json:"disable_auto_fallback"`
+	SwitchLocked        bool               `json:"switch_locked"`
+	SupportURL          string             `json:"support_url"`
+	ServiceModeV2       *deviceServiceMode `json:"service_mode_v2,omitempty"`
+}
+
+type deviceServiceMode struct {
+	Mode string `json:"mode"`
+	Port int    `json:"port,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustDeviceCustomProfile() *schema.Resource {
+	s := deviceSettingsPolicySchema()
+	s["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["description"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	s["match"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["precedence"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Required: true,
+	}
+	s["enabled"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustDeviceCustomProfileCreate,
+		Read:   resourceCloudFlareZeroTrustDeviceCustomProfileRead,
+		Update: resourceCloudFlareZeroTrustDeviceCustomProfileUpdate,
+		Delete: resourceCloudFlareZeroTrustDeviceCustomProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: s,
+	}
+}
+
+// deviceSettingsPolicySchema is the schema of the settings shared by the
+// default and custom device profiles.
+func deviceSettingsPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"allow_mode_switch": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"allow_updates": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"auto_connect": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+
+		"captive_portal": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  180,
+		},
+
+		"disable_auto_fallback": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"switch_locked": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"support_url": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"service_mode_v2_mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "warp",
+			ValidateFunc: validateDeviceServiceMode,
+		},
+
+		"service_mode_v2_port": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustDeviceCustomProfileCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	policy := deviceCustomProfileFromResourceData(d)
+	if err := checkDeviceProfilePrecedence(client, accountID, "", policy.Precedence); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare Device Profile create configuration: %#v", policy)
+
+	var created deviceSettingsPolicy
+	if err := client.apiRequest("POST", deviceSettingsPolicyURI(accountID), policy, &created); err != nil {
+		return fmt.Errorf("Error creating device profile for account %q: %s", accountID, err)
+	}
+
+	if created.PolicyID == "" {
+		return fmt.Errorf("Failed to find device profile in create response; ID was empty")
+	}
+
+	d.SetId(created.PolicyID)
+
+	log.Printf("[INFO] CloudFlare Device Profile ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustDeviceCustomProfileRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDeviceCustomProfileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var policy deviceSettingsPolicy
+	err := client.apiRequest("GET", deviceSettingsPolicyURI(accountID)+"/"+d.Id(), nil, &policy)
+	if isNotFound(err) {
+		log.Printf("[INFO] Device profile %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding device profile %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("match", policy.Match)
+	d.Set("precedence", policy.Precedence)
+	if policy.Enabled != nil {
+		d.Set("enabled", *policy.Enabled)
+	}
+	setDeviceSettingsPolicy(d, policy)
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustDeviceCustomProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	policy := deviceCustomProfileFromResourceData(d)
+	if d.HasChange("precedence") {
+		if err := checkDeviceProfilePrecedence(client, accountID, d.Id(), policy.Precedence); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[DEBUG] CloudFlare Device Profile update configuration: %#v", policy)
+
+	if err := client.apiRequest("PATCH", deviceSettingsPolicyURI(accountID)+"/"+d.Id(), policy, nil); err != nil {
+		return fmt.Errorf("Error updating device profile %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustDeviceCustomProfileRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDeviceCustomProfileDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Device Profile: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", deviceSettingsPolicyURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting device profile %q: %s", d.Id(), err)
+}
+
+// deviceSettingsPolicyURI is the default profile of an account; custom
+// profiles live below it.
+func deviceSettingsPolicyURI(accountID string) string {
+	return "/accounts/" + accountID + "/devices/policy"
+}
+
+// checkDeviceProfilePrecedence ensures that no other custom profile of the
+// account has the given precedence, which the API would otherwise reject
+// with a less helpful error.
+func checkDeviceProfilePrecedence(client *CloudFlareClient, accountID, policyID string, precedence int) error {
+	var policies []deviceSettingsPolicy
+	if err := client.apiRequest("GET", "/accounts/"+accountID+"/devices/policies", nil, &policies); err != nil {
+		return fmt.Errorf("Error listing device profiles for account %q: %s", accountID, err)
+	}
+
+	if conflict, ok := findDeviceProfileWithPrecedence(policies, policyID, precedence); ok {
+		return fmt.Errorf("Device profile %q (%s) already has precedence %d", conflict.Name, conflict.PolicyID, precedence)
+	}
+	return nil
+}
+
+func findDeviceProfileWithPrecedence(policies []deviceSettingsPolicy, policyID string, precedence int) (deviceSettingsPolicy, bool) {
+	for _, policy := range policies {
+		if policy.Default || policy.PolicyID == policyID {
+			continue
+		}
+		if policy.Precedence == precedence {
+			return policy, true
+		}
+	}
+	return deviceSettingsPolicy{}, false
+}
+
+func deviceSettingsPolicyFromResourceData(d *schema.ResourceData) deviceSettingsPolicy {
+	policy := deviceSettingsPolicy{
+		AllowModeSwitch:     d.Get("allow_mode_switch").(bool),
+		AllowUpdates:        d.Get("allow_updates").(bool),
+		AutoConnect:         d.Get("auto_connect").(int),
+		CaptivePortal:       d.Get("captive_portal").(int),
+		DisableAutoFallback: d.Get("disable_auto_fallback").(bool),
+		SwitchLocked:        d.Get("switch_locked").(bool),
+		SupportURL:          d.Get("support_url").(string),
+		ServiceModeV2: &deviceServiceMode{
+			Mode: d.Get("service_mode_v2_mode").(string),
+			Port: d.Get("service_mode_v2_port").(int),
+		},
+	}
+
+	return policy
+}
+
+func deviceCustomProfileFromResourceData(d *schema.ResourceData) deviceSettingsPolicy {
+	policy := deviceSettingsPolicyFromResourceData(d)
+
+	enabled := d.Get("enabled").(bool)
+	policy.Name = d.Get("name").(string)
+	policy.Description = d.Get("description").(string)
+	policy.Match = d.Get("match").(string)
+	policy.Precedence = d.Get("precedence").(int)
+	policy.Enabled = &enabled
+
+	return policy
+}
+
+func setDeviceSettingsPolicy(d *schema.ResourceData, policy deviceSettingsPolicy) {
+	d.Set("allow_mode_switch", policy.AllowModeSwitch)
+	d.Set("allow_updates", policy.AllowUpdates)
+	d.Set("auto_connect", policy.AutoConnect)
+	d.Set("captive_portal", policy.CaptivePortal)
+	d.Set("disable_auto_fallback", policy.DisableAutoFallback)
+	d.Set("switch_locked", policy.SwitchLocked)
+	d.Set("support_url", policy.SupportURL)
+	if policy.ServiceModeV2 != nil {
+		d.Set("service_mode_v2_mode", policy.ServiceModeV2.Mode)
+		d.Set("service_mode_v2_port", policy.ServiceModeV2.Port)
+	}
+}
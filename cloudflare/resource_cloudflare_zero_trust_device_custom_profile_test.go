@@ -0,0 +1,91 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustDeviceCustomProfile_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_device_custom_profile.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustDeviceCustomProfileDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDeviceCustomProfileConfig, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform"),
+					resource.TestCheckResourceAttr(name, "precedence", "101"),
+					resource.TestCheckResourceAttr(name, "allow_mode_switch", "true"),
+					resource.TestCheckResourceAttr(name, "service_mode_v2_mode", "proxy"),
+					resource.TestCheckResourceAttr(name, "service_mode_v2_port", "8080"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestFindDeviceProfileWithPrecedence(t *testing.T) {
+	policies := []deviceSettingsPolicy{
+		{PolicyID: "", Default: true},
+		{PolicyID: "a", Name: "engineering", Precedence: 10},
+		{PolicyID: "b", Name: "sales", Precedence: 20},
+	}
+
+	if conflict, ok := findDeviceProfileWithPrecedence(policies, "", 10); !ok || conflict.PolicyID != "a" {
+		t.Fatalf("precedence 10 should conflict with profile a, got %#v", conflict)
+	}
+	if _, ok := findDeviceProfileWithPrecedence(policies, "a", 10); ok {
+		t.Fatal("a profile should not conflict with itself")
+	}
+	if _, ok := findDeviceProfileWithPrecedence(policies, "", 0); ok {
+		t.Fatal("the default profile should not conflict")
+	}
+	if _, ok := findDeviceProfileWithPrecedence(policies, "", 30); ok {
+		t.Fatal("precedence 30 should not conflict")
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustDeviceCustomProfileDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_device_custom_profile" {
+			continue
+		}
+
+		uri := deviceSettingsPolicyURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Device profile still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustDeviceCustomProfileConfig = `
+resource "cloudflare_zero_trust_device_custom_profile" "foobar" {
+	account_id = "%s"
+	name = "terraform"
+	match = "identity.email == \"terraform@example.com\""
+	precedence = 101
+	allow_mode_switch = true
+	service_mode_v2_mode = "proxy"
+	service_mode_v2_port = 8080
+}`
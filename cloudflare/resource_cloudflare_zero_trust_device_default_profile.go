@@ -0,0 +1,83 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceCloudFlareZeroTrustDeviceDefaultProfile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustDeviceDefaultProfileUpdate,
+		Read:   resourceCloudFlareZeroTrustDeviceDefaultProfileRead,
+		Update: resourceCloudFlareZeroTrustDeviceDefaultProfileUpdate,
+		Delete: resourceCloudFlareZeroTrustDeviceDefaultProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: deviceSettingsPolicySchema(),
+	}
+}
+
+func resourceCloudFlareZeroTrustDeviceDefaultProfileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	var policy deviceSettingsPolicy
+	err := client.apiRequest("GET", deviceSettingsPolicyURI(accountID), nil, &policy)
+	if isNotFound(err) {
+		log.Printf("[INFO] Default device profile for account %s not found", accountID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding default device profile for account %q: %s", accountID, err)
+	}
+
+	d.Set("account_id", accountID)
+	setDeviceSettingsPolicy(d, policy)
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustDeviceDefaultProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	policy := deviceSettingsPolicyFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare default Device Profile for account %s: %#v", accountID, policy)
+
+	if err := client.apiRequest("PATCH", deviceSettingsPolicyURI(accountID), policy, nil); err != nil {
+		return fmt.Errorf("Error updating default device profile for account %q: %s", accountID, err)
+	}
+
+	d.SetId(accountID)
+
+	return resourceCloudFlareZeroTrustDeviceDefaultProfileRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDeviceDefaultProfileDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	// Every account has a default profile, so it can't be deleted; its
+	// settings are reset instead.
+	log.Printf("[INFO] Resetting default device profile for account %s", accountID)
+
+	err := client.apiRequest("PATCH", deviceSettingsPolicyURI(accountID), defaultDeviceSettingsPolicy(), nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error resetting default device profile for account %q: %s", accountID, err)
+}
+
+// defaultDeviceSettingsPolicy is the default profile of a new account,
+// which also has the defaults of deviceSettingsPolicySchema.
+func defaultDeviceSettingsPolicy() deviceSettingsPolicy {
+	return deviceSettingsPolicy{
+		CaptivePortal: 180,
+		ServiceModeV2: &deviceServiceMode{Mode: "warp"},
+	}
+}
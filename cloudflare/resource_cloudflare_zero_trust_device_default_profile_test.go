@@ -0,0 +1,122 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustDeviceDefaultProfile_AutoConnect(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_device_default_profile.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustDeviceDefaultProfileReset,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDeviceDefaultProfileConfig, accountID, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", accountID),
+					resource.TestCheckResourceAttr(name, "auto_connect", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDeviceDefaultProfileConfig, accountID, 15),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "auto_connect", "15"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     accountID,
+			},
+		},
+	})
+}
+
+func TestCloudFlareZeroTrustDeviceDefaultProfile_AutoConnect(t *testing.T) {
+	stored := defaultDeviceSettingsPolicy()
+	stored.Default = true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != deviceSettingsPolicyURI("abc") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+				t.Errorf("invalid profile: %s", err)
+			}
+			writeTestResult(w, stored)
+		case "GET":
+			writeTestResult(w, stored)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareZeroTrustDeviceDefaultProfile().Schema, map[string]interface{}{
+		"account_id":    "abc",
+		"auto_connect":  15,
+		"switch_locked": true,
+	})
+	if err := resourceCloudFlareZeroTrustDeviceDefaultProfileUpdate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "abc" || stored.AutoConnect != 15 || d.Get("auto_connect") != 15 {
+		t.Fatalf("bad update: id %q, stored auto_connect %d, state auto_connect %v", d.Id(), stored.AutoConnect, d.Get("auto_connect"))
+	}
+
+	if err := resourceCloudFlareZeroTrustDeviceDefaultProfileDelete(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if stored.AutoConnect != 0 || stored.SwitchLocked || stored.CaptivePortal != 180 || stored.ServiceModeV2.Mode != "warp" {
+		t.Fatalf("expected delete to reset the profile to its defaults, got %#v", stored)
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustDeviceDefaultProfileReset(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_device_default_profile" {
+			continue
+		}
+
+		var policy deviceSettingsPolicy
+		if err := client.apiRequest("GET", deviceSettingsPolicyURI(rs.Primary.ID), nil, &policy); err != nil {
+			return err
+		}
+		if policy.AutoConnect != 0 {
+			return fmt.Errorf("Default device profile wasn't reset: auto_connect is %d", policy.AutoConnect)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustDeviceDefaultProfileConfig = `
+resource "cloudflare_zero_trust_device_default_profile" "foobar" {
+	account_id = "%s"
+	auto_connect = %d
+}`
@@ -0,0 +1,170 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// deviceManagedNetwork is a network WARP clients recognise as trusted by
+// reaching a TLS endpoint on it that presents the expected certificate.
+type deviceManagedNetwork struct {
+	NetworkID string                     `json:"network_id,omitempty"`
+	Name      string                     `json:"name"`
+	Type      string                     `json:"type"`
+	Config    deviceManagedNetworkConfig `json:"config"`
+}
+
+type deviceManagedNetworkConfig struct {
+	TLSSockaddr string `json:"tls_sockaddr"`
+	SHA256      string `json:"sha256,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustDeviceManagedNetworks() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustDeviceManagedNetworksCreate,
+		Read:   resourceCloudFlareZeroTrustDeviceManagedNetworksRead,
+		Update: resourceCloudFlareZeroTrustDeviceManagedNetworksUpdate,
+		Delete: resourceCloudFlareZeroTrustDeviceManagedNetworksDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "tls",
+				ValidateFunc: validateDeviceManagedNetworkType,
+			},
+
+			"config": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tls_sockaddr": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateTLSSockaddr,
+						},
+						"sha256": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateSHA256Fingerprint,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustDeviceManagedNetworksCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	network := deviceManagedNetworkFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Device Managed Network create configuration: %#v", network)
+
+	var created deviceManagedNetwork
+	if err := client.apiRequest("POST", deviceManagedNetworksURI(accountID), network, &created); err != nil {
+		return fmt.Errorf("Error creating device managed network for account %q: %s", accountID, err)
+	}
+
+	if created.NetworkID == "" {
+		return fmt.Errorf("Failed to find device managed network in create response; ID was empty")
+	}
+
+	d.SetId(created.NetworkID)
+
+	log.Printf("[INFO] CloudFlare Device Managed Network ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustDeviceManagedNetworksRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDeviceManagedNetworksRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var network deviceManagedNetwork
+	err := client.apiRequest("GET", deviceManagedNetworksURI(accountID)+"/"+d.Id(), nil, &network)
+	if isNotFound(err) {
+		log.Printf("[INFO] Device managed network %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding device managed network %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", network.Name)
+	d.Set("type", network.Type)
+
+	config := []interface{}{map[string]interface{}{
+		"tls_sockaddr": network.Config.TLSSockaddr,
+		"sha256":       network.Config.SHA256,
+	}}
+	if err := d.Set("config", config); err != nil {
+		return fmt.Errorf("Error setting config: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustDeviceManagedNetworksUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	network := deviceManagedNetworkFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Device Managed Network update configuration: %#v", network)
+
+	if err := client.apiRequest("PUT", deviceManagedNetworksURI(accountID)+"/"+d.Id(), network, nil); err != nil {
+		return fmt.Errorf("Error updating device managed network %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustDeviceManagedNetworksRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDeviceManagedNetworksDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Device Managed Network: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", deviceManagedNetworksURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting device managed network %q: %s", d.Id(), err)
+}
+
+func deviceManagedNetworksURI(accountID string) string {
+	return "/accounts/" + accountID + "/devices/networks"
+}
+
+func deviceManagedNetworkFromResourceData(d *schema.ResourceData) deviceManagedNetwork {
+	config := d.Get("config").([]interface{})[0].(map[string]interface{})
+
+	return deviceManagedNetwork{
+		Name: d.Get("name").(string),
+		Type: d.Get("type").(string),
+		Config: deviceManagedNetworkConfig{
+			TLSSockaddr: config["tls_sockaddr"].(string),
+			SHA256:      config["sha256"].(string),
+		},
+	}
+}
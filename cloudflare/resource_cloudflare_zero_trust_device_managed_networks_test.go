@@ -0,0 +1,77 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustDeviceManagedNetworks_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_device_managed_networks.foobar"
+	sha256 := "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustDeviceManagedNetworksDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDeviceManagedNetworksConfig, accountID, "192.0.2.10:443", sha256),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "type", "tls"),
+					resource.TestCheckResourceAttr(name, "config.0.tls_sockaddr", "192.0.2.10:443"),
+					resource.TestCheckResourceAttr(name, "config.0.sha256", sha256),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDeviceManagedNetworksConfig, accountID, "192.0.2.11:8443", sha256),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "config.0.tls_sockaddr", "192.0.2.11:8443"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareZeroTrustDeviceManagedNetworksDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_device_managed_networks" {
+			continue
+		}
+
+		uri := deviceManagedNetworksURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Device managed network still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustDeviceManagedNetworksConfig = `
+resource "cloudflare_zero_trust_device_managed_networks" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	type = "tls"
+
+	config {
+		tls_sockaddr = "%s"
+		sha256 = "%s"
+	}
+}`
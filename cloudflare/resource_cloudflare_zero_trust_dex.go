@@ -0,0 +1,217 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dexTest is a Digital Experience Monitoring test, which WARP clients run
+// against a host to measure the experience of their users.
+type dexTest struct {
+	TestID      string      `json:"test_id,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Interval    string      `json:"interval"`
+	Enabled     bool        `json:"enabled"`
+	Data        dexTestData `json:"data"`
+}
+
+type dexTestData struct {
+	Host   string `json:"host"`
+	Kind   string `json:"kind"`
+	Method string `json:"method,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustDEXTest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustDEXTestCreate,
+		Read:   resourceCloudFlareZeroTrustDEXTestRead,
+		Update: resourceCloudFlareZeroTrustDEXTestUpdate,
+		Delete: resourceCloudFlareZeroTrustDEXTestDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"interval": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"data": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"kind": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateDEXTestKind,
+						},
+						"method": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustDEXTestCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	test := dexTestFromResourceData(d)
+	if err := checkDEXTest(test); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare DEX Test create configuration: %#v", test)
+
+	var created dexTest
+	if err := client.apiRequest("POST", dexTestsURI(accountID), test, &created); err != nil {
+		return fmt.Errorf("Error creating DEX test %q for account %q: %s", test.Name, accountID, err)
+	}
+
+	if created.TestID == "" {
+		return fmt.Errorf("Failed to find DEX test in create response; ID was empty")
+	}
+
+	d.SetId(created.TestID)
+
+	log.Printf("[INFO] CloudFlare DEX Test ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustDEXTestRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDEXTestRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var test dexTest
+	err := client.apiRequest("GET", dexTestsURI(accountID)+"/"+d.Id(), nil, &test)
+	if isNotFound(err) {
+		log.Printf("[INFO] DEX test %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding DEX test %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", test.Name)
+	d.Set("description", test.Description)
+	d.Set("interval", test.Interval)
+	d.Set("enabled", test.Enabled)
+	if err := d.Set("data", flattenDEXTestData(test.Data)); err != nil {
+		return fmt.Errorf("Error setting data: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustDEXTestUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	test := dexTestFromResourceData(d)
+	if err := checkDEXTest(test); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare DEX Test update configuration: %#v", test)
+
+	if err := client.apiRequest("PUT", dexTestsURI(accountID)+"/"+d.Id(), test, nil); err != nil {
+		return fmt.Errorf("Error updating DEX test %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustDEXTestRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDEXTestDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare DEX Test: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", dexTestsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting DEX test %q: %s", d.Id(), err)
+}
+
+func dexTestsURI(accountID string) string {
+	return "/accounts/" + accountID + "/dex/devices/dex_tests"
+}
+
+// checkDEXTest ensures that HTTP tests have a method, which can only be GET,
+// and that traceroute tests, which don't make requests, have none.
+func checkDEXTest(test dexTest) error {
+	switch test.Data.Kind {
+	case "http":
+		if test.Data.Method != "GET" {
+			return fmt.Errorf("DEX test %q of kind \"http\" requires method \"GET\", got %q", test.Name, test.Data.Method)
+		}
+	case "traceroute":
+		if test.Data.Method != "" {
+			return fmt.Errorf("DEX test %q of kind \"traceroute\" can't have a method", test.Name)
+		}
+	}
+	return nil
+}
+
+func dexTestFromResourceData(d *schema.ResourceData) dexTest {
+	data := d.Get("data").([]interface{})[0].(map[string]interface{})
+
+	return dexTest{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Interval:    d.Get("interval").(string),
+		Enabled:     d.Get("enabled").(bool),
+		Data: dexTestData{
+			Host:   data["host"].(string),
+			Kind:   data["kind"].(string),
+			Method: data["method"].(string),
+		},
+	}
+}
+
+func flattenDEXTestData(data dexTestData) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"host":   data.Host,
+		"kind":   data.Kind,
+		"method": data.Method,
+	}}
+}
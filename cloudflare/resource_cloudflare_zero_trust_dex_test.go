@@ -0,0 +1,118 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustDEXTest_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_dex_test.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustDEXTestDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDEXTestConfigHTTP, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "interval", "30m"),
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "data.0.kind", "http"),
+					resource.TestCheckResourceAttr(name, "data.0.method", "GET"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDEXTestConfigTraceroute, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "false"),
+					resource.TestCheckResourceAttr(name, "data.0.kind", "traceroute"),
+					resource.TestCheckResourceAttr(name, "data.0.method", ""),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestCheckDEXTest(t *testing.T) {
+	cases := []struct {
+		test  dexTest
+		valid bool
+	}{
+		{dexTest{Name: "a", Data: dexTestData{Kind: "http", Method: "GET"}}, true},
+		{dexTest{Name: "b", Data: dexTestData{Kind: "http"}}, false},
+		{dexTest{Name: "c", Data: dexTestData{Kind: "http", Method: "POST"}}, false},
+		{dexTest{Name: "d", Data: dexTestData{Kind: "traceroute"}}, true},
+		{dexTest{Name: "e", Data: dexTestData{Kind: "traceroute", Method: "GET"}}, false},
+	}
+
+	for _, c := range cases {
+		err := checkDEXTest(c.test)
+		if c.valid && err != nil {
+			t.Fatalf("%s should be valid: %s", c.test.Name, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%s should not be valid", c.test.Name)
+		}
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustDEXTestDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_dex_test" {
+			continue
+		}
+
+		uri := dexTestsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("DEX test still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustDEXTestConfigHTTP = `
+resource "cloudflare_zero_trust_dex_test" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	description = "Checks the intranet"
+	interval = "30m"
+
+	data {
+		host = "https://intranet.example.com"
+		kind = "http"
+		method = "GET"
+	}
+}`
+
+const testAccCheckCloudFlareZeroTrustDEXTestConfigTraceroute = `
+resource "cloudflare_zero_trust_dex_test" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	description = "Checks the intranet"
+	interval = "30m"
+	enabled = false
+
+	data {
+		host = "intranet.example.com"
+		kind = "traceroute"
+	}
+}`
@@ -0,0 +1,359 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dlpProfile is a set of patterns Gateway looks for in traffic. Custom
+// profiles are managed by the account; predefined profiles are provided by
+// Cloudflare and only their entries can be turned on and off.
+type dlpProfile struct {
+	ID                string     `json:"id,omitempty"`
+	Name              string     `json:"name,omitempty"`
+	Type              string     `json:"type,omitempty"`
+	Description       string     `json:"description,omitempty"`
+	AllowedMatchCount int        `json:"allowed_match_count"`
+	Entries           []dlpEntry `json:"entries"`
+}
+
+type dlpEntry struct {
+	ID      string      `json:"id,omitempty"`
+	Name    string      `json:"name,omitempty"`
+	Enabled bool        `json:"enabled"`
+	Pattern *dlpPattern `json:"pattern,omitempty"`
+}
+
+type dlpPattern struct {
+	Regex      string `json:"regex"`
+	Validation string `json:"validation,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustDLPProfile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustDLPProfileCreate,
+		Read:   resourceCloudFlareZeroTrustDLPProfileRead,
+		Update: resourceCloudFlareZeroTrustDLPProfileUpdate,
+		Delete: resourceCloudFlareZeroTrustDLPProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDLPProfileType,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"allowed_match_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+
+			"entry": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"pattern": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"regex": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"validation": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateDLPPatternValidation,
+									},
+								},
+							},
+						},
+					},
+				},
+				Set: dlpEntryHash,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustDLPProfileCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	profile := dlpProfileFromResourceData(d)
+	if err := checkDLPProfileEntries(profile); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare DLP Profile create configuration: %#v", profile)
+
+	if profile.Type == "predefined" {
+		// Predefined profiles always exist; "creating" one takes over the
+		// enablement of its entries.
+		existing, err := findPredefinedDLPProfile(client, accountID, profile.Name)
+		if err != nil {
+			return err
+		}
+
+		d.SetId(existing.ID)
+		if err := updatePredefinedDLPProfile(client, accountID, existing, profile); err != nil {
+			return err
+		}
+
+		return resourceCloudFlareZeroTrustDLPProfileRead(d, meta)
+	}
+
+	var created []dlpProfile
+	req := map[string][]dlpProfile{"profiles": {profile}}
+	if err := client.apiRequest("POST", dlpProfilesURI(accountID)+"/custom", req, &created); err != nil {
+		return fmt.Errorf("Error creating DLP profile for account %q: %s", accountID, err)
+	}
+
+	if len(created) == 0 || created[0].ID == "" {
+		return fmt.Errorf("Failed to find DLP profile in create response; ID was empty")
+	}
+
+	d.SetId(created[0].ID)
+
+	log.Printf("[INFO] CloudFlare DLP Profile ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustDLPProfileRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDLPProfileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var profile dlpProfile
+	err := client.apiRequest("GET", dlpProfilesURI(accountID)+"/"+d.Id(), nil, &profile)
+	if isNotFound(err) {
+		log.Printf("[INFO] DLP profile %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding DLP profile %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", profile.Name)
+	d.Set("type", profile.Type)
+	d.Set("description", profile.Description)
+	d.Set("allowed_match_count", profile.AllowedMatchCount)
+
+	entries := make([]interface{}, 0, len(profile.Entries))
+	for _, entry := range profile.Entries {
+		m := map[string]interface{}{
+			"id":      entry.ID,
+			"name":    entry.Name,
+			"enabled": entry.Enabled,
+		}
+		// The patterns of predefined entries are Cloudflare's to manage.
+		if entry.Pattern != nil && profile.Type == "custom" {
+			m["pattern"] = []interface{}{map[string]interface{}{
+				"regex":      entry.Pattern.Regex,
+				"validation": entry.Pattern.Validation,
+			}}
+		}
+		entries = append(entries, m)
+	}
+	if err := d.Set("entry", schema.NewSet(dlpEntryHash, entries)); err != nil {
+		return fmt.Errorf("Error setting entry: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustDLPProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	profile := dlpProfileFromResourceData(d)
+	if err := checkDLPProfileEntries(profile); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] CloudFlare DLP Profile update configuration: %#v", profile)
+
+	var current dlpProfile
+	if err := client.apiRequest("GET", dlpProfilesURI(accountID)+"/"+d.Id(), nil, &current); err != nil {
+		return fmt.Errorf("Error finding DLP profile %q: %s", d.Id(), err)
+	}
+
+	if profile.Type == "predefined" {
+		if err := updatePredefinedDLPProfile(client, accountID, current, profile); err != nil {
+			return err
+		}
+		return resourceCloudFlareZeroTrustDLPProfileRead(d, meta)
+	}
+
+	// Keep the IDs of entries that are still there so they aren't recreated.
+	for i, entry := range profile.Entries {
+		if existing, ok := findDLPEntry(current, entry.Name); ok {
+			profile.Entries[i].ID = existing.ID
+		}
+	}
+
+	if err := client.apiRequest("PUT", dlpProfilesURI(accountID)+"/custom/"+d.Id(), profile, nil); err != nil {
+		return fmt.Errorf("Error updating DLP profile %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustDLPProfileRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDLPProfileDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	if d.Get("type").(string) == "predefined" {
+		log.Printf("[WARN] Removing predefined DLP profile %s from state; it cannot be deleted", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting CloudFlare DLP Profile: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", dlpProfilesURI(accountID)+"/custom/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting DLP profile %q: %s", d.Id(), err)
+}
+
+func dlpProfilesURI(accountID string) string {
+	return "/accounts/" + accountID + "/dlp/profiles"
+}
+
+func findPredefinedDLPProfile(client *CloudFlareClient, accountID, name string) (dlpProfile, error) {
+	var profiles []dlpProfile
+	if err := client.apiRequest("GET", dlpProfilesURI(accountID), nil, &profiles); err != nil {
+		return dlpProfile{}, fmt.Errorf("Error listing DLP profiles for account %q: %s", accountID, err)
+	}
+
+	for _, profile := range profiles {
+		if profile.Type == "predefined" && profile.Name == name {
+			return profile, nil
+		}
+	}
+	return dlpProfile{}, fmt.Errorf("Predefined DLP profile %q not found in account %q", name, accountID)
+}
+
+// updatePredefinedDLPProfile turns the entries of a predefined profile on and
+// off. Entries are matched by name; entries not in the configuration are left
+// as they are.
+func updatePredefinedDLPProfile(client *CloudFlareClient, accountID string, current, profile dlpProfile) error {
+	update := dlpProfile{AllowedMatchCount: profile.AllowedMatchCount}
+	for _, entry := range profile.Entries {
+		existing, ok := findDLPEntry(current, entry.Name)
+		if !ok {
+			return fmt.Errorf("Predefined DLP profile %q has no entry %q", current.Name, entry.Name)
+		}
+		update.Entries = append(update.Entries, dlpEntry{ID: existing.ID, Enabled: entry.Enabled})
+	}
+
+	if err := client.apiRequest("PUT", dlpProfilesURI(accountID)+"/predefined/"+current.ID, update, nil); err != nil {
+		return fmt.Errorf("Error updating DLP profile %q: %s", current.ID, err)
+	}
+	return nil
+}
+
+func findDLPEntry(profile dlpProfile, name string) (dlpEntry, bool) {
+	for _, entry := range profile.Entries {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return dlpEntry{}, false
+}
+
+func dlpProfileFromResourceData(d *schema.ResourceData) dlpProfile {
+	profile := dlpProfile{
+		Name:              d.Get("name").(string),
+		Type:              d.Get("type").(string),
+		Description:       d.Get("description").(string),
+		AllowedMatchCount: d.Get("allowed_match_count").(int),
+		Entries:           []dlpEntry{},
+	}
+
+	for _, v := range d.Get("entry").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		entry := dlpEntry{
+			Name:    m["name"].(string),
+			Enabled: m["enabled"].(bool),
+		}
+		if patterns := m["pattern"].([]interface{}); len(patterns) > 0 && patterns[0] != nil {
+			pattern := patterns[0].(map[string]interface{})
+			entry.Pattern = &dlpPattern{
+				Regex:      pattern["regex"].(string),
+				Validation: pattern["validation"].(string),
+			}
+		}
+		profile.Entries = append(profile.Entries, entry)
+	}
+
+	return profile
+}
+
+// checkDLPProfileEntries ensures that custom entries have a pattern and that
+// predefined entries don't, as only their enablement can be changed.
+func checkDLPProfileEntries(profile dlpProfile) error {
+	for _, entry := range profile.Entries {
+		if profile.Type == "predefined" && entry.Pattern != nil {
+			return fmt.Errorf("Entry %q of a predefined DLP profile cannot have a pattern; only enabled can be set", entry.Name)
+		}
+		if profile.Type == "custom" && entry.Pattern == nil {
+			return fmt.Errorf("Entry %q of a custom DLP profile must have a pattern", entry.Name)
+		}
+	}
+	return nil
+}
+
+// dlpEntryHash leaves out the computed entry ID.
+func dlpEntryHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	s := fmt.Sprintf("%s-%t", m["name"].(string), m["enabled"].(bool))
+	if patterns, ok := m["pattern"].([]interface{}); ok && len(patterns) > 0 && patterns[0] != nil {
+		pattern := patterns[0].(map[string]interface{})
+		s += fmt.Sprintf("-%s-%s", pattern["regex"].(string), pattern["validation"].(string))
+	}
+	return hashcode.String(s)
+}
@@ -0,0 +1,100 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustDLPProfile_Custom(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_dlp_profile.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustDLPProfileDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDLPProfileConfigCustom, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform"),
+					resource.TestCheckResourceAttr(name, "type", "custom"),
+					resource.TestCheckResourceAttr(name, "allowed_match_count", "1"),
+					resource.TestCheckResourceAttr(name, "entry.#", "1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestCheckDLPProfileEntries(t *testing.T) {
+	pattern := &dlpPattern{Regex: "^4[0-9]{12}$", Validation: "luhn"}
+
+	cases := []struct {
+		profile dlpProfile
+		valid   bool
+	}{
+		{dlpProfile{Type: "custom", Entries: []dlpEntry{{Name: "card", Enabled: true, Pattern: pattern}}}, true},
+		{dlpProfile{Type: "custom", Entries: []dlpEntry{{Name: "card", Enabled: true}}}, false},
+		{dlpProfile{Type: "predefined", Entries: []dlpEntry{{Name: "Visa Card Number", Enabled: true}}}, true},
+		{dlpProfile{Type: "predefined", Entries: []dlpEntry{{Name: "Visa Card Number", Enabled: true, Pattern: pattern}}}, false},
+	}
+
+	for _, c := range cases {
+		err := checkDLPProfileEntries(c.profile)
+		if c.valid && err != nil {
+			t.Fatalf("%#v should be valid: %s", c.profile, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("%#v should not be valid", c.profile)
+		}
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustDLPProfileDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_dlp_profile" {
+			continue
+		}
+
+		uri := dlpProfilesURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("DLP profile still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustDLPProfileConfigCustom = `
+resource "cloudflare_zero_trust_dlp_profile" "foobar" {
+	account_id = "%s"
+	name = "terraform"
+	type = "custom"
+	description = "Employee IDs"
+	allowed_match_count = 1
+
+	entry {
+		name = "employee id"
+		enabled = true
+
+		pattern {
+			regex = "^EMP-[0-9]{6}$"
+		}
+	}
+}`
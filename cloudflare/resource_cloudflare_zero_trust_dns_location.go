@@ -0,0 +1,290 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// teamsLocation is a network whose DNS queries Gateway resolves and filters.
+// Queries reach it on the endpoints that are enabled: plain DNS over IPv4 or
+// IPv6, DNS over TLS or DNS over HTTPS.
+type teamsLocation struct {
+	ID              string                  `json:"id,omitempty"`
+	Name            string                  `json:"name"`
+	ClientDefault   bool                    `json:"client_default"`
+	ECSSupport      bool                    `json:"ecs_support"`
+	Networks        []teamsLocationNetwork  `json:"networks"`
+	Endpoints       *teamsLocationEndpoints `json:"endpoints,omitempty"`
+	DOHSubdomain    string                  `json:"doh_subdomain,omitempty"`
+	IP              string                  `json:"ip,omitempty"`
+	IPv4Destination string                  `json:"ipv4_destination,omitempty"`
+}
+
+type teamsLocationNetwork struct {
+	Network string `json:"network"`
+}
+
+type teamsLocationEndpoints struct {
+	IPv4 teamsLocationEndpoint `json:"ipv4"`
+	IPv6 teamsLocationEndpoint `json:"ipv6"`
+	DOT  teamsLocationEndpoint `json:"dot"`
+	DOH  teamsLocationEndpoint `json:"doh"`
+}
+
+// teamsLocationEndpoint is one way of sending queries to a location. Only
+// DNS over HTTPS can require a service token.
+type teamsLocationEndpoint struct {
+	Enabled      bool `json:"enabled"`
+	RequireToken bool `json:"require_token,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustDNSLocation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustDNSLocationCreate,
+		Read:   resourceCloudFlareZeroTrustDNSLocationRead,
+		Update: resourceCloudFlareZeroTrustDNSLocationUpdate,
+		Delete: resourceCloudFlareZeroTrustDNSLocationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"client_default": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"ecs_support": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"networks": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCIDR,
+				},
+				Set: schema.HashString,
+			},
+
+			"endpoints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipv4": teamsLocationEndpointSchema(false),
+						"ipv6": teamsLocationEndpointSchema(false),
+						"dot":  teamsLocationEndpointSchema(false),
+						"doh":  teamsLocationEndpointSchema(true),
+					},
+				},
+			},
+
+			"doh_subdomain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ipv4_destination": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func teamsLocationEndpointSchema(requireToken bool) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+	}
+	if requireToken {
+		s["require_token"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem:     &schema.Resource{Schema: s},
+	}
+}
+
+func resourceCloudFlareZeroTrustDNSLocationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	location := teamsLocationFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Gateway DNS Location create configuration: %#v", location)
+
+	var created teamsLocation
+	if err := client.apiRequest("POST", teamsLocationsURI(accountID), location, &created); err != nil {
+		return fmt.Errorf("Error creating Gateway DNS location %q for account %q: %s", location.Name, accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find Gateway DNS location in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Gateway DNS Location ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustDNSLocationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDNSLocationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var location teamsLocation
+	err := client.apiRequest("GET", teamsLocationsURI(accountID)+"/"+d.Id(), nil, &location)
+	if isNotFound(err) {
+		log.Printf("[INFO] Gateway DNS location %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Gateway DNS location %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", location.Name)
+	d.Set("client_default", location.ClientDefault)
+	d.Set("ecs_support", location.ECSSupport)
+	d.Set("doh_subdomain", location.DOHSubdomain)
+	d.Set("ip", location.IP)
+	d.Set("ipv4_destination", location.IPv4Destination)
+
+	networks := make([]interface{}, 0, len(location.Networks))
+	for _, n := range location.Networks {
+		networks = append(networks, n.Network)
+	}
+	if err := d.Set("networks", schema.NewSet(schema.HashString, networks)); err != nil {
+		return fmt.Errorf("Error setting networks: %s", err)
+	}
+	if err := d.Set("endpoints", flattenTeamsLocationEndpoints(location.Endpoints)); err != nil {
+		return fmt.Errorf("Error setting endpoints: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustDNSLocationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	location := teamsLocationFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Gateway DNS Location update configuration: %#v", location)
+
+	if err := client.apiRequest("PUT", teamsLocationsURI(accountID)+"/"+d.Id(), location, nil); err != nil {
+		return fmt.Errorf("Error updating Gateway DNS location %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustDNSLocationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustDNSLocationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Gateway DNS Location: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", teamsLocationsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Gateway DNS location %q: %s", d.Id(), err)
+}
+
+func teamsLocationsURI(accountID string) string {
+	return "/accounts/" + accountID + "/gateway/locations"
+}
+
+func teamsLocationFromResourceData(d *schema.ResourceData) teamsLocation {
+	location := teamsLocation{
+		Name:          d.Get("name").(string),
+		ClientDefault: d.Get("client_default").(bool),
+		ECSSupport:    d.Get("ecs_support").(bool),
+		Networks:      []teamsLocationNetwork{},
+		Endpoints:     expandTeamsLocationEndpoints(d.Get("endpoints")),
+	}
+	for _, n := range expandStringSet(d.Get("networks")) {
+		location.Networks = append(location.Networks, teamsLocationNetwork{Network: n})
+	}
+	return location
+}
+
+// expandTeamsLocationEndpoints returns nil when there is no endpoints block,
+// leaving the endpoints of the location as they are.
+func expandTeamsLocationEndpoints(v interface{}) *teamsLocationEndpoints {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+
+	return &teamsLocationEndpoints{
+		IPv4: expandTeamsLocationEndpoint(m["ipv4"]),
+		IPv6: expandTeamsLocationEndpoint(m["ipv6"]),
+		DOT:  expandTeamsLocationEndpoint(m["dot"]),
+		DOH:  expandTeamsLocationEndpoint(m["doh"]),
+	}
+}
+
+func expandTeamsLocationEndpoint(v interface{}) teamsLocationEndpoint {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return teamsLocationEndpoint{}
+	}
+	m := list[0].(map[string]interface{})
+
+	endpoint := teamsLocationEndpoint{Enabled: m["enabled"].(bool)}
+	if requireToken, ok := m["require_token"]; ok {
+		endpoint.RequireToken = requireToken.(bool)
+	}
+	return endpoint
+}
+
+func flattenTeamsLocationEndpoints(endpoints *teamsLocationEndpoints) []interface{} {
+	if endpoints == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"ipv4": []interface{}{map[string]interface{}{"enabled": endpoints.IPv4.Enabled}},
+		"ipv6": []interface{}{map[string]interface{}{"enabled": endpoints.IPv6.Enabled}},
+		"dot":  []interface{}{map[string]interface{}{"enabled": endpoints.DOT.Enabled}},
+		"doh": []interface{}{map[string]interface{}{
+			"enabled":       endpoints.DOH.Enabled,
+			"require_token": endpoints.DOH.RequireToken,
+		}},
+	}}
+}
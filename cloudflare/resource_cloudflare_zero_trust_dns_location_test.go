@@ -0,0 +1,152 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustDNSLocation_DOHRequireToken(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_dns_location.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustDNSLocationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustDNSLocationConfig, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "networks.#", "1"),
+					resource.TestCheckResourceAttr(name, "endpoints.0.doh.0.enabled", "true"),
+					resource.TestCheckResourceAttr(name, "endpoints.0.doh.0.require_token", "true"),
+					resource.TestCheckResourceAttr(name, "endpoints.0.dot.0.enabled", "false"),
+					resource.TestCheckResourceAttrSet(name, "doh_subdomain"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestCloudFlareZeroTrustDNSLocationCreate_Endpoints(t *testing.T) {
+	var sent teamsLocation
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/accounts/1234567890/gateway/locations" && r.Method == "POST":
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("invalid location: %s", err)
+			}
+			created := sent
+			created.ID = "abc"
+			created.DOHSubdomain = "abc123"
+			writeTestResult(w, created)
+		case r.URL.Path == "/accounts/1234567890/gateway/locations/abc" && r.Method == "GET":
+			read := sent
+			read.ID = "abc"
+			read.DOHSubdomain = "abc123"
+			writeTestResult(w, read)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareZeroTrustDNSLocation().Schema, map[string]interface{}{
+		"account_id": "1234567890",
+		"name":       "office",
+		"networks":   []interface{}{"192.0.2.0/24"},
+		"endpoints": []interface{}{map[string]interface{}{
+			"doh": []interface{}{map[string]interface{}{
+				"enabled":       true,
+				"require_token": true,
+			}},
+			"ipv4": []interface{}{map[string]interface{}{
+				"enabled": true,
+			}},
+		}},
+	})
+
+	if err := resourceCloudFlareZeroTrustDNSLocationCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if sent.Endpoints == nil || !sent.Endpoints.DOH.Enabled || !sent.Endpoints.DOH.RequireToken || !sent.Endpoints.IPv4.Enabled {
+		t.Fatalf("expected DoH with a required token and IPv4 to be enabled, got %#v", sent.Endpoints)
+	}
+	if sent.Endpoints.DOT.Enabled || sent.Endpoints.IPv6.Enabled {
+		t.Fatalf("expected DoT and IPv6 to be disabled, got %#v", sent.Endpoints)
+	}
+	if len(sent.Networks) != 1 || sent.Networks[0].Network != "192.0.2.0/24" {
+		t.Fatalf("expected network 192.0.2.0/24, got %#v", sent.Networks)
+	}
+
+	if d.Get("endpoints.0.doh.0.require_token") != true || d.Get("endpoints.0.dot.0.enabled") != false {
+		t.Fatalf("bad endpoints: %#v", d.Get("endpoints"))
+	}
+	if d.Get("doh_subdomain") != "abc123" {
+		t.Fatalf("expected doh_subdomain abc123, got %q", d.Get("doh_subdomain"))
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustDNSLocationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_dns_location" {
+			continue
+		}
+
+		uri := teamsLocationsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Gateway DNS location still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustDNSLocationConfig = `
+resource "cloudflare_zero_trust_dns_location" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	networks = ["192.0.2.0/24"]
+
+	endpoints {
+		doh {
+			enabled = true
+			require_token = true
+		}
+		dot {
+			enabled = false
+		}
+		ipv4 {
+			enabled = true
+		}
+		ipv6 {
+			enabled = false
+		}
+	}
+}`
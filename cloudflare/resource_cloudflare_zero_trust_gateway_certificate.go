@@ -0,0 +1,217 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// gatewayCertificate is a Cloudflare-managed root certificate that Gateway
+// signs the certificates of inspected HTTPS traffic with. It is only used
+// once activated, which deploys it to Cloudflare's edge.
+type gatewayCertificate struct {
+	ID                 string `json:"id,omitempty"`
+	ValidityPeriodDays int    `json:"validity_period_days,omitempty"`
+	Certificate        string `json:"certificate,omitempty"`
+	Fingerprint        string `json:"fingerprint,omitempty"`
+	ExpiresOn          string `json:"expires_on,omitempty"`
+	BindingStatus      string `json:"binding_status,omitempty"`
+}
+
+// Binding statuses of gateway certificates.
+const (
+	gatewayCertificatePendingDeployment = "pending_deployment"
+	gatewayCertificateAvailable         = "available"
+	gatewayCertificateInactive          = "inactive"
+)
+
+func resourceCloudFlareZeroTrustGatewayCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustGatewayCertificateCreate,
+		Read:   resourceCloudFlareZeroTrustGatewayCertificateRead,
+		Update: resourceCloudFlareZeroTrustGatewayCertificateUpdate,
+		Delete: resourceCloudFlareZeroTrustGatewayCertificateDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"validity_period_days": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"activate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"expires_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"binding_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustGatewayCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	newCertificate := gatewayCertificate{
+		ValidityPeriodDays: d.Get("validity_period_days").(int),
+	}
+
+	log.Printf("[DEBUG] CloudFlare Gateway Certificate create configuration: %#v", newCertificate)
+
+	var created gatewayCertificate
+	if err := client.apiRequest("POST", gatewayCertificatesURI(accountID), newCertificate, &created); err != nil {
+		return fmt.Errorf("Error creating gateway certificate for account %q: %s", accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find gateway certificate in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Gateway Certificate ID: %s", d.Id())
+
+	if d.Get("activate").(bool) {
+		if err := client.apiRequest("POST", gatewayCertificatesURI(accountID)+"/"+d.Id()+"/activate", nil, nil); err != nil {
+			return fmt.Errorf("Error activating gateway certificate %q: %s", d.Id(), err)
+		}
+	}
+
+	return resourceCloudFlareZeroTrustGatewayCertificateRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustGatewayCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var certificate gatewayCertificate
+	err := client.apiRequest("GET", gatewayCertificatesURI(accountID)+"/"+d.Id(), nil, &certificate)
+	if isNotFound(err) {
+		log.Printf("[INFO] Gateway certificate %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding gateway certificate %q: %s", d.Id(), err)
+	}
+
+	d.Set("activate", gatewayCertificateActive(certificate))
+	d.Set("certificate", certificate.Certificate)
+	d.Set("fingerprint", certificate.Fingerprint)
+	d.Set("expires_on", certificate.ExpiresOn)
+	d.Set("binding_status", certificate.BindingStatus)
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustGatewayCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	if d.HasChange("activate") {
+		action := "deactivate"
+		if d.Get("activate").(bool) {
+			action = "activate"
+		}
+
+		log.Printf("[DEBUG] CloudFlare Gateway Certificate update: %s %s", action, d.Id())
+
+		if err := client.apiRequest("POST", gatewayCertificatesURI(accountID)+"/"+d.Id()+"/"+action, nil, nil); err != nil {
+			return fmt.Errorf("Failed to %s gateway certificate %q: %s", action, d.Id(), err)
+		}
+	}
+
+	return resourceCloudFlareZeroTrustGatewayCertificateRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustGatewayCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+	uri := gatewayCertificatesURI(accountID) + "/" + d.Id()
+
+	log.Printf("[INFO] Deleting CloudFlare Gateway Certificate: %s, %s", accountID, d.Id())
+
+	var certificate gatewayCertificate
+	err := client.apiRequest("GET", uri, nil, &certificate)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding gateway certificate %q: %s", d.Id(), err)
+	}
+
+	// Certificates can only be deleted once they have been removed from
+	// the edge, which takes a while after deactivating them.
+	if certificate.BindingStatus != gatewayCertificateInactive {
+		if gatewayCertificateActive(certificate) {
+			if err := client.apiRequest("POST", uri+"/deactivate", nil, nil); err != nil {
+				return fmt.Errorf("Error deactivating gateway certificate %q: %s", d.Id(), err)
+			}
+		}
+
+		err := waitForState(d.Timeout(schema.TimeoutDelete), fmt.Sprintf("gateway certificate %q to be deactivated", d.Id()), func() (bool, error) {
+			var certificate gatewayCertificate
+			if err := client.apiRequest("GET", uri, nil, &certificate); err != nil {
+				return false, err
+			}
+			return certificate.BindingStatus == gatewayCertificateInactive, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.apiRequest("DELETE", uri, nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting gateway certificate %q: %s", d.Id(), err)
+}
+
+func gatewayCertificatesURI(accountID string) string {
+	return "/accounts/" + accountID + "/gateway/certificates"
+}
+
+// gatewayCertificateActive reports whether certificate has been activated,
+// including when it is still being deployed.
+func gatewayCertificateActive(certificate gatewayCertificate) bool {
+	switch certificate.BindingStatus {
+	case gatewayCertificatePendingDeployment, gatewayCertificateAvailable:
+		return true
+	}
+	return false
+}
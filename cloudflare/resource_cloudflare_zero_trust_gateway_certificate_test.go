@@ -0,0 +1,73 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustGatewayCertificate_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_gateway_certificate.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustGatewayCertificateDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustGatewayCertificateConfig, accountID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "activate", "false"),
+					resource.TestCheckResourceAttr(name, "binding_status", gatewayCertificateInactive),
+					resource.TestCheckResourceAttrSet(name, "certificate"),
+					resource.TestCheckResourceAttrSet(name, "fingerprint"),
+					resource.TestCheckResourceAttrSet(name, "expires_on"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustGatewayCertificateConfig, accountID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "activate", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     accountID + "/",
+				ImportStateVerifyIgnore: []string{"validity_period_days", "binding_status"},
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareZeroTrustGatewayCertificateDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_gateway_certificate" {
+			continue
+		}
+
+		uri := gatewayCertificatesURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Gateway certificate still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustGatewayCertificateConfig = `
+resource "cloudflare_zero_trust_gateway_certificate" "foobar" {
+	account_id = "%s"
+	validity_period_days = 30
+	activate = %t
+}`
@@ -0,0 +1,91 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceCloudFlareZeroTrustGatewayLogging manages the same settings as the
+// logging block of cloudflare_zero_trust_gateway_settings, for accounts
+// whose other Gateway settings are managed elsewhere. A configuration should
+// use one or the other.
+func resourceCloudFlareZeroTrustGatewayLogging() *schema.Resource {
+	s := teamsLoggingSchema()
+	s["account_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustGatewayLoggingUpdate,
+		Read:   resourceCloudFlareZeroTrustGatewayLoggingRead,
+		Update: resourceCloudFlareZeroTrustGatewayLoggingUpdate,
+		Delete: resourceCloudFlareZeroTrustGatewayLoggingDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceCloudFlareZeroTrustGatewayLoggingRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	var logging teamsLoggingSettings
+	err := client.apiRequest("GET", "/accounts/"+accountID+"/gateway/logging", nil, &logging)
+	if isNotFound(err) {
+		log.Printf("[INFO] Gateway logging settings for account %s not found", accountID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Gateway logging settings for account %q: %s", accountID, err)
+	}
+
+	d.Set("account_id", accountID)
+
+	flattened := flattenTeamsLoggingSettings(logging)
+	d.Set("redact_pii", flattened["redact_pii"])
+	if err := d.Set("settings_by_rule_type", flattened["settings_by_rule_type"]); err != nil {
+		return fmt.Errorf("Error setting settings_by_rule_type: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustGatewayLoggingUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	settings := expandTeamsLoggingSettings(map[string]interface{}{
+		"redact_pii":            d.Get("redact_pii"),
+		"settings_by_rule_type": d.Get("settings_by_rule_type"),
+	})
+	log.Printf("[DEBUG] CloudFlare Gateway logging settings for account %s: %#v", accountID, settings)
+
+	if err := client.apiRequest("PUT", "/accounts/"+accountID+"/gateway/logging", settings, nil); err != nil {
+		return fmt.Errorf("Error updating Gateway logging settings for account %q: %s", accountID, err)
+	}
+
+	d.SetId(accountID)
+
+	return resourceCloudFlareZeroTrustGatewayLoggingRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustGatewayLoggingDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	log.Printf("[INFO] Resetting Gateway logging settings for account %s to their defaults", accountID)
+
+	if err := client.apiRequest("PUT", "/accounts/"+accountID+"/gateway/logging", defaultTeamsLoggingSettings(), nil); err != nil {
+		return fmt.Errorf("Error resetting Gateway logging settings for account %q: %s", accountID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareZeroTrustGatewayLogging_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_gateway_logging.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustGatewayLoggingConfig, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttr(name, "redact_pii", "true"),
+					resource.TestCheckResourceAttr(name, "settings_by_rule_type.0.dns.0.log_all", "false"),
+					resource.TestCheckResourceAttr(name, "settings_by_rule_type.0.dns.0.log_blocks", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCheckCloudFlareZeroTrustGatewayLoggingConfig = `
+resource "cloudflare_zero_trust_gateway_logging" "foobar" {
+	account_id = "%s"
+	redact_pii = true
+
+	settings_by_rule_type {
+		dns {
+			log_all = false
+			log_blocks = true
+		}
+	}
+}`
@@ -0,0 +1,194 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// gatewayPolicy is a Gateway rule: it takes action on the DNS, HTTP or
+// network traffic matching its expressions. Rules are evaluated in order of
+// precedence, lowest first.
+type gatewayPolicy struct {
+	ID            string   `json:"id,omitempty"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Precedence    int      `json:"precedence"`
+	Enabled       bool     `json:"enabled"`
+	Action        string   `json:"action"`
+	Filters       []string `json:"filters"`
+	Traffic       string   `json:"traffic"`
+	Identity      string   `json:"identity"`
+	DevicePosture string   `json:"device_posture"`
+}
+
+func resourceCloudFlareZeroTrustGatewayPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustGatewayPolicyCreate,
+		Read:   resourceCloudFlareZeroTrustGatewayPolicyRead,
+		Update: resourceCloudFlareZeroTrustGatewayPolicyUpdate,
+		Delete: resourceCloudFlareZeroTrustGatewayPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"precedence": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateGatewayPolicyAction,
+			},
+
+			"filters": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateGatewayPolicyFilter,
+				},
+				Set: schema.HashString,
+			},
+
+			"traffic": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"identity": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"device_posture": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustGatewayPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	policy := gatewayPolicyFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Gateway Policy create configuration: %#v", policy)
+
+	var created gatewayPolicy
+	if err := client.apiRequest("POST", gatewayPoliciesURI(accountID), policy, &created); err != nil {
+		return fmt.Errorf("Error creating Gateway policy %q for account %q: %s", policy.Name, accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find Gateway policy in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Gateway Policy ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustGatewayPolicyRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustGatewayPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var policy gatewayPolicy
+	err := client.apiRequest("GET", gatewayPoliciesURI(accountID)+"/"+d.Id(), nil, &policy)
+	if isNotFound(err) {
+		log.Printf("[INFO] Gateway policy %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Gateway policy %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("precedence", policy.Precedence)
+	d.Set("enabled", policy.Enabled)
+	d.Set("action", policy.Action)
+	d.Set("traffic", policy.Traffic)
+	d.Set("identity", policy.Identity)
+	d.Set("device_posture", policy.DevicePosture)
+	if err := d.Set("filters", schema.NewSet(schema.HashString, stringsToInterfaces(policy.Filters))); err != nil {
+		return fmt.Errorf("Error setting filters: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustGatewayPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	policy := gatewayPolicyFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Gateway Policy update configuration: %#v", policy)
+
+	if err := client.apiRequest("PUT", gatewayPoliciesURI(accountID)+"/"+d.Id(), policy, nil); err != nil {
+		return fmt.Errorf("Error updating Gateway policy %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustGatewayPolicyRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustGatewayPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Gateway Policy: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", gatewayPoliciesURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Gateway policy %q: %s", d.Id(), err)
+}
+
+func gatewayPoliciesURI(accountID string) string {
+	return "/accounts/" + accountID + "/gateway/rules"
+}
+
+func gatewayPolicyFromResourceData(d *schema.ResourceData) gatewayPolicy {
+	return gatewayPolicy{
+		Name:          d.Get("name").(string),
+		Description:   d.Get("description").(string),
+		Precedence:    d.Get("precedence").(int),
+		Enabled:       d.Get("enabled").(bool),
+		Action:        d.Get("action").(string),
+		Filters:       expandStringSet(d.Get("filters")),
+		Traffic:       d.Get("traffic").(string),
+		Identity:      d.Get("identity").(string),
+		DevicePosture: d.Get("device_posture").(string),
+	}
+}
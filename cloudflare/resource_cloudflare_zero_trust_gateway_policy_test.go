@@ -0,0 +1,173 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustGatewayPolicy_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_gateway_policy.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustGatewayPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustGatewayPolicyConfig, accountID, "block"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform-acctest"),
+					resource.TestCheckResourceAttr(name, "action", "block"),
+					resource.TestCheckResourceAttr(name, "precedence", "12302"),
+					resource.TestCheckResourceAttr(name, "filters.#", "1"),
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustGatewayPolicyConfig, accountID, "allow"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "action", "allow"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+// Updating a policy leaves its precedence, and that of other policies,
+// alone, even when they were created out of order.
+func TestCloudFlareZeroTrustGatewayPolicy_PrecedenceStable(t *testing.T) {
+	policy := resourceCloudFlareZeroTrustGatewayPolicy()
+
+	stored := map[string]gatewayPolicy{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri := gatewayPoliciesURI("abc")
+		id := strings.TrimPrefix(r.URL.Path, uri+"/")
+		switch {
+		case r.URL.Path == uri && r.Method == "POST":
+			var created gatewayPolicy
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("invalid policy: %s", err)
+			}
+			created.ID = fmt.Sprintf("%032x", len(stored)+1)
+			stored[created.ID] = created
+			writeTestResult(w, created)
+		case r.Method == "PUT" && stored[id].ID != "":
+			var updated gatewayPolicy
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Errorf("invalid policy: %s", err)
+			}
+			updated.ID = id
+			stored[id] = updated
+			writeTestResult(w, updated)
+		case r.Method == "GET" && stored[id].ID != "":
+			writeTestResult(w, stored[id])
+		default:
+			writeMockError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	policyConfig := func(precedence int) map[string]interface{} {
+		return map[string]interface{}{
+			"account_id": "abc",
+			"name":       "block malware",
+			"precedence": precedence,
+			"action":     "block",
+			"filters":    []interface{}{"dns"},
+			"traffic":    `any(dns.domains[*] in $malware)`,
+		}
+	}
+
+	// The policies are created with precedences out of order. Updating one
+	// must leave the precedence of both alone.
+	states := map[string]*schema.ResourceData{}
+	for name, precedence := range map[string]int{"first": 20, "second": 10} {
+		d := schema.TestResourceDataRaw(t, policy.Schema, policyConfig(precedence))
+		if err := policy.Create(d, client); err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+		states[name] = d
+	}
+
+	updated := states["first"]
+	updated.Set("description", "updated")
+	if err := policy.Update(updated, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for name, precedence := range map[string]int{"first": 20, "second": 10} {
+		d := states[name]
+		if got := d.Get("precedence"); got != precedence || stored[d.Id()].Precedence != precedence {
+			t.Fatalf("%s: expected precedence %d, got %v in the state and %d in the API", name, precedence, got, stored[d.Id()].Precedence)
+		}
+
+		c := policyConfig(precedence)
+		if name == "first" {
+			c["description"] = "updated"
+		}
+		raw, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+		diff, err := policy.Diff(d.State(), terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Fatalf("%s: expected no diff after read, got %#v", name, diff)
+		}
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustGatewayPolicyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_gateway_policy" {
+			continue
+		}
+
+		uri := gatewayPoliciesURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Gateway policy still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustGatewayPolicyConfig = `
+resource "cloudflare_zero_trust_gateway_policy" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	description = "Blocks a test domain"
+	precedence = 12302
+	action = "%s"
+	filters = ["dns"]
+	traffic = "any(dns.domains[*] == \"terraform-acctest.example.com\")"
+}`
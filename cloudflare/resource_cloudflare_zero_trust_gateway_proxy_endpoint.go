@@ -0,0 +1,143 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// teamsProxyEndpoint lets devices without the WARP client send traffic
+// through Gateway, by configuring the endpoint's subdomain as their proxy.
+// Only requests from its ips are accepted.
+type teamsProxyEndpoint struct {
+	ID        string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	IPs       []string `json:"ips"`
+	Subdomain string   `json:"subdomain,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustGatewayProxyEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustGatewayProxyEndpointCreate,
+		Read:   resourceCloudFlareZeroTrustGatewayProxyEndpointRead,
+		Update: resourceCloudFlareZeroTrustGatewayProxyEndpointUpdate,
+		Delete: resourceCloudFlareZeroTrustGatewayProxyEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ips": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCIDR,
+				},
+				Set: schema.HashString,
+			},
+
+			"subdomain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustGatewayProxyEndpointCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	endpoint := teamsProxyEndpointFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Gateway Proxy Endpoint create configuration: %#v", endpoint)
+
+	var created teamsProxyEndpoint
+	if err := client.apiRequest("POST", teamsProxyEndpointsURI(accountID), endpoint, &created); err != nil {
+		return fmt.Errorf("Error creating Gateway proxy endpoint %q for account %q: %s", endpoint.Name, accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find Gateway proxy endpoint in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Gateway Proxy Endpoint ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustGatewayProxyEndpointRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustGatewayProxyEndpointRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var endpoint teamsProxyEndpoint
+	err := client.apiRequest("GET", teamsProxyEndpointsURI(accountID)+"/"+d.Id(), nil, &endpoint)
+	if isNotFound(err) {
+		log.Printf("[INFO] Gateway proxy endpoint %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Gateway proxy endpoint %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", endpoint.Name)
+	d.Set("subdomain", endpoint.Subdomain)
+	if err := d.Set("ips", schema.NewSet(schema.HashString, stringsToInterfaces(endpoint.IPs))); err != nil {
+		return fmt.Errorf("Error setting ips: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustGatewayProxyEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	endpoint := teamsProxyEndpointFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Gateway Proxy Endpoint update configuration: %#v", endpoint)
+
+	if err := client.apiRequest("PATCH", teamsProxyEndpointsURI(accountID)+"/"+d.Id(), endpoint, nil); err != nil {
+		return fmt.Errorf("Error updating Gateway proxy endpoint %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustGatewayProxyEndpointRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustGatewayProxyEndpointDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Gateway Proxy Endpoint: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", teamsProxyEndpointsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Gateway proxy endpoint %q: %s", d.Id(), err)
+}
+
+func teamsProxyEndpointsURI(accountID string) string {
+	return "/accounts/" + accountID + "/gateway/proxy_endpoints"
+}
+
+func teamsProxyEndpointFromResourceData(d *schema.ResourceData) teamsProxyEndpoint {
+	return teamsProxyEndpoint{
+		Name: d.Get("name").(string),
+		IPs:  expandStringSet(d.Get("ips")),
+	}
+}
@@ -0,0 +1,504 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// teamsAccountConfiguration is the Gateway configuration of an account.
+type teamsAccountConfiguration struct {
+	Settings teamsAccountSettings `json:"settings"`
+}
+
+type teamsAccountSettings struct {
+	Antivirus   *teamsAntivirus `json:"antivirus,omitempty"`
+	BlockPage   *teamsBlockPage `json:"block_page,omitempty"`
+	TLSDecrypt  *teamsToggle    `json:"tls_decrypt,omitempty"`
+	ActivityLog *teamsToggle    `json:"activity_log,omitempty"`
+	FIPS        *teamsFIPS      `json:"fips,omitempty"`
+}
+
+type teamsAntivirus struct {
+	EnabledDownloadPhase bool `json:"enabled_download_phase"`
+	EnabledUploadPhase   bool `json:"enabled_upload_phase"`
+	FailClosed           bool `json:"fail_closed"`
+}
+
+type teamsBlockPage struct {
+	Enabled         bool   `json:"enabled"`
+	Name            string `json:"name,omitempty"`
+	FooterText      string `json:"footer_text,omitempty"`
+	HeaderText      string `json:"header_text,omitempty"`
+	LogoPath        string `json:"logo_path,omitempty"`
+	BackgroundColor string `json:"background_color,omitempty"`
+	MailtoAddress   string `json:"mailto_address,omitempty"`
+	MailtoSubject   string `json:"mailto_subject,omitempty"`
+}
+
+type teamsToggle struct {
+	Enabled bool `json:"enabled"`
+}
+
+type teamsFIPS struct {
+	TLS bool `json:"tls"`
+}
+
+// teamsDeviceSettings are the account-wide proxy settings of the WARP client.
+type teamsDeviceSettings struct {
+	GatewayProxyEnabled                bool `json:"gateway_proxy_enabled"`
+	GatewayUDPProxyEnabled             bool `json:"gateway_udp_proxy_enabled"`
+	RootCertificateInstallationEnabled bool `json:"root_certificate_installation_enabled"`
+}
+
+// teamsLoggingSettings control what Gateway logs for an account.
+type teamsLoggingSettings struct {
+	RedactPII          bool                                    `json:"redact_pii"`
+	SettingsByRuleType map[string]teamsLoggingRuleTypeSettings `json:"settings_by_rule_type"`
+}
+
+type teamsLoggingRuleTypeSettings struct {
+	LogAll    bool `json:"log_all"`
+	LogBlocks bool `json:"log_blocks"`
+}
+
+// teamsLoggingRuleTypes are the kinds of Gateway rules logging is configured for.
+var teamsLoggingRuleTypes = []string{"dns", "http", "l4"}
+
+func resourceCloudFlareZeroTrustGatewaySettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustGatewaySettingsUpdate,
+		Read:   resourceCloudFlareZeroTrustGatewaySettingsRead,
+		Update: resourceCloudFlareZeroTrustGatewaySettingsUpdate,
+		Delete: resourceCloudFlareZeroTrustGatewaySettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"antivirus": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled_download_phase": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"enabled_upload_phase": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"fail_closed": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"block_page": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"footer_text": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"header_text": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"logo_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"background_color": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"mailto_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"mailto_subject": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"tls_decrypt": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"activity_log": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"fips": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tls": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"proxy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tcp": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"udp": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"root_ca": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"logging": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: teamsLoggingSchema(),
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustGatewaySettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	var config teamsAccountConfiguration
+	err := client.apiRequest("GET", "/accounts/"+accountID+"/gateway/configuration", nil, &config)
+	if isNotFound(err) {
+		log.Printf("[INFO] Gateway configuration for account %s not found", accountID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Gateway configuration for account %q: %s", accountID, err)
+	}
+
+	var devices teamsDeviceSettings
+	if err := client.apiRequest("GET", "/accounts/"+accountID+"/devices/settings", nil, &devices); err != nil {
+		return fmt.Errorf("Error finding device settings for account %q: %s", accountID, err)
+	}
+
+	var logging teamsLoggingSettings
+	if err := client.apiRequest("GET", "/accounts/"+accountID+"/gateway/logging", nil, &logging); err != nil {
+		return fmt.Errorf("Error finding Gateway logging settings for account %q: %s", accountID, err)
+	}
+
+	settings := config.Settings
+	d.Set("account_id", accountID)
+
+	if settings.Antivirus != nil {
+		d.Set("antivirus", []map[string]interface{}{{
+			"enabled_download_phase": settings.Antivirus.EnabledDownloadPhase,
+			"enabled_upload_phase":   settings.Antivirus.EnabledUploadPhase,
+			"fail_closed":            settings.Antivirus.FailClosed,
+		}})
+	}
+
+	if settings.BlockPage != nil {
+		d.Set("block_page", []map[string]interface{}{{
+			"enabled":          settings.BlockPage.Enabled,
+			"name":             settings.BlockPage.Name,
+			"footer_text":      settings.BlockPage.FooterText,
+			"header_text":      settings.BlockPage.HeaderText,
+			"logo_path":        settings.BlockPage.LogoPath,
+			"background_color": settings.BlockPage.BackgroundColor,
+			"mailto_address":   settings.BlockPage.MailtoAddress,
+			"mailto_subject":   settings.BlockPage.MailtoSubject,
+		}})
+	}
+
+	if settings.TLSDecrypt != nil {
+		d.Set("tls_decrypt", []map[string]interface{}{{"enabled": settings.TLSDecrypt.Enabled}})
+	}
+
+	if settings.ActivityLog != nil {
+		d.Set("activity_log", []map[string]interface{}{{"enabled": settings.ActivityLog.Enabled}})
+	}
+
+	if settings.FIPS != nil {
+		d.Set("fips", []map[string]interface{}{{"tls": settings.FIPS.TLS}})
+	}
+
+	d.Set("proxy", []map[string]interface{}{{
+		"tcp":     devices.GatewayProxyEnabled,
+		"udp":     devices.GatewayUDPProxyEnabled,
+		"root_ca": devices.RootCertificateInstallationEnabled,
+	}})
+
+	if err := d.Set("logging", []map[string]interface{}{flattenTeamsLoggingSettings(logging)}); err != nil {
+		return fmt.Errorf("Error setting logging: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustGatewaySettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	config := teamsAccountConfiguration{Settings: expandTeamsAccountSettings(d)}
+	log.Printf("[DEBUG] CloudFlare Gateway configuration for account %s: %#v", accountID, config)
+
+	if err := client.apiRequest("PUT", "/accounts/"+accountID+"/gateway/configuration", config, nil); err != nil {
+		return fmt.Errorf("Error updating Gateway configuration for account %q: %s", accountID, err)
+	}
+
+	if proxy, ok := d.GetOk("proxy"); ok {
+		p := proxy.([]interface{})[0].(map[string]interface{})
+		devices := teamsDeviceSettings{
+			GatewayProxyEnabled:                p["tcp"].(bool),
+			GatewayUDPProxyEnabled:             p["udp"].(bool),
+			RootCertificateInstallationEnabled: p["root_ca"].(bool),
+		}
+		if err := client.apiRequest("PUT", "/accounts/"+accountID+"/devices/settings", devices, nil); err != nil {
+			return fmt.Errorf("Error updating device settings for account %q: %s", accountID, err)
+		}
+	}
+
+	if logging, ok := d.GetOk("logging"); ok {
+		settings := expandTeamsLoggingSettings(logging.([]interface{})[0].(map[string]interface{}))
+		if err := client.apiRequest("PUT", "/accounts/"+accountID+"/gateway/logging", settings, nil); err != nil {
+			return fmt.Errorf("Error updating Gateway logging settings for account %q: %s", accountID, err)
+		}
+	}
+
+	d.SetId(accountID)
+
+	return resourceCloudFlareZeroTrustGatewaySettingsRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustGatewaySettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	log.Printf("[INFO] Resetting Gateway settings for account %s to their defaults", accountID)
+
+	if err := client.apiRequest("PUT", "/accounts/"+accountID+"/gateway/configuration", teamsAccountConfiguration{}, nil); err != nil {
+		return fmt.Errorf("Error resetting Gateway configuration for account %q: %s", accountID, err)
+	}
+
+	if err := client.apiRequest("PUT", "/accounts/"+accountID+"/devices/settings", teamsDeviceSettings{}, nil); err != nil {
+		return fmt.Errorf("Error resetting device settings for account %q: %s", accountID, err)
+	}
+
+	if err := client.apiRequest("PUT", "/accounts/"+accountID+"/gateway/logging", defaultTeamsLoggingSettings(), nil); err != nil {
+		return fmt.Errorf("Error resetting Gateway logging settings for account %q: %s", accountID, err)
+	}
+
+	return nil
+}
+
+func expandTeamsAccountSettings(d *schema.ResourceData) teamsAccountSettings {
+	var settings teamsAccountSettings
+
+	if v, ok := d.GetOk("antivirus"); ok {
+		av := v.([]interface{})[0].(map[string]interface{})
+		settings.Antivirus = &teamsAntivirus{
+			EnabledDownloadPhase: av["enabled_download_phase"].(bool),
+			EnabledUploadPhase:   av["enabled_upload_phase"].(bool),
+			FailClosed:           av["fail_closed"].(bool),
+		}
+	}
+
+	if v, ok := d.GetOk("block_page"); ok {
+		bp := v.([]interface{})[0].(map[string]interface{})
+		settings.BlockPage = &teamsBlockPage{
+			Enabled:         bp["enabled"].(bool),
+			Name:            bp["name"].(string),
+			FooterText:      bp["footer_text"].(string),
+			HeaderText:      bp["header_text"].(string),
+			LogoPath:        bp["logo_path"].(string),
+			BackgroundColor: bp["background_color"].(string),
+			MailtoAddress:   bp["mailto_address"].(string),
+			MailtoSubject:   bp["mailto_subject"].(string),
+		}
+	}
+
+	if v, ok := d.GetOk("tls_decrypt"); ok {
+		settings.TLSDecrypt = &teamsToggle{Enabled: v.([]interface{})[0].(map[string]interface{})["enabled"].(bool)}
+	}
+
+	if v, ok := d.GetOk("activity_log"); ok {
+		settings.ActivityLog = &teamsToggle{Enabled: v.([]interface{})[0].(map[string]interface{})["enabled"].(bool)}
+	}
+
+	if v, ok := d.GetOk("fips"); ok {
+		settings.FIPS = &teamsFIPS{TLS: v.([]interface{})[0].(map[string]interface{})["tls"].(bool)}
+	}
+
+	return settings
+}
+
+// teamsLoggingSchema describes Gateway logging settings; it's shared by every
+// resource that manages them.
+func teamsLoggingSchema() map[string]*schema.Schema {
+	ruleType := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"log_all": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"log_blocks": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+		},
+	}
+
+	settingsByRuleType := make(map[string]*schema.Schema, len(teamsLoggingRuleTypes))
+	for _, t := range teamsLoggingRuleTypes {
+		settingsByRuleType[t] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem:     ruleType,
+		}
+	}
+
+	return map[string]*schema.Schema{
+		"redact_pii": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+
+		"settings_by_rule_type": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: settingsByRuleType,
+			},
+		},
+	}
+}
+
+func expandTeamsLoggingSettings(m map[string]interface{}) teamsLoggingSettings {
+	settings := teamsLoggingSettings{
+		RedactPII:          m["redact_pii"].(bool),
+		SettingsByRuleType: make(map[string]teamsLoggingRuleTypeSettings),
+	}
+
+	byRuleType, ok := m["settings_by_rule_type"].([]interface{})
+	if !ok || len(byRuleType) == 0 || byRuleType[0] == nil {
+		return settings
+	}
+
+	for t, v := range byRuleType[0].(map[string]interface{}) {
+		list := v.([]interface{})
+		if len(list) == 0 || list[0] == nil {
+			continue
+		}
+		rt := list[0].(map[string]interface{})
+		settings.SettingsByRuleType[t] = teamsLoggingRuleTypeSettings{
+			LogAll:    rt["log_all"].(bool),
+			LogBlocks: rt["log_blocks"].(bool),
+		}
+	}
+
+	return settings
+}
+
+func flattenTeamsLoggingSettings(settings teamsLoggingSettings) map[string]interface{} {
+	byRuleType := make(map[string]interface{})
+	for _, t := range teamsLoggingRuleTypes {
+		rt, ok := settings.SettingsByRuleType[t]
+		if !ok {
+			continue
+		}
+		byRuleType[t] = []map[string]interface{}{{
+			"log_all":    rt.LogAll,
+			"log_blocks": rt.LogBlocks,
+		}}
+	}
+
+	return map[string]interface{}{
+		"redact_pii":            settings.RedactPII,
+		"settings_by_rule_type": []map[string]interface{}{byRuleType},
+	}
+}
+
+// defaultTeamsLoggingSettings are the logging settings of a new account:
+// everything logged, nothing redacted.
+func defaultTeamsLoggingSettings() teamsLoggingSettings {
+	settings := teamsLoggingSettings{
+		SettingsByRuleType: make(map[string]teamsLoggingRuleTypeSettings),
+	}
+	for _, t := range teamsLoggingRuleTypes {
+		settings.SettingsByRuleType[t] = teamsLoggingRuleTypeSettings{LogAll: true}
+	}
+	return settings
+}
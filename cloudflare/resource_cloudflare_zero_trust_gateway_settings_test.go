@@ -0,0 +1,108 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareZeroTrustGatewaySettings_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_gateway_settings.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustGatewaySettingsConfig, accountID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttr(name, "tls_decrypt.0.enabled", "false"),
+					resource.TestCheckResourceAttr(name, "block_page.0.enabled", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustGatewaySettingsConfig, accountID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tls_decrypt.0.enabled", "true"),
+					resource.TestCheckResourceAttr(name, "block_page.0.enabled", "true"),
+					resource.TestCheckResourceAttr(name, "block_page.0.name", "terraform"),
+					resource.TestCheckResourceAttr(name, "logging.0.settings_by_rule_type.0.dns.0.log_blocks", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCheckCloudFlareZeroTrustGatewaySettingsConfig = `
+resource "cloudflare_zero_trust_gateway_settings" "foobar" {
+	account_id = "%[1]s"
+
+	tls_decrypt {
+		enabled = %[2]t
+	}
+
+	activity_log {
+		enabled = true
+	}
+
+	block_page {
+		enabled = %[2]t
+		name = "terraform"
+		footer_text = "blocked by terraform"
+		background_color = "#000000"
+	}
+
+	antivirus {
+		enabled_download_phase = true
+		fail_closed = false
+	}
+
+	logging {
+		redact_pii = true
+
+		settings_by_rule_type {
+			dns {
+				log_all = false
+				log_blocks = true
+			}
+		}
+	}
+}`
+
+func TestTeamsLoggingSettingsRoundTrip(t *testing.T) {
+	settings := teamsLoggingSettings{
+		RedactPII: true,
+		SettingsByRuleType: map[string]teamsLoggingRuleTypeSettings{
+			"dns": {LogAll: false, LogBlocks: true},
+			"l4":  {LogAll: true, LogBlocks: false},
+		},
+	}
+
+	// flatten produces []map[string]interface{}, the schema hands back []interface{}
+	flat := flattenTeamsLoggingSettings(settings)
+	byRuleType := flat["settings_by_rule_type"].([]map[string]interface{})[0]
+	for k, v := range byRuleType {
+		rt := v.([]map[string]interface{})
+		byRuleType[k] = []interface{}{rt[0]}
+	}
+	flat["settings_by_rule_type"] = []interface{}{map[string]interface{}(byRuleType)}
+
+	got := expandTeamsLoggingSettings(flat)
+	if got.RedactPII != settings.RedactPII || len(got.SettingsByRuleType) != 2 ||
+		got.SettingsByRuleType["dns"] != settings.SettingsByRuleType["dns"] ||
+		got.SettingsByRuleType["l4"] != settings.SettingsByRuleType["l4"] {
+		t.Fatalf("expected %#v, got %#v", settings, got)
+	}
+}
@@ -0,0 +1,212 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// gatewayListItemsPerPage is the page size used to read the items of a list.
+const gatewayListItemsPerPage = 50
+
+// gatewayList is a list of values, such as domains or IPs, that Gateway
+// policies can refer to.
+type gatewayList struct {
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type,omitempty"`
+	Description string            `json:"description"`
+	Items       []gatewayListItem `json:"items,omitempty"`
+}
+
+type gatewayListItem struct {
+	Value string `json:"value"`
+}
+
+// gatewayListPatch adds and removes items without replacing the list.
+type gatewayListPatch struct {
+	Append []gatewayListItem `json:"append"`
+	Remove []string          `json:"remove"`
+}
+
+func resourceCloudFlareZeroTrustList() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustListCreate,
+		Read:   resourceCloudFlareZeroTrustListRead,
+		Update: resourceCloudFlareZeroTrustListUpdate,
+		Delete: resourceCloudFlareZeroTrustListDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateZeroTrustListType,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"items": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustListCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	list := gatewayList{
+		Name:        d.Get("name").(string),
+		Type:        d.Get("type").(string),
+		Description: d.Get("description").(string),
+		Items:       gatewayListItems(expandStringSet(d.Get("items"))),
+	}
+
+	log.Printf("[DEBUG] CloudFlare Zero Trust List create configuration: %#v", list)
+
+	var created gatewayList
+	if err := client.apiRequest("POST", gatewayListsURI(accountID), list, &created); err != nil {
+		return fmt.Errorf("Error creating Zero Trust list for account %q: %s", accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find Zero Trust list in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Zero Trust List ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustListRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustListRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var list gatewayList
+	err := client.apiRequest("GET", gatewayListsURI(accountID)+"/"+d.Id(), nil, &list)
+	if isNotFound(err) {
+		log.Printf("[INFO] Zero Trust list %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Zero Trust list %q: %s", d.Id(), err)
+	}
+
+	query := url.Values{}
+	query.Set("per_page", strconv.Itoa(gatewayListItemsPerPage))
+
+	var items []string
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var batch []gatewayListItem
+		if err := client.apiRequest("GET", gatewayListsURI(accountID)+"/"+d.Id()+"/items?"+query.Encode(), nil, &batch); err != nil {
+			return fmt.Errorf("Error listing items of Zero Trust list %q: %s", d.Id(), err)
+		}
+		for _, item := range batch {
+			items = append(items, item.Value)
+		}
+
+		if len(batch) < gatewayListItemsPerPage {
+			break
+		}
+	}
+
+	d.Set("name", list.Name)
+	d.Set("type", list.Type)
+	d.Set("description", list.Description)
+	if err := d.Set("items", schema.NewSet(schema.HashString, stringsToInterfaces(items))); err != nil {
+		return fmt.Errorf("Error setting items: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustListUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	if d.HasChange("name") || d.HasChange("description") {
+		list := gatewayList{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		}
+
+		log.Printf("[DEBUG] CloudFlare Zero Trust List update configuration: %#v", list)
+
+		if err := client.apiRequest("PUT", gatewayListsURI(accountID)+"/"+d.Id(), list, nil); err != nil {
+			return fmt.Errorf("Error updating Zero Trust list %q: %s", d.Id(), err)
+		}
+	}
+
+	// Lists can hold many items, so only the changed ones are sent.
+	if d.HasChange("items") {
+		o, n := d.GetChange("items")
+		oldItems, newItems := o.(*schema.Set), n.(*schema.Set)
+		patch := gatewayListPatch{
+			Append: gatewayListItems(expandStringSet(newItems.Difference(oldItems))),
+			Remove: expandStringSet(oldItems.Difference(newItems)),
+		}
+
+		log.Printf("[DEBUG] CloudFlare Zero Trust List items update: %#v", patch)
+
+		if err := client.apiRequest("PATCH", gatewayListsURI(accountID)+"/"+d.Id(), patch, nil); err != nil {
+			return fmt.Errorf("Error updating items of Zero Trust list %q: %s", d.Id(), err)
+		}
+	}
+
+	return resourceCloudFlareZeroTrustListRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustListDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Zero Trust List: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", gatewayListsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting Zero Trust list %q: %s", d.Id(), err)
+}
+
+func gatewayListsURI(accountID string) string {
+	return "/accounts/" + accountID + "/gateway/lists"
+}
+
+func gatewayListItems(values []string) []gatewayListItem {
+	items := make([]gatewayListItem, 0, len(values))
+	for _, value := range values {
+		items = append(items, gatewayListItem{Value: value})
+	}
+	return items
+}
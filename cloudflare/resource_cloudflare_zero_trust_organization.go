@@ -0,0 +1,240 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accessOrganization is the Zero Trust organization of an account or zone:
+// its team domain, and the login page Access shows users.
+type accessOrganization struct {
+	Name                           string                   `json:"name"`
+	AuthDomain                     string                   `json:"auth_domain"`
+	IsUIReadOnly                   bool                     `json:"is_ui_read_only"`
+	UserSeatExpirationInactiveTime string                   `json:"user_seat_expiration_inactive_time,omitempty"`
+	AutoRedirectToIdentity         bool                     `json:"auto_redirect_to_identity"`
+	LoginDesign                    *accessOrganizationLogin `json:"login_design,omitempty"`
+}
+
+type accessOrganizationLogin struct {
+	BackgroundColor string `json:"background_color"`
+	TextColor       string `json:"text_color"`
+	LogoPath        string `json:"logo_path"`
+	HeaderText      string `json:"header_text"`
+	FooterText      string `json:"footer_text"`
+}
+
+func resourceCloudFlareZeroTrustOrganization() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustOrganizationCreate,
+		Read:   resourceCloudFlareZeroTrustOrganizationRead,
+		Update: resourceCloudFlareZeroTrustOrganizationUpdate,
+		Delete: resourceCloudFlareZeroTrustOrganizationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccessScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone_id"},
+			},
+
+			"zone_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"account_id"},
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"auth_domain": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"is_ui_read_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"user_seat_expiration_inactive_time": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"auto_redirect_to_identity": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"login_design": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"background_color": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"text_color": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"logo_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"header_text": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"footer_text": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustOrganizationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/organizations")
+	if err != nil {
+		return err
+	}
+
+	// Enabling Zero Trust in the dashboard creates the organization, so it
+	// is usually there to be updated already.
+	method := "PUT"
+	if err := client.apiRequest("GET", uri, nil, nil); isNotFound(err) {
+		method = "POST"
+	} else if err != nil {
+		return fmt.Errorf("Error finding Access organization: %s", err)
+	}
+
+	organization := accessOrganizationFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Access Organization create configuration: %s %#v", method, organization)
+
+	if err := client.apiRequest(method, uri, organization, nil); err != nil {
+		return fmt.Errorf("Error creating Access organization %q: %s", organization.Name, err)
+	}
+
+	if accountID, ok := d.GetOk("account_id"); ok {
+		d.SetId(accountID.(string))
+	} else {
+		d.SetId(d.Get("zone_id").(string))
+	}
+
+	log.Printf("[INFO] CloudFlare Access Organization ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustOrganizationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustOrganizationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/organizations")
+	if err != nil {
+		return err
+	}
+
+	var organization accessOrganization
+	err = client.apiRequest("GET", uri, nil, &organization)
+	if isNotFound(err) {
+		log.Printf("[INFO] Access organization %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding Access organization %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", organization.Name)
+	d.Set("auth_domain", organization.AuthDomain)
+	d.Set("is_ui_read_only", organization.IsUIReadOnly)
+	d.Set("user_seat_expiration_inactive_time", organization.UserSeatExpirationInactiveTime)
+	d.Set("auto_redirect_to_identity", organization.AutoRedirectToIdentity)
+	if err := d.Set("login_design", flattenAccessOrganizationLogin(organization.LoginDesign)); err != nil {
+		return fmt.Errorf("Error setting login_design: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustOrganizationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	uri, err := accessScopedURI(d, "/access/organizations")
+	if err != nil {
+		return err
+	}
+
+	organization := accessOrganizationFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Access Organization update configuration: %#v", organization)
+
+	if err := client.apiRequest("PUT", uri, organization, nil); err != nil {
+		return fmt.Errorf("Error updating Access organization %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustOrganizationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustOrganizationDelete(d *schema.ResourceData, meta interface{}) error {
+	// Organizations can't be deleted, only left as they are.
+	log.Printf("[INFO] Removing Access organization %s from state", d.Id())
+
+	return nil
+}
+
+func accessOrganizationFromResourceData(d *schema.ResourceData) accessOrganization {
+	organization := accessOrganization{
+		Name:                           d.Get("name").(string),
+		AuthDomain:                     d.Get("auth_domain").(string),
+		IsUIReadOnly:                   d.Get("is_ui_read_only").(bool),
+		UserSeatExpirationInactiveTime: d.Get("user_seat_expiration_inactive_time").(string),
+		AutoRedirectToIdentity:         d.Get("auto_redirect_to_identity").(bool),
+	}
+
+	if v, ok := d.GetOk("login_design"); ok {
+		m := v.([]interface{})[0].(map[string]interface{})
+		organization.LoginDesign = &accessOrganizationLogin{
+			BackgroundColor: m["background_color"].(string),
+			TextColor:       m["text_color"].(string),
+			LogoPath:        m["logo_path"].(string),
+			HeaderText:      m["header_text"].(string),
+			FooterText:      m["footer_text"].(string),
+		}
+	}
+
+	return organization
+}
+
+func flattenAccessOrganizationLogin(login *accessOrganizationLogin) []interface{} {
+	if login == nil || *login == (accessOrganizationLogin{}) {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"background_color": login.BackgroundColor,
+		"text_color":       login.TextColor,
+		"logo_path":        login.LogoPath,
+		"header_text":      login.HeaderText,
+		"footer_text":      login.FooterText,
+	}}
+}
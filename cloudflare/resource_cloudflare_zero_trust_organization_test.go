@@ -0,0 +1,60 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareZeroTrustOrganization_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	authDomain := os.Getenv("CLOUDFLARE_ACCESS_AUTH_DOMAIN")
+	name := "cloudflare_zero_trust_organization.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+			testAccPreCheckAccessAuthDomain(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustOrganizationConfig, accountID, authDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttr(name, "auth_domain", authDomain),
+					resource.TestCheckResourceAttr(name, "login_design.0.background_color", "#000000"),
+					resource.TestCheckResourceAttr(name, "login_design.0.header_text", "Terraform"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: "account/",
+			},
+		},
+	})
+}
+
+func testAccPreCheckAccessAuthDomain(t *testing.T) {
+	if v := os.Getenv("CLOUDFLARE_ACCESS_AUTH_DOMAIN"); v == "" {
+		t.Fatal("CLOUDFLARE_ACCESS_AUTH_DOMAIN must be set for this acceptance test. It is the team domain of the account, e.g. example.cloudflareaccess.com.")
+	}
+}
+
+const testAccCheckCloudFlareZeroTrustOrganizationConfig = `
+resource "cloudflare_zero_trust_organization" "foobar" {
+	account_id = "%s"
+	name = "terraform-acctest"
+	auth_domain = "%s"
+
+	login_design {
+		background_color = "#000000"
+		text_color = "#FFFFFF"
+		header_text = "Terraform"
+	}
+}`
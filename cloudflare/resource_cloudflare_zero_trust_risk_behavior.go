@@ -0,0 +1,169 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// riskBehaviors are the user behaviors that raise an account's risk scores,
+// keyed by behavior name.
+type riskBehaviors struct {
+	Behaviors map[string]riskBehavior `json:"behaviors"`
+}
+
+type riskBehavior struct {
+	Enabled   bool   `json:"enabled"`
+	RiskLevel string `json:"risk_level"`
+}
+
+// defaultRiskBehavior is what behaviors are reset to when the resource is
+// destroyed.
+var defaultRiskBehavior = riskBehavior{Enabled: false, RiskLevel: "low"}
+
+func resourceCloudFlareZeroTrustRiskBehavior() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustRiskBehaviorUpdate,
+		Read:   resourceCloudFlareZeroTrustRiskBehaviorRead,
+		Update: resourceCloudFlareZeroTrustRiskBehaviorUpdate,
+		Delete: resourceCloudFlareZeroTrustRiskBehaviorDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"behavior": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"risk_level": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateRiskLevel,
+						},
+					},
+				},
+				Set: riskBehaviorHash,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustRiskBehaviorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	var current riskBehaviors
+	err := client.apiRequest("GET", riskBehaviorsURI(accountID), nil, &current)
+	if isNotFound(err) {
+		log.Printf("[INFO] Risk behaviors for account %s not found", accountID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding risk behaviors for account %q: %s", accountID, err)
+	}
+
+	// Only the behaviors in the configuration are managed, unless this is
+	// an import and there is no configuration yet.
+	managed := make(map[string]bool)
+	for _, v := range d.Get("behavior").(*schema.Set).List() {
+		managed[v.(map[string]interface{})["name"].(string)] = true
+	}
+
+	behaviors := make([]interface{}, 0, len(current.Behaviors))
+	for name, behavior := range current.Behaviors {
+		if len(managed) > 0 && !managed[name] {
+			continue
+		}
+		behaviors = append(behaviors, map[string]interface{}{
+			"name":       name,
+			"enabled":    behavior.Enabled,
+			"risk_level": behavior.RiskLevel,
+		})
+	}
+
+	d.Set("account_id", accountID)
+	if err := d.Set("behavior", schema.NewSet(riskBehaviorHash, behaviors)); err != nil {
+		return fmt.Errorf("Error setting behavior: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustRiskBehaviorUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	update := riskBehaviors{Behaviors: make(map[string]riskBehavior)}
+
+	// Behaviors removed from the configuration go back to their defaults.
+	if d.HasChange("behavior") {
+		o, _ := d.GetChange("behavior")
+		for _, v := range o.(*schema.Set).List() {
+			update.Behaviors[v.(map[string]interface{})["name"].(string)] = defaultRiskBehavior
+		}
+	}
+
+	for _, v := range d.Get("behavior").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		update.Behaviors[m["name"].(string)] = riskBehavior{
+			Enabled:   m["enabled"].(bool),
+			RiskLevel: m["risk_level"].(string),
+		}
+	}
+
+	log.Printf("[DEBUG] CloudFlare risk behaviors for account %s: %#v", accountID, update)
+
+	if err := client.apiRequest("PUT", riskBehaviorsURI(accountID), update, nil); err != nil {
+		return fmt.Errorf("Error updating risk behaviors for account %q: %s", accountID, err)
+	}
+
+	d.SetId(accountID)
+
+	return resourceCloudFlareZeroTrustRiskBehaviorRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustRiskBehaviorDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Id()
+
+	log.Printf("[INFO] Resetting risk behaviors for account %s to their defaults", accountID)
+
+	reset := riskBehaviors{Behaviors: make(map[string]riskBehavior)}
+	for _, v := range d.Get("behavior").(*schema.Set).List() {
+		reset.Behaviors[v.(map[string]interface{})["name"].(string)] = defaultRiskBehavior
+	}
+
+	if err := client.apiRequest("PUT", riskBehaviorsURI(accountID), reset, nil); err != nil {
+		return fmt.Errorf("Error resetting risk behaviors for account %q: %s", accountID, err)
+	}
+
+	return nil
+}
+
+func riskBehaviorsURI(accountID string) string {
+	return "/accounts/" + accountID + "/zt_risk_scoring/behaviors"
+}
+
+func riskBehaviorHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s-%t-%s", m["name"].(string), m["enabled"].(bool), m["risk_level"].(string)))
+}
@@ -0,0 +1,47 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareZeroTrustRiskBehavior_High(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_risk_behavior.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustRiskBehaviorConfig, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttr(name, "behavior.#", "1"),
+					resource.TestCheckResourceAttr(name, fmt.Sprintf("behavior.%d.risk_level", riskBehaviorHash(map[string]interface{}{
+						"name":       "imp_travel",
+						"enabled":    true,
+						"risk_level": "high",
+					})), "high"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckCloudFlareZeroTrustRiskBehaviorConfig = `
+resource "cloudflare_zero_trust_risk_behavior" "foobar" {
+	account_id = "%s"
+
+	behavior {
+		name = "imp_travel"
+		enabled = true
+		risk_level = "high"
+	}
+}`
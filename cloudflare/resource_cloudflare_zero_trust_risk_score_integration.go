@@ -0,0 +1,162 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// riskScoreIntegration shares the risk scores of an account's users with
+// an identity provider.
+type riskScoreIntegration struct {
+	ID              string `json:"id,omitempty"`
+	IntegrationType string `json:"integration_type,omitempty"`
+	TenantURL       string `json:"tenant_url"`
+	ReferenceID     string `json:"reference_id,omitempty"`
+	Active          *bool  `json:"active,omitempty"`
+	WellKnownURL    string `json:"well_known_url,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustRiskScoreIntegration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustRiskScoreIntegrationCreate,
+		Read:   resourceCloudFlareZeroTrustRiskScoreIntegrationRead,
+		Update: resourceCloudFlareZeroTrustRiskScoreIntegrationUpdate,
+		Delete: resourceCloudFlareZeroTrustRiskScoreIntegrationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"integration_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRiskScoreIntegrationType,
+			},
+
+			"tenant_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"reference_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"well_known_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustRiskScoreIntegrationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	// Integrations are always created active.
+	integration := riskScoreIntegration{
+		IntegrationType: d.Get("integration_type").(string),
+		TenantURL:       d.Get("tenant_url").(string),
+		ReferenceID:     d.Get("reference_id").(string),
+	}
+	log.Printf("[DEBUG] CloudFlare Risk Score Integration create configuration: %#v", integration)
+
+	var created riskScoreIntegration
+	if err := client.apiRequest("POST", riskScoreIntegrationsURI(accountID), integration, &created); err != nil {
+		return fmt.Errorf("Error creating risk score integration for account %q: %s", accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find risk score integration in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Risk Score Integration ID: %s", d.Id())
+
+	if !d.Get("active").(bool) {
+		return resourceCloudFlareZeroTrustRiskScoreIntegrationUpdate(d, meta)
+	}
+
+	return resourceCloudFlareZeroTrustRiskScoreIntegrationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustRiskScoreIntegrationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var integration riskScoreIntegration
+	err := client.apiRequest("GET", riskScoreIntegrationsURI(accountID)+"/"+d.Id(), nil, &integration)
+	if isNotFound(err) {
+		log.Printf("[INFO] Risk score integration %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding risk score integration %q: %s", d.Id(), err)
+	}
+
+	d.Set("integration_type", integration.IntegrationType)
+	d.Set("tenant_url", integration.TenantURL)
+	d.Set("reference_id", integration.ReferenceID)
+	d.Set("well_known_url", integration.WellKnownURL)
+	if integration.Active != nil {
+		d.Set("active", *integration.Active)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustRiskScoreIntegrationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	active := d.Get("active").(bool)
+	integration := riskScoreIntegration{
+		TenantURL:   d.Get("tenant_url").(string),
+		ReferenceID: d.Get("reference_id").(string),
+		Active:      &active,
+	}
+	log.Printf("[DEBUG] CloudFlare Risk Score Integration update configuration: %#v", integration)
+
+	if err := client.apiRequest("PUT", riskScoreIntegrationsURI(accountID)+"/"+d.Id(), integration, nil); err != nil {
+		return fmt.Errorf("Error updating risk score integration %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustRiskScoreIntegrationRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustRiskScoreIntegrationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Risk Score Integration: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", riskScoreIntegrationsURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting risk score integration %q: %s", d.Id(), err)
+}
+
+func riskScoreIntegrationsURI(accountID string) string {
+	return "/accounts/" + accountID + "/zt_risk_scoring/integrations"
+}
@@ -0,0 +1,142 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustRiskScoreIntegration_Deactivate(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_risk_score_integration.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustRiskScoreIntegrationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustRiskScoreIntegrationConfig, accountID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "integration_type", "Okta"),
+					resource.TestCheckResourceAttr(name, "tenant_url", "https://terraform.okta.com"),
+					resource.TestCheckResourceAttr(name, "active", "true"),
+					resource.TestCheckResourceAttrSet(name, "reference_id"),
+					resource.TestCheckResourceAttrSet(name, "well_known_url"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustRiskScoreIntegrationConfig, accountID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "active", "false"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func TestCloudFlareZeroTrustRiskScoreIntegrationCreate_Inactive(t *testing.T) {
+	stored := riskScoreIntegration{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri := riskScoreIntegrationsURI("abc")
+		switch {
+		case r.URL.Path == uri && r.Method == "POST":
+			var created riskScoreIntegration
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("invalid integration: %s", err)
+			}
+			if created.Active != nil {
+				t.Errorf("expected active not to be sent on create, got %v", *created.Active)
+			}
+			active := true
+			stored = riskScoreIntegration{
+				ID:              "1234",
+				IntegrationType: created.IntegrationType,
+				TenantURL:       created.TenantURL,
+				ReferenceID:     "5678",
+				Active:          &active,
+				WellKnownURL:    "https://example.cloudflareaccess.com/.well-known/sse/1234",
+			}
+			writeTestResult(w, stored)
+		case r.URL.Path == uri+"/1234" && r.Method == "PUT":
+			var updated riskScoreIntegration
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Errorf("invalid integration: %s", err)
+			}
+			stored.Active = updated.Active
+			stored.TenantURL = updated.TenantURL
+			writeTestResult(w, stored)
+		case r.URL.Path == uri+"/1234" && r.Method == "GET":
+			writeTestResult(w, stored)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareZeroTrustRiskScoreIntegration().Schema, map[string]interface{}{
+		"account_id":       "abc",
+		"integration_type": "Okta",
+		"tenant_url":       "https://example.okta.com",
+		"active":           false,
+	})
+	if err := resourceCloudFlareZeroTrustRiskScoreIntegrationCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Get("active").(bool) {
+		t.Fatalf("expected the integration to be deactivated after create")
+	}
+	if got := d.Get("reference_id"); got != "5678" {
+		t.Fatalf("expected reference_id %q, got %v", "5678", got)
+	}
+	if got := d.Get("well_known_url"); got != stored.WellKnownURL {
+		t.Fatalf("expected well_known_url %q, got %v", stored.WellKnownURL, got)
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustRiskScoreIntegrationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_risk_score_integration" {
+			continue
+		}
+
+		uri := riskScoreIntegrationsURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Risk score integration still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZeroTrustRiskScoreIntegrationConfig = `
+resource "cloudflare_zero_trust_risk_score_integration" "foobar" {
+	account_id = "%s"
+	integration_type = "Okta"
+	tenant_url = "https://terraform.okta.com"
+	active = %t
+}`
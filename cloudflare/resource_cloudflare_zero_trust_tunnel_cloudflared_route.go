@@ -0,0 +1,220 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// tunnelRoute sends traffic for a private network to a Cloudflare Tunnel.
+// Routes of different virtual networks may overlap.
+type tunnelRoute struct {
+	ID               string `json:"id,omitempty"`
+	Network          string `json:"network"`
+	TunnelID         string `json:"tunnel_id"`
+	Comment          string `json:"comment"`
+	VirtualNetworkID string `json:"virtual_network_id,omitempty"`
+}
+
+func resourceCloudFlareZeroTrustTunnelCloudflaredRoute() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustTunnelCloudflaredRouteCreate,
+		Read:   resourceCloudFlareZeroTrustTunnelCloudflaredRouteRead,
+		Update: resourceCloudFlareZeroTrustTunnelCloudflaredRouteUpdate,
+		Delete: resourceCloudFlareZeroTrustTunnelCloudflaredRouteDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCloudFlareZeroTrustTunnelCloudflaredRouteImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"tunnel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDR,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"virtual_network_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustTunnelCloudflaredRouteCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	route := tunnelRouteFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Tunnel Route create configuration: %#v", route)
+
+	var created tunnelRoute
+	err := client.apiRequest("POST", tunnelRoutesURI(accountID), route, &created)
+	if isConflict(err) {
+		return tunnelRouteConflictError(route, err)
+	}
+	if err != nil {
+		return fmt.Errorf("Error creating tunnel route for account %q: %s", accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find tunnel route in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Tunnel Route ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustTunnelCloudflaredRouteRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustTunnelCloudflaredRouteRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var route tunnelRoute
+	err := client.apiRequest("GET", tunnelRoutesURI(accountID)+"/"+d.Id(), nil, &route)
+	if isNotFound(err) {
+		log.Printf("[INFO] Tunnel route %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding tunnel route %q: %s", d.Id(), err)
+	}
+
+	d.Set("tunnel_id", route.TunnelID)
+	d.Set("network", route.Network)
+	d.Set("comment", route.Comment)
+	d.Set("virtual_network_id", route.VirtualNetworkID)
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustTunnelCloudflaredRouteUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	route := tunnelRouteFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Tunnel Route update configuration: %#v", route)
+
+	err := client.apiRequest("PATCH", tunnelRoutesURI(accountID)+"/"+d.Id(), route, nil)
+	if isConflict(err) {
+		return tunnelRouteConflictError(route, err)
+	}
+	if err != nil {
+		return fmt.Errorf("Error updating tunnel route %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustTunnelCloudflaredRouteRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustTunnelCloudflaredRouteDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Tunnel Route: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", tunnelRoutesURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting tunnel route %q: %s", d.Id(), err)
+}
+
+// resourceCloudFlareZeroTrustTunnelCloudflaredRouteImport imports routes by
+// what identifies them to users, "account_id/network/virtual_network_id",
+// e.g. "1d5fdc9e88c8a8c4518b068cd94331fe/10.0.0.0/16/0f4c8e5e". The
+// virtual network can be left out for routes of the default network.
+func resourceCloudFlareZeroTrustTunnelCloudflaredRouteImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*CloudFlareClient)
+
+	accountID, network, virtualNetworkID, err := parseTunnelRouteImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("is_deleted", "false")
+	query.Set("network_subset", network)
+	query.Set("network_superset", network)
+	if virtualNetworkID != "" {
+		query.Set("virtual_network_id", virtualNetworkID)
+	}
+
+	var routes []tunnelRoute
+	if err := client.apiRequest("GET", tunnelRoutesURI(accountID)+"?"+query.Encode(), nil, &routes); err != nil {
+		return nil, fmt.Errorf("Error listing tunnel routes for account %q: %s", accountID, err)
+	}
+
+	for _, route := range routes {
+		if route.Network == network && (virtualNetworkID == "" || route.VirtualNetworkID == virtualNetworkID) {
+			d.Set("account_id", accountID)
+			d.SetId(route.ID)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("No tunnel route for network %q found in account %q", network, accountID)
+}
+
+func parseTunnelRouteImportID(id string) (accountID, network, virtualNetworkID string, err error) {
+	// The network is a CIDR, so contains a slash of its own.
+	tokens := strings.Split(id, "/")
+	switch len(tokens) {
+	case 3:
+		accountID, network = tokens[0], tokens[1]+"/"+tokens[2]
+	case 4:
+		accountID, network, virtualNetworkID = tokens[0], tokens[1]+"/"+tokens[2], tokens[3]
+	default:
+		return "", "", "", fmt.Errorf("expecting account_id/network/virtual_network_id, got %q", id)
+	}
+
+	if accountID == "" {
+		return "", "", "", fmt.Errorf("expecting account_id/network/virtual_network_id, got %q", id)
+	}
+	return accountID, network, virtualNetworkID, nil
+}
+
+func tunnelRoutesURI(accountID string) string {
+	return "/accounts/" + accountID + "/teamnet/routes"
+}
+
+func tunnelRouteFromResourceData(d *schema.ResourceData) tunnelRoute {
+	return tunnelRoute{
+		Network:          d.Get("network").(string),
+		TunnelID:         d.Get("tunnel_id").(string),
+		Comment:          d.Get("comment").(string),
+		VirtualNetworkID: d.Get("virtual_network_id").(string),
+	}
+}
+
+// tunnelRouteConflictError explains the API's rejection of a route that
+// overlaps another route of the same virtual network.
+func tunnelRouteConflictError(route tunnelRoute, err error) error {
+	virtualNetwork := "the default virtual network"
+	if route.VirtualNetworkID != "" {
+		virtualNetwork = fmt.Sprintf("virtual network %q", route.VirtualNetworkID)
+	}
+	return fmt.Errorf("Network %s overlaps a route that already exists in %s. Routes of the same virtual network "+
+		"can't overlap; use a different virtual network for overlapping private networks: %s", route.Network, virtualNetwork, err)
+}
@@ -0,0 +1,106 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustTunnelCloudflaredRoute_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	tunnelID := os.Getenv("CLOUDFLARE_TUNNEL_ID")
+	name := "cloudflare_zero_trust_tunnel_cloudflared_route.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+			testAccPreCheckTunnel(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustTunnelCloudflaredRouteDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustTunnelCloudflaredRouteConfig, accountID, tunnelID, "10.250.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "network", "10.250.0.0/16"),
+					resource.TestCheckResourceAttr(name, "tunnel_id", tunnelID),
+					resource.TestCheckResourceAttr(name, "comment", "terraform"),
+					resource.TestCheckResourceAttrSet(name, "virtual_network_id"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustTunnelCloudflaredRouteConfig, accountID, tunnelID, "10.251.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "network", "10.251.0.0/16"),
+				),
+			},
+			resource.TestStep{
+				// The route is in the default virtual network, which the
+				// import ID can leave out.
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     accountID + "/10.251.0.0/16",
+			},
+		},
+	})
+}
+
+func testAccPreCheckTunnel(t *testing.T) {
+	if v := os.Getenv("CLOUDFLARE_TUNNEL_ID"); v == "" {
+		t.Fatal("CLOUDFLARE_TUNNEL_ID must be set for this acceptance test")
+	}
+}
+
+func testAccCheckCloudFlareZeroTrustTunnelCloudflaredRouteDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_tunnel_cloudflared_route" {
+			continue
+		}
+
+		uri := tunnelRoutesURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Tunnel route still exists")
+		}
+	}
+
+	return nil
+}
+
+func TestParseTunnelRouteImportID(t *testing.T) {
+	cases := []struct {
+		id, accountID, network, virtualNetworkID string
+		valid                                    bool
+	}{
+		{"account/10.0.0.0/16/vnet", "account", "10.0.0.0/16", "vnet", true},
+		{"account/10.0.0.0/16", "account", "10.0.0.0/16", "", true},
+		{"account/2001:db8::/48/vnet", "account", "2001:db8::/48", "vnet", true},
+		{"account/10.0.0.0", "", "", "", false},
+		{"/10.0.0.0/16/vnet", "", "", "", false},
+	}
+
+	for _, c := range cases {
+		accountID, network, virtualNetworkID, err := parseTunnelRouteImportID(c.id)
+		if c.valid != (err == nil) {
+			t.Fatalf("%s: unexpected result %v", c.id, err)
+		}
+		if accountID != c.accountID || network != c.network || virtualNetworkID != c.virtualNetworkID {
+			t.Fatalf("%s: expected %s, %s, %s, got %s, %s, %s", c.id,
+				c.accountID, c.network, c.virtualNetworkID, accountID, network, virtualNetworkID)
+		}
+	}
+}
+
+const testAccCheckCloudFlareZeroTrustTunnelCloudflaredRouteConfig = `
+resource "cloudflare_zero_trust_tunnel_cloudflared_route" "foobar" {
+	account_id = "%s"
+	tunnel_id = "%s"
+	network = "%s"
+	comment = "terraform"
+}`
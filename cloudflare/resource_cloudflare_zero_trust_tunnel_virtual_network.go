@@ -0,0 +1,194 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// tunnelVirtualNetwork separates tunnel routes, so that private networks
+// with overlapping addresses can be routed. Every account has exactly one
+// default virtual network, which routes without one belong to.
+type tunnelVirtualNetwork struct {
+	ID               string `json:"id,omitempty"`
+	Name             string `json:"name"`
+	Comment          string `json:"comment"`
+	IsDefaultNetwork bool   `json:"is_default_network"`
+}
+
+func resourceCloudFlareZeroTrustTunnelVirtualNetwork() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZeroTrustTunnelVirtualNetworkCreate,
+		Read:   resourceCloudFlareZeroTrustTunnelVirtualNetworkRead,
+		Update: resourceCloudFlareZeroTrustTunnelVirtualNetworkUpdate,
+		Delete: resourceCloudFlareZeroTrustTunnelVirtualNetworkDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAccountScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"is_default_network": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZeroTrustTunnelVirtualNetworkCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	network := tunnelVirtualNetworkFromResourceData(d)
+	if network.IsDefaultNetwork {
+		if err := checkDefaultVirtualNetwork(client, accountID, ""); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[DEBUG] CloudFlare Tunnel Virtual Network create configuration: %#v", network)
+
+	// Creating a network takes is_default rather than is_default_network.
+	params := map[string]interface{}{
+		"name":       network.Name,
+		"comment":    network.Comment,
+		"is_default": network.IsDefaultNetwork,
+	}
+
+	var created tunnelVirtualNetwork
+	if err := client.apiRequest("POST", tunnelVirtualNetworksURI(accountID), params, &created); err != nil {
+		return fmt.Errorf("Error creating tunnel virtual network for account %q: %s", accountID, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find tunnel virtual network in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Tunnel Virtual Network ID: %s", d.Id())
+
+	return resourceCloudFlareZeroTrustTunnelVirtualNetworkRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustTunnelVirtualNetworkRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	var network tunnelVirtualNetwork
+	err := client.apiRequest("GET", tunnelVirtualNetworksURI(accountID)+"/"+d.Id(), nil, &network)
+	if isNotFound(err) {
+		log.Printf("[INFO] Tunnel virtual network %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding tunnel virtual network %q: %s", d.Id(), err)
+	}
+
+	d.Set("name", network.Name)
+	d.Set("comment", network.Comment)
+	d.Set("is_default_network", network.IsDefaultNetwork)
+
+	return nil
+}
+
+func resourceCloudFlareZeroTrustTunnelVirtualNetworkUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	network := tunnelVirtualNetworkFromResourceData(d)
+	if d.HasChange("is_default_network") {
+		if !network.IsDefaultNetwork {
+			return fmt.Errorf("Tunnel virtual network %q can't stop being the default network, as an account always has one. "+
+				"Set is_default_network on the network that should replace it instead", d.Id())
+		}
+		if err := checkDefaultVirtualNetwork(client, accountID, d.Id()); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[DEBUG] CloudFlare Tunnel Virtual Network update configuration: %#v", network)
+
+	if err := client.apiRequest("PATCH", tunnelVirtualNetworksURI(accountID)+"/"+d.Id(), network, nil); err != nil {
+		return fmt.Errorf("Error updating tunnel virtual network %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZeroTrustTunnelVirtualNetworkRead(d, meta)
+}
+
+func resourceCloudFlareZeroTrustTunnelVirtualNetworkDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	accountID := d.Get("account_id").(string)
+
+	// The API rejects deleting the default network with a less helpful error.
+	if d.Get("is_default_network").(bool) {
+		return fmt.Errorf("Tunnel virtual network %q is the account's default network, which can't be deleted. "+
+			"Make another virtual network the default first", d.Id())
+	}
+
+	log.Printf("[INFO] Deleting CloudFlare Tunnel Virtual Network: %s, %s", accountID, d.Id())
+
+	err := client.apiRequest("DELETE", tunnelVirtualNetworksURI(accountID)+"/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting tunnel virtual network %q: %s", d.Id(), err)
+}
+
+func tunnelVirtualNetworksURI(accountID string) string {
+	return "/accounts/" + accountID + "/teamnet/virtual_networks"
+}
+
+func tunnelVirtualNetworkFromResourceData(d *schema.ResourceData) tunnelVirtualNetwork {
+	return tunnelVirtualNetwork{
+		Name:             d.Get("name").(string),
+		Comment:          d.Get("comment").(string),
+		IsDefaultNetwork: d.Get("is_default_network").(bool),
+	}
+}
+
+// checkDefaultVirtualNetwork warns when making a network the default takes
+// that away from another network. An account has exactly one default, so two
+// networks configured as the default take it from each other on every apply.
+func checkDefaultVirtualNetwork(client *CloudFlareClient, accountID, networkID string) error {
+	var networks []tunnelVirtualNetwork
+	query := "?is_deleted=false&is_default=true"
+	if err := client.apiRequest("GET", tunnelVirtualNetworksURI(accountID)+query, nil, &networks); err != nil {
+		return fmt.Errorf("Error listing tunnel virtual networks for account %q: %s", accountID, err)
+	}
+
+	if current, ok := findDefaultVirtualNetwork(networks, networkID); ok {
+		log.Printf("[WARN] Tunnel virtual network %q (%s) stops being the default network of account %q. "+
+			"If it is managed by Terraform, set its is_default_network to false",
+			current.Name, current.ID, accountID)
+	}
+	return nil
+}
+
+func findDefaultVirtualNetwork(networks []tunnelVirtualNetwork, networkID string) (tunnelVirtualNetwork, bool) {
+	for _, network := range networks {
+		if network.IsDefaultNetwork && network.ID != networkID {
+			return network, true
+		}
+	}
+	return tunnelVirtualNetwork{}, false
+}
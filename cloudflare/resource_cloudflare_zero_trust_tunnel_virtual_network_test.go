@@ -0,0 +1,84 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZeroTrustTunnelVirtualNetwork_Basic(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	name := "cloudflare_zero_trust_tunnel_virtual_network.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZeroTrustTunnelVirtualNetworkDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustTunnelVirtualNetworkConfig, accountID, "staging"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "terraform"),
+					resource.TestCheckResourceAttr(name, "comment", "staging"),
+					resource.TestCheckResourceAttr(name, "is_default_network", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZeroTrustTunnelVirtualNetworkConfig, accountID, "production"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "comment", "production"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: accountID + "/",
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFlareZeroTrustTunnelVirtualNetworkDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zero_trust_tunnel_virtual_network" {
+			continue
+		}
+
+		uri := tunnelVirtualNetworksURI(rs.Primary.Attributes["account_id"]) + "/" + rs.Primary.ID
+		if err := client.apiRequest("GET", uri, nil, nil); err == nil {
+			return fmt.Errorf("Tunnel virtual network still exists")
+		}
+	}
+
+	return nil
+}
+
+func TestFindDefaultVirtualNetwork(t *testing.T) {
+	networks := []tunnelVirtualNetwork{
+		{ID: "a", Name: "a"},
+		{ID: "b", Name: "b", IsDefaultNetwork: true},
+	}
+
+	if network, ok := findDefaultVirtualNetwork(networks, "a"); !ok || network.ID != "b" {
+		t.Fatalf("expected b to be found as the current default, got %#v", network)
+	}
+	if _, ok := findDefaultVirtualNetwork(networks, "b"); ok {
+		t.Fatal("a network already the default shouldn't conflict with itself")
+	}
+}
+
+const testAccCheckCloudFlareZeroTrustTunnelVirtualNetworkConfig = `
+resource "cloudflare_zero_trust_tunnel_virtual_network" "foobar" {
+	account_id = "%s"
+	name = "terraform"
+	comment = "%s"
+}`
@@ -0,0 +1,299 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// zoneExistsErrorCode is returned when creating a zone for a domain that is
+// already a zone.
+const zoneExistsErrorCode = 1061
+
+// zone is a zone as it is created and read. cloudflare-go's CreateZone
+// can't set the account or type of a zone.
+type zone struct {
+	ID              string               `json:"id,omitempty"`
+	Name            string               `json:"name"`
+	Account         *zoneAccount         `json:"account,omitempty"`
+	JumpStart       bool                 `json:"jump_start,omitempty"`
+	Type            string               `json:"type,omitempty"`
+	Status          string               `json:"status,omitempty"`
+	NameServers     []string             `json:"name_servers,omitempty"`
+	VerificationKey string               `json:"verification_key,omitempty"`
+	Plan            *cloudflare.ZonePlan `json:"plan,omitempty"`
+}
+
+type zoneAccount struct {
+	ID string `json:"id"`
+}
+
+func resourceCloudFlareZone() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZoneCreate,
+		Read:   resourceCloudFlareZoneRead,
+		Update: resourceCloudFlareZoneUpdate,
+		Delete: resourceCloudFlareZoneDelete,
+		Importer: &schema.ResourceImporter{
+			State: importZone,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"jump_start": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"plan": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateZoneRatePlan,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "full",
+				ValidateFunc: validateZoneType,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name_servers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"verification_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZoneCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	name := d.Get("zone").(string)
+
+	z := zone{
+		Name:      name,
+		JumpStart: d.Get("jump_start").(bool),
+		Type:      d.Get("type").(string),
+	}
+	if accountID, ok := d.GetOk("account_id"); ok {
+		z.Account = &zoneAccount{ID: accountID.(string)}
+	}
+	log.Printf("[DEBUG] CloudFlare Zone create configuration: %#v", z)
+
+	var created zone
+	err := client.apiRequest("POST", "/zones", z, &created)
+	if hasErrorCode(err, zoneExistsErrorCode) {
+		return zoneExistsError(client, name, err)
+	}
+	if err != nil {
+		return fmt.Errorf("Error creating zone %q: %s", name, err)
+	}
+
+	if created.ID == "" {
+		return fmt.Errorf("Failed to find zone in create response; ID was empty")
+	}
+
+	d.SetId(created.ID)
+
+	log.Printf("[INFO] CloudFlare Zone ID: %s", d.Id())
+
+	if plan, ok := d.GetOk("plan"); ok && (created.Plan == nil || plan.(string) != created.Plan.LegacyID) {
+		if err := client.saveZoneSubscription(d.Id(), zoneSubscription{RatePlan: zoneRatePlan{ID: plan.(string)}}); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudFlareZoneRead(d, meta)
+}
+
+func resourceCloudFlareZoneRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	var z zone
+	err := client.apiRequest("GET", "/zones/"+d.Id(), nil, &z)
+	if isNotFound(err) {
+		log.Printf("[INFO] Zone %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding zone %q: %s", d.Id(), err)
+	}
+
+	d.Set("zone", z.Name)
+	if z.Account != nil {
+		d.Set("account_id", z.Account.ID)
+	}
+	if z.Plan != nil {
+		d.Set("plan", z.Plan.LegacyID)
+	}
+	d.Set("type", z.Type)
+	d.Set("status", z.Status)
+	d.Set("verification_key", z.VerificationKey)
+	if err := d.Set("name_servers", z.NameServers); err != nil {
+		return fmt.Errorf("Error setting name_servers: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZoneUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	if d.HasChange("type") {
+		z := map[string]interface{}{"type": d.Get("type").(string)}
+		if err := client.apiRequest("PATCH", "/zones/"+d.Id(), z, nil); err != nil {
+			return fmt.Errorf("Error updating type of zone %q: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("plan") {
+		sub := zoneSubscription{RatePlan: zoneRatePlan{ID: d.Get("plan").(string)}}
+		if err := client.saveZoneSubscription(d.Id(), sub); err != nil {
+			return err
+		}
+	}
+
+	// jump_start only applies when the zone is created, and force_destroy
+	// when it is deleted, so changing them needs no request.
+	return resourceCloudFlareZoneRead(d, meta)
+}
+
+func resourceCloudFlareZoneDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	name := d.Get("zone").(string)
+
+	log.Printf("[INFO] Deleting CloudFlare Zone: %s, %s", name, d.Id())
+
+	records, sub, err := zoneDependents(client, d.Id())
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding what zone %q still holds: %s", name, err)
+	}
+
+	if len(records) > 0 || sub != nil {
+		if !d.Get("force_destroy").(bool) {
+			return zoneDependentsError(name, records, sub)
+		}
+		if err := deleteZoneDependents(client, d.Id(), records, sub); err != nil {
+			return fmt.Errorf("Error deleting what zone %q holds: %s", name, err)
+		}
+	}
+
+	err = client.apiRequest("DELETE", "/zones/"+d.Id(), nil, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error deleting zone %q: %s", d.Id(), err)
+}
+
+// importZone imports a zone by its ID. force_destroy isn't read from the API,
+// so it is set to its default, which imported zones would otherwise lack
+// until the next apply.
+func importZone(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("force_destroy", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// zoneDependents returns what would be lost with a zone: its DNS records,
+// and its subscription if it is on a paid plan.
+func zoneDependents(client *CloudFlareClient, zoneID string) ([]cloudflare.DNSRecord, *zoneSubscription, error) {
+	records, err := client.listDNSRecords(zoneID, url.Values{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sub zoneSubscription
+	err = client.apiRequest("GET", "/zones/"+zoneID+"/subscription", nil, &sub)
+	if err != nil && !isNotFound(err) {
+		return nil, nil, err
+	}
+	if err != nil || sub.ID == "" || sub.RatePlan.ID == "free" {
+		return records, nil, nil
+	}
+	return records, &sub, nil
+}
+
+// zoneDependentsError lists what keeps a zone from being deleted without
+// force_destroy.
+func zoneDependentsError(name string, records []cloudflare.DNSRecord, sub *zoneSubscription) error {
+	var blocking []string
+	if len(records) > 0 {
+		described := make([]string, 0, len(records))
+		for _, record := range records {
+			described = append(described, fmt.Sprintf("%s %s", record.Type, record.Name))
+		}
+		blocking = append(blocking, fmt.Sprintf("%d DNS records (%s)", len(records), strings.Join(described, ", ")))
+	}
+	if sub != nil {
+		blocking = append(blocking, fmt.Sprintf("a %q plan subscription", sub.RatePlan.ID))
+	}
+	return fmt.Errorf("Zone %q still has %s. Remove them, or set force_destroy to delete them along with the zone",
+		name, strings.Join(blocking, " and "))
+}
+
+// deleteZoneDependents deletes the records of a zone, and moves it back to
+// the free plan, ahead of deleting it.
+func deleteZoneDependents(client *CloudFlareClient, zoneID string, records []cloudflare.DNSRecord, sub *zoneSubscription) error {
+	for _, record := range records {
+		log.Printf("[INFO] Deleting CloudFlare Record %s %s along with its zone", record.Type, record.Name)
+		if err := client.deleteDNSRecord(zoneID, record.ID); err != nil && !isRecordNotFound(err) {
+			return fmt.Errorf("Error deleting record %q: %s", record.ID, err)
+		}
+	}
+
+	if sub != nil {
+		log.Printf("[INFO] Cancelling the %q plan subscription of zone %s", sub.RatePlan.ID, zoneID)
+		if err := client.saveZoneSubscription(zoneID, zoneSubscription{RatePlan: zoneRatePlan{ID: "free"}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zoneExistsError explains that a zone can't be created because the domain
+// is already a zone, and how to manage that zone instead.
+func zoneExistsError(client *CloudFlareClient, name string, err error) error {
+	if zoneID, lookupErr := client.ZoneIDByName(name); lookupErr == nil {
+		return fmt.Errorf("Zone %q already exists with ID %q. Import it with "+
+			"`terraform import cloudflare_zone.<name> %s` to manage it: %s", name, zoneID, zoneID, err)
+	}
+	return fmt.Errorf("Zone %q already exists, in this or another CloudFlare account: %s", name, err)
+}
@@ -0,0 +1,170 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// zoneDNSSEC is the DNSSEC setting of a zone.
+type zoneDNSSEC struct {
+	Status     string `json:"status"`
+	Flags      int    `json:"flags"`
+	Algorithm  string `json:"algorithm"`
+	KeyType    string `json:"key_type"`
+	DigestType string `json:"digest_type"`
+	Digest     string `json:"digest"`
+	DS         string `json:"ds"`
+	KeyTag     int    `json:"key_tag"`
+	PublicKey  string `json:"public_key"`
+}
+
+func resourceCloudFlareZoneDNSSEC() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZoneDNSSECCreate,
+		Read:   resourceCloudFlareZoneDNSSECRead,
+		Delete: resourceCloudFlareZoneDNSSECDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ds": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ds_record": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"key_tag": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"algorithm": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"digest_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"digest": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"flags": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"public_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZoneDNSSECCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	log.Printf("[INFO] Enabling DNSSEC for zone %s", zoneID)
+
+	if err := client.apiRequest("PATCH", "/zones/"+zoneID+"/dnssec", map[string]string{"status": "active"}, nil); err != nil {
+		return fmt.Errorf("Error enabling DNSSEC for zone %q: %s", zoneID, err)
+	}
+
+	d.SetId(zoneID)
+
+	// The DS record is only known once Cloudflare has generated the zone's
+	// signing key, which can take a moment after DNSSEC is enabled.
+	err := waitForState(d.Timeout(schema.TimeoutCreate), fmt.Sprintf("the DNSSEC key of zone %q", zoneID), func() (bool, error) {
+		var dnssec zoneDNSSEC
+		if err := client.apiRequest("GET", "/zones/"+zoneID+"/dnssec", nil, &dnssec); err != nil {
+			return false, err
+		}
+		return dnssec.Digest != "", nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceCloudFlareZoneDNSSECRead(d, meta)
+}
+
+func resourceCloudFlareZoneDNSSECRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	var dnssec zoneDNSSEC
+	err := client.apiRequest("GET", "/zones/"+d.Id()+"/dnssec", nil, &dnssec)
+	if isNotFound(err) {
+		log.Printf("[INFO] Zone %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding DNSSEC settings for zone %q: %s", d.Id(), err)
+	}
+
+	if dnssec.Status == "disabled" {
+		log.Printf("[INFO] DNSSEC is disabled for zone %s", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("zone_id", d.Id())
+	d.Set("status", dnssec.Status)
+	d.Set("ds_record", dnssec.DS)
+	d.Set("key_tag", dnssec.KeyTag)
+	d.Set("algorithm", dnssec.Algorithm)
+	d.Set("digest_type", dnssec.DigestType)
+	d.Set("digest", dnssec.Digest)
+	d.Set("flags", dnssec.Flags)
+	d.Set("public_key", dnssec.PublicKey)
+
+	// The DS details are only known once Cloudflare has signed the zone.
+	if dnssec.Digest != "" {
+		d.Set("ds", dsRecordValue(dnssec.KeyTag, dnssec.Algorithm, dnssec.DigestType, dnssec.Digest))
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZoneDNSSECDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	log.Printf("[INFO] Disabling DNSSEC for zone %s", d.Id())
+
+	err := client.apiRequest("PATCH", "/zones/"+d.Id()+"/dnssec", map[string]string{"status": "disabled"}, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error disabling DNSSEC for zone %q: %s", d.Id(), err)
+}
+
+// dsRecordValue formats the value of a DS record the way registrars expect it:
+// key tag, algorithm, digest type and digest, in that order.
+func dsRecordValue(keyTag int, algorithm, digestType, digest string) string {
+	return fmt.Sprintf("%d %s %s %s", keyTag, algorithm, digestType, digest)
+}
@@ -0,0 +1,50 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareZoneDNSSEC_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "cloudflare_zone_dnssec.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZoneDNSSECConfig, zoneID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone_id", zoneID),
+					resource.TestMatchResourceAttr(name, "status", regexp.MustCompile("^(active|pending)$")),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestDSRecordValue(t *testing.T) {
+	ds := dsRecordValue(2371, "13", "2", "1F248E1C2A4D7F3EE1F2B4A2BC8A0E4AAF8F5E1B3E3C1F4A5B6C7D8E9F0A1B2C")
+	expected := "2371 13 2 1F248E1C2A4D7F3EE1F2B4A2BC8A0E4AAF8F5E1B3E3C1F4A5B6C7D8E9F0A1B2C"
+	if ds != expected {
+		t.Fatalf("expected %q, got %q", expected, ds)
+	}
+}
+
+const testAccCheckCloudFlareZoneDNSSECConfig = `
+resource "cloudflare_zone_dnssec" "foobar" {
+	zone_id = "%s"
+}`
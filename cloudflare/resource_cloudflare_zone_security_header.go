@@ -0,0 +1,155 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// hstsMaxAgeLimit is the longest max-age Cloudflare accepts, one year.
+const hstsMaxAgeLimit = 31536000
+
+// zoneSecurityHeaderSetting is the security_header zone setting. Unlike most
+// zone settings, its value is an object rather than a string.
+type zoneSecurityHeaderSetting struct {
+	Value zoneSecurityHeader `json:"value"`
+}
+
+type zoneSecurityHeader struct {
+	StrictTransportSecurity strictTransportSecurity `json:"strict_transport_security"`
+}
+
+type strictTransportSecurity struct {
+	Enabled           bool `json:"enabled"`
+	MaxAge            int  `json:"max_age"`
+	IncludeSubdomains bool `json:"include_subdomains"`
+	Preload           bool `json:"preload"`
+	NoSniff           bool `json:"nosniff"`
+}
+
+func resourceCloudFlareZoneSecurityHeader() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZoneSecurityHeaderUpdate,
+		Read:   resourceCloudFlareZoneSecurityHeaderRead,
+		Update: resourceCloudFlareZoneSecurityHeaderUpdate,
+		Delete: resourceCloudFlareZoneSecurityHeaderDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"strict_transport_security": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"max_age": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validateHSTSMaxAge,
+						},
+						"include_subdomains": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"preload": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"nosniff": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZoneSecurityHeaderRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	var setting zoneSecurityHeaderSetting
+	err := client.apiRequest("GET", zoneSecurityHeaderURI(d.Id()), nil, &setting)
+	if isNotFound(err) {
+		log.Printf("[INFO] Zone %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding security header setting for zone %q: %s", d.Id(), err)
+	}
+
+	hsts := setting.Value.StrictTransportSecurity
+	d.Set("zone_id", d.Id())
+	if err := d.Set("strict_transport_security", []interface{}{map[string]interface{}{
+		"enabled":            hsts.Enabled,
+		"max_age":            hsts.MaxAge,
+		"include_subdomains": hsts.IncludeSubdomains,
+		"preload":            hsts.Preload,
+		"nosniff":            hsts.NoSniff,
+	}}); err != nil {
+		return fmt.Errorf("Error setting strict_transport_security: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZoneSecurityHeaderUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	m := d.Get("strict_transport_security").([]interface{})[0].(map[string]interface{})
+	setting := zoneSecurityHeaderSetting{Value: zoneSecurityHeader{
+		StrictTransportSecurity: strictTransportSecurity{
+			Enabled:           m["enabled"].(bool),
+			MaxAge:            m["max_age"].(int),
+			IncludeSubdomains: m["include_subdomains"].(bool),
+			Preload:           m["preload"].(bool),
+			NoSniff:           m["nosniff"].(bool),
+		},
+	}}
+
+	log.Printf("[DEBUG] CloudFlare security header setting for zone %s: %#v", zoneID, setting)
+
+	if err := client.apiRequest("PATCH", zoneSecurityHeaderURI(zoneID), setting, nil); err != nil {
+		return fmt.Errorf("Error updating security header setting for zone %q: %s", zoneID, err)
+	}
+
+	d.SetId(zoneID)
+
+	return resourceCloudFlareZoneSecurityHeaderRead(d, meta)
+}
+
+func resourceCloudFlareZoneSecurityHeaderDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	log.Printf("[INFO] Disabling HSTS for zone %s", d.Id())
+
+	err := client.apiRequest("PATCH", zoneSecurityHeaderURI(d.Id()), zoneSecurityHeaderSetting{}, nil)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("Error resetting security header setting for zone %q: %s", d.Id(), err)
+}
+
+func zoneSecurityHeaderURI(zoneID string) string {
+	return "/zones/" + zoneID + "/settings/security_header"
+}
@@ -0,0 +1,52 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareZoneSecurityHeader_Preload(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "cloudflare_zone_security_header.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZoneSecurityHeaderConfigPreload, zoneID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "strict_transport_security.0.enabled", "true"),
+					resource.TestCheckResourceAttr(name, "strict_transport_security.0.max_age", "31536000"),
+					resource.TestCheckResourceAttr(name, "strict_transport_security.0.include_subdomains", "true"),
+					resource.TestCheckResourceAttr(name, "strict_transport_security.0.preload", "true"),
+					resource.TestCheckResourceAttr(name, "strict_transport_security.0.nosniff", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCheckCloudFlareZoneSecurityHeaderConfigPreload = `
+resource "cloudflare_zone_security_header" "foobar" {
+	zone_id = "%s"
+
+	strict_transport_security {
+		enabled = true
+		max_age = 31536000
+		include_subdomains = true
+		preload = true
+		nosniff = true
+	}
+}`
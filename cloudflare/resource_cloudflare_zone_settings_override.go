@@ -0,0 +1,282 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// zoneSettingOverride describes a zone setting that
+// cloudflare_zone_settings_override manages: the values it can take, or
+// whether it is a number of seconds.
+type zoneSettingOverride struct {
+	values  []string
+	integer bool
+}
+
+var onOff = []string{"on", "off"}
+
+// zoneSettingOverrides are the zone settings that can be overridden, by
+// their ID. security_header is left to cloudflare_zone_security_header.
+var zoneSettingOverrides = map[string]zoneSettingOverride{
+	"always_online":            {values: onOff},
+	"always_use_https":         {values: onOff},
+	"automatic_https_rewrites": {values: onOff},
+	"brotli":                   {values: onOff},
+	"browser_cache_ttl":        {integer: true},
+	"browser_check":            {values: onOff},
+	"cache_level":              {values: []string{"aggressive", "basic", "simplified"}},
+	"challenge_ttl":            {integer: true},
+	"development_mode":         {values: onOff},
+	"email_obfuscation":        {values: onOff},
+	"hotlink_protection":       {values: onOff},
+	"http2":                    {values: onOff},
+	"http3":                    {values: onOff},
+	"ipv6":                     {values: onOff},
+	"min_tls_version":          {values: []string{"1.0", "1.1", "1.2", "1.3"}},
+	"opportunistic_encryption": {values: onOff},
+	"rocket_loader":            {values: onOff},
+	"security_level":           {values: []string{"off", "essentially_off", "low", "medium", "high", "under_attack"}},
+	"server_side_exclude":      {values: onOff},
+	"ssl":                      {values: []string{"off", "flexible", "full", "strict"}},
+	"tls_1_3":                  {values: []string{"on", "off", "zrt"}},
+	"websockets":               {values: onOff},
+}
+
+// zoneSettingsUpdate is the body of a request updating several zone
+// settings at once.
+type zoneSettingsUpdate struct {
+	Items []zoneSettingValue `json:"items"`
+}
+
+// zoneSettingValue is a setting in a zoneSettingsUpdate, which only takes
+// the ID and value of cloudflare.ZoneSetting.
+type zoneSettingValue struct {
+	ID    string      `json:"id"`
+	Value interface{} `json:"value"`
+}
+
+func resourceCloudFlareZoneSettingsOverride() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZoneSettingsOverrideCreate,
+		Read:   resourceCloudFlareZoneSettingsOverrideRead,
+		Update: resourceCloudFlareZoneSettingsOverrideUpdate,
+		Delete: resourceCloudFlareZoneSettingsOverrideDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Every setting is computed, so that those left out of the
+			// configuration keep whatever value they have.
+			"settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: zoneSettingsOverrideSchema(false),
+				},
+			},
+
+			// initial_settings are the values of the settings before they
+			// were overridden, which are restored on destroy.
+			"initial_settings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: zoneSettingsOverrideSchema(true),
+				},
+			},
+		},
+	}
+}
+
+func zoneSettingsOverrideSchema(computedOnly bool) map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema, len(zoneSettingOverrides))
+	for id, setting := range zoneSettingOverrides {
+		field := &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: !computedOnly,
+			Computed: true,
+		}
+		if setting.integer {
+			field.Type = schema.TypeInt
+		}
+		if !computedOnly && !setting.integer {
+			field.ValidateFunc = validateZoneSettingOverride
+		}
+		s[id] = field
+	}
+	return s
+}
+
+func resourceCloudFlareZoneSettingsOverrideCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	initial, err := client.zoneSettingOverrideValues(zoneID)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("initial_settings", []interface{}{initial}); err != nil {
+		return fmt.Errorf("Error setting initial_settings: %s", err)
+	}
+
+	// Only the settings in the configuration are set; GetOk skips the
+	// others, which are still unknown.
+	changed := map[string]interface{}{}
+	for id := range zoneSettingOverrides {
+		if v, ok := d.GetOk("settings.0." + id); ok {
+			changed[id] = v
+		}
+	}
+	if err := client.updateZoneSettings(zoneID, changed); err != nil {
+		return err
+	}
+
+	d.SetId(zoneID)
+
+	return resourceCloudFlareZoneSettingsOverrideRead(d, meta)
+}
+
+func resourceCloudFlareZoneSettingsOverrideRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	current, err := client.zoneSettingOverrideValues(d.Id())
+	if isNotFound(err) {
+		log.Printf("[INFO] Zone %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("zone_id", d.Id())
+	if err := d.Set("settings", []interface{}{current}); err != nil {
+		return fmt.Errorf("Error setting settings: %s", err)
+	}
+
+	// Imported overrides have no initial settings yet, so destroying them
+	// restores the settings they were imported with.
+	if len(d.Get("initial_settings").([]interface{})) == 0 {
+		if err := d.Set("initial_settings", []interface{}{current}); err != nil {
+			return fmt.Errorf("Error setting initial_settings: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceCloudFlareZoneSettingsOverrideUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	changed := map[string]interface{}{}
+	for id := range zoneSettingOverrides {
+		if key := "settings.0." + id; d.HasChange(key) {
+			changed[id] = d.Get(key)
+		}
+	}
+	if err := client.updateZoneSettings(d.Id(), changed); err != nil {
+		return err
+	}
+
+	return resourceCloudFlareZoneSettingsOverrideRead(d, meta)
+}
+
+func resourceCloudFlareZoneSettingsOverrideDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	initial := d.Get("initial_settings").([]interface{})
+	settings := d.Get("settings").([]interface{})
+	if len(initial) == 0 || initial[0] == nil || len(settings) == 0 || settings[0] == nil {
+		return nil
+	}
+
+	// Only the settings that differ from before are restored, as the others
+	// may not be editable on the zone's plan. Settings the zone didn't have
+	// to begin with are left alone.
+	before, after := initial[0].(map[string]interface{}), settings[0].(map[string]interface{})
+	restored := map[string]interface{}{}
+	for id := range zoneSettingOverrides {
+		if before[id] != after[id] && before[id] != "" {
+			restored[id] = before[id]
+		}
+	}
+
+	log.Printf("[INFO] Restoring initial settings of zone %s: %v", d.Id(), restored)
+
+	err := client.updateZoneSettings(d.Id(), restored)
+	if err == nil || isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func zoneSettingsURI(zoneID string) string {
+	return "/zones/" + zoneID + "/settings"
+}
+
+// zoneSettingOverrideValues returns the values of the settings of a zone
+// that can be overridden, in the form of the settings block.
+func (client *CloudFlareClient) zoneSettingOverrideValues(zoneID string) (map[string]interface{}, error) {
+	var settings []cloudflare.ZoneSetting
+	if err := client.apiRequest("GET", zoneSettingsURI(zoneID), nil, &settings); err != nil {
+		if isNotFound(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Error finding settings of zone %q: %s", zoneID, err)
+	}
+
+	values := map[string]interface{}{}
+	for _, setting := range settings {
+		override, ok := zoneSettingOverrides[setting.ID]
+		if !ok {
+			continue
+		}
+		if n, ok := setting.Value.(float64); ok && override.integer {
+			values[setting.ID] = int(n)
+		} else if s, ok := setting.Value.(string); ok && !override.integer {
+			values[setting.ID] = s
+		}
+	}
+	return values, nil
+}
+
+// updateZoneSettings sets several settings of a zone in one request.
+func (client *CloudFlareClient) updateZoneSettings(zoneID string, values map[string]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	update := zoneSettingsUpdate{}
+	for _, id := range ids {
+		update.Items = append(update.Items, zoneSettingValue{ID: id, Value: values[id]})
+	}
+
+	log.Printf("[DEBUG] CloudFlare settings update for zone %s: %v", zoneID, values)
+
+	if err := client.apiRequest("PATCH", zoneSettingsURI(zoneID), update, nil); err != nil {
+		if isNotFound(err) {
+			return err
+		}
+		return fmt.Errorf("Error updating settings of zone %q: %s", zoneID, err)
+	}
+	return nil
+}
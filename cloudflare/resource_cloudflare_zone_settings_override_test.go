@@ -0,0 +1,171 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZoneSettingsOverride_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	name := "cloudflare_zone_settings_override.foobar"
+
+	var initial map[string]interface{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZoneSettingsOverrideRestored(zoneID, &initial),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*CloudFlareClient)
+					var err error
+					if initial, err = client.zoneSettingOverrideValues(zoneID); err != nil {
+						t.Fatalf("Error finding initial zone settings: %s", err)
+					}
+				},
+				Config: fmt.Sprintf(testAccCheckCloudFlareZoneSettingsOverrideConfig, zoneID, "on", 14400),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", zoneID),
+					resource.TestCheckResourceAttr(name, "settings.0.brotli", "on"),
+					resource.TestCheckResourceAttr(name, "settings.0.browser_cache_ttl", "14400"),
+					resource.TestCheckResourceAttrSet(name, "settings.0.ssl"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZoneSettingsOverrideConfig, zoneID, "off", 7200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "settings.0.brotli", "off"),
+					resource.TestCheckResourceAttr(name, "settings.0.browser_cache_ttl", "7200"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Imported overrides take the current settings as their
+				// initial ones.
+				ImportStateVerifyIgnore: []string{"initial_settings"},
+			},
+		},
+	})
+}
+
+func TestCloudFlareZoneSettingsOverride_Restore(t *testing.T) {
+	stored := map[string]interface{}{
+		"ssl":               "flexible",
+		"brotli":            "off",
+		"browser_cache_ttl": float64(14400),
+		"security_header":   map[string]interface{}{},
+	}
+	var patched []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != zoneSettingsURI("abc") {
+			writeMockError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			var update zoneSettingsUpdate
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("invalid settings update: %s", err)
+			}
+			patched = nil
+			for _, item := range update.Items {
+				patched = append(patched, item.ID)
+				stored[item.ID] = item.Value
+			}
+			writeTestResult(w, nil)
+		case "GET":
+			ids := make([]string, 0, len(stored))
+			for id := range stored {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+
+			var settings []cloudflare.ZoneSetting
+			for _, id := range ids {
+				settings = append(settings, cloudflare.ZoneSetting{ID: id, Value: stored[id], Editable: true})
+			}
+			writeTestResult(w, settings)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareZoneSettingsOverride().Schema, map[string]interface{}{
+		"zone_id": "abc",
+		"settings": []interface{}{
+			map[string]interface{}{"ssl": "strict"},
+		},
+	})
+	if err := resourceCloudFlareZoneSettingsOverrideCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !reflect.DeepEqual(patched, []string{"ssl"}) {
+		t.Fatalf("expected only ssl to be updated, got %v", patched)
+	}
+	if d.Id() != "abc" || d.Get("settings.0.ssl") != "strict" || d.Get("settings.0.brotli") != "off" || d.Get("settings.0.browser_cache_ttl") != 14400 {
+		t.Fatalf("bad settings: id %q, %v", d.Id(), d.Get("settings"))
+	}
+	if d.Get("initial_settings.0.ssl") != "flexible" {
+		t.Fatalf("expected initial ssl to be flexible, got %v", d.Get("initial_settings.0.ssl"))
+	}
+
+	if err := resourceCloudFlareZoneSettingsOverrideDelete(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(patched, []string{"ssl"}) || stored["ssl"] != "flexible" {
+		t.Fatalf("expected only ssl to be restored to flexible, got %v: %v", patched, stored)
+	}
+}
+
+func testAccCheckCloudFlareZoneSettingsOverrideRestored(zoneID string, initial *map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*CloudFlareClient)
+
+		current, err := client.zoneSettingOverrideValues(zoneID)
+		if err != nil {
+			return err
+		}
+		for _, id := range []string{"brotli", "browser_cache_ttl"} {
+			if current[id] != (*initial)[id] {
+				return fmt.Errorf("Zone setting %s wasn't restored: %v, was %v", id, current[id], (*initial)[id])
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckCloudFlareZoneSettingsOverrideConfig = `
+resource "cloudflare_zone_settings_override" "foobar" {
+	zone_id = "%s"
+
+	settings {
+		brotli = "%s"
+		browser_cache_ttl = %d
+	}
+}`
@@ -0,0 +1,163 @@
+package cloudflare
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// zoneSubscription is a zone's billing subscription.
+type zoneSubscription struct {
+	ID        string       `json:"id,omitempty"`
+	RatePlan  zoneRatePlan `json:"rate_plan"`
+	Frequency string       `json:"frequency,omitempty"`
+	Currency  string       `json:"currency,omitempty"`
+	Price     float64      `json:"price,omitempty"`
+	State     string       `json:"state,omitempty"`
+}
+
+type zoneRatePlan struct {
+	ID string `json:"id"`
+}
+
+func resourceCloudFlareZoneSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFlareZoneSubscriptionCreate,
+		Read:   resourceCloudFlareZoneSubscriptionRead,
+		Update: resourceCloudFlareZoneSubscriptionUpdate,
+		Delete: resourceCloudFlareZoneSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"rate_plan_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateZoneRatePlan,
+			},
+
+			"frequency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSubscriptionFrequency,
+			},
+
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"price": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFlareZoneSubscriptionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+	zoneID := d.Get("zone_id").(string)
+
+	sub := zoneSubscriptionFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Zone Subscription create configuration: %#v", sub)
+
+	if err := client.saveZoneSubscription(zoneID, sub); err != nil {
+		return err
+	}
+
+	d.SetId(zoneID)
+
+	return resourceCloudFlareZoneSubscriptionRead(d, meta)
+}
+
+func resourceCloudFlareZoneSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	var sub zoneSubscription
+	err := client.apiRequest("GET", "/zones/"+d.Id()+"/subscription", nil, &sub)
+	if isNotFound(err) {
+		log.Printf("[INFO] Zone %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error finding subscription for zone %q: %s", d.Id(), err)
+	}
+
+	d.Set("zone_id", d.Id())
+	d.Set("rate_plan_id", sub.RatePlan.ID)
+	d.Set("frequency", sub.Frequency)
+	d.Set("currency", sub.Currency)
+	d.Set("price", sub.Price)
+	d.Set("state", sub.State)
+
+	return nil
+}
+
+func resourceCloudFlareZoneSubscriptionUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*CloudFlareClient)
+
+	sub := zoneSubscriptionFromResourceData(d)
+	log.Printf("[DEBUG] CloudFlare Zone Subscription update configuration: %#v", sub)
+
+	if err := client.apiRequest("PUT", "/zones/"+d.Id()+"/subscription", sub, nil); err != nil {
+		return fmt.Errorf("Error updating subscription for zone %q: %s", d.Id(), err)
+	}
+
+	return resourceCloudFlareZoneSubscriptionRead(d, meta)
+}
+
+func resourceCloudFlareZoneSubscriptionDelete(d *schema.ResourceData, meta interface{}) error {
+	// Cancelling a subscription is a billing decision we don't want to
+	// make implicitly, so the zone keeps its current plan.
+	log.Printf("[WARN] Removing subscription for zone %s from state; the zone keeps its current plan", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// saveZoneSubscription creates or replaces the subscription of a zone.
+func (client *CloudFlareClient) saveZoneSubscription(zoneID string, sub zoneSubscription) error {
+	// Every zone has a subscription, even on the free plan, but zones
+	// that have never been upgraded have no subscription ID yet.
+	var current zoneSubscription
+	if err := client.apiRequest("GET", "/zones/"+zoneID+"/subscription", nil, &current); err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error finding subscription for zone %q: %s", zoneID, err)
+	}
+
+	method := "PUT"
+	if current.ID == "" {
+		method = "POST"
+	}
+
+	if err := client.apiRequest(method, "/zones/"+zoneID+"/subscription", sub, nil); err != nil {
+		return fmt.Errorf("Error creating subscription for zone %q: %s", zoneID, err)
+	}
+	return nil
+}
+
+func zoneSubscriptionFromResourceData(d *schema.ResourceData) zoneSubscription {
+	sub := zoneSubscription{
+		RatePlan: zoneRatePlan{ID: d.Get("rate_plan_id").(string)},
+	}
+
+	if frequency, ok := d.GetOk("frequency"); ok {
+		sub.Frequency = frequency.(string)
+	}
+
+	return sub
+}
@@ -0,0 +1,53 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudFlareZoneSubscription_Upgrade(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckZoneID(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZoneSubscriptionConfig, zoneID, "free"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"cloudflare_zone_subscription.foobar", "zone_id", zoneID),
+					resource.TestCheckResourceAttr(
+						"cloudflare_zone_subscription.foobar", "rate_plan_id", "free"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZoneSubscriptionConfig, zoneID, "pro"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"cloudflare_zone_subscription.foobar", "rate_plan_id", "pro"),
+					resource.TestCheckResourceAttrSet(
+						"cloudflare_zone_subscription.foobar", "state"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "cloudflare_zone_subscription.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCheckCloudFlareZoneSubscriptionConfig = `
+resource "cloudflare_zone_subscription" "foobar" {
+	zone_id = "%s"
+	rate_plan_id = "%s"
+	frequency = "monthly"
+}`
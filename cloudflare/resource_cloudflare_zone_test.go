@@ -0,0 +1,226 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCloudFlareZone_Partial(t *testing.T) {
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	zoneName := fmt.Sprintf("terraform-%s.cfapi.net", resource.UniqueId())
+	name := "cloudflare_zone.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFlareZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckCloudFlareZoneConfigPartial, zoneName, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone", zoneName),
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttr(name, "type", "partial"),
+					resource.TestCheckResourceAttr(name, "plan", "free"),
+					resource.TestCheckResourceAttr(name, "status", "pending"),
+					resource.TestCheckResourceAttrSet(name, "verification_key"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"jump_start"},
+			},
+		},
+	})
+}
+
+func TestCloudFlareZoneCreate(t *testing.T) {
+	var subscribed string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/zones" && r.Method == "GET":
+			writeTestResult(w, []cloudflare.Zone{{ID: "1234567890", Name: "example.com"}})
+		case r.URL.Path == "/zones" && r.Method == "POST":
+			var z zone
+			if err := json.NewDecoder(r.Body).Decode(&z); err != nil {
+				t.Errorf("invalid zone: %s", err)
+			}
+			if z.Name == "example.com" {
+				writeMockError(w, http.StatusBadRequest, zoneExistsErrorCode, "example.com already exists")
+				return
+			}
+			writeTestResult(w, zone{ID: "0987654321", Name: z.Name, Type: z.Type, Plan: &cloudflare.ZonePlan{LegacyID: "free"}})
+		case r.URL.Path == "/zones/0987654321" && r.Method == "GET":
+			writeTestResult(w, zone{
+				ID:              "0987654321",
+				Name:            "example.org",
+				Account:         &zoneAccount{ID: "abc"},
+				Type:            "partial",
+				Status:          "pending",
+				VerificationKey: "284344499-1084221259",
+				Plan:            &cloudflare.ZonePlan{LegacyID: subscribed},
+			})
+		case r.URL.Path == "/zones/0987654321/subscription" && r.Method == "GET":
+			writeMockError(w, http.StatusNotFound, 1207, "Subscription not found")
+		case r.URL.Path == "/zones/0987654321/subscription" && r.Method == "POST":
+			var sub zoneSubscription
+			if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+				t.Errorf("invalid subscription: %s", err)
+			}
+			subscribed = sub.RatePlan.ID
+			writeTestResult(w, sub)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudFlareZone().Schema, map[string]interface{}{
+		"zone": "example.com",
+	})
+	err = resourceCloudFlareZoneCreate(d, client)
+	if err == nil || !strings.Contains(err.Error(), `already exists with ID "1234567890"`) {
+		t.Fatalf("expected an error naming the existing zone, got: %v", err)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceCloudFlareZone().Schema, map[string]interface{}{
+		"zone":       "example.org",
+		"account_id": "abc",
+		"type":       "partial",
+		"plan":       "pro",
+	})
+	if err := resourceCloudFlareZoneCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"plan":             "pro",
+		"type":             "partial",
+		"status":           "pending",
+		"verification_key": "284344499-1084221259",
+	}
+	for k, v := range expected {
+		if got := d.Get(k); got != v {
+			t.Fatalf("expected %s %v, got %v", k, v, got)
+		}
+	}
+}
+
+func TestCloudFlareZoneDelete(t *testing.T) {
+	for _, force := range []bool{false, true} {
+		records := []cloudflare.DNSRecord{
+			{ID: "372e67954025e0ba6aaa6d586b9e0b59", Type: "A", Name: "www.example.com", Content: "192.168.0.10"},
+			{ID: "372e67954025e0ba6aaa6d586b9e0b60", Type: "MX", Name: "example.com", Content: "mx.example.com"},
+		}
+		plan := "pro"
+		zoneDeleted := false
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/zones/1234567890/dns_records" && r.Method == "GET":
+				writeTestResult(w, records)
+			case strings.HasPrefix(r.URL.Path, "/zones/1234567890/dns_records/") && r.Method == "DELETE":
+				id := strings.TrimPrefix(r.URL.Path, "/zones/1234567890/dns_records/")
+				for i, record := range records {
+					if record.ID == id {
+						records = append(records[:i], records[i+1:]...)
+						break
+					}
+				}
+				writeTestResult(w, map[string]string{"id": id})
+			case r.URL.Path == "/zones/1234567890/subscription" && r.Method == "GET":
+				writeTestResult(w, zoneSubscription{ID: "506e3185e9c882d175a2d0cb0093d9f2", RatePlan: zoneRatePlan{ID: plan}})
+			case r.URL.Path == "/zones/1234567890/subscription" && r.Method == "PUT":
+				var sub zoneSubscription
+				if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+					t.Errorf("invalid subscription: %s", err)
+				}
+				plan = sub.RatePlan.ID
+				writeTestResult(w, sub)
+			case r.URL.Path == "/zones/1234567890" && r.Method == "DELETE":
+				if len(records) > 0 || plan != "free" {
+					t.Errorf("force_destroy %t: zone deleted with records %v on the %q plan", force, records, plan)
+				}
+				zoneDeleted = true
+				writeTestResult(w, map[string]string{"id": "1234567890"})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		client, err := testClient(ts.URL)
+		if err != nil {
+			t.Fatalf("Error building CloudFlare API: %s", err)
+		}
+
+		d := schema.TestResourceDataRaw(t, resourceCloudFlareZone().Schema, map[string]interface{}{
+			"zone":          "example.com",
+			"force_destroy": force,
+		})
+		d.SetId("1234567890")
+		err = resourceCloudFlareZoneDelete(d, client)
+		ts.Close()
+
+		if !force {
+			expected := `Zone "example.com" still has 2 DNS records (A www.example.com, MX example.com) and a "pro" plan subscription`
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Fatalf("expected an error with %q, got: %v", expected, err)
+			}
+			if zoneDeleted || len(records) != 2 || plan != "pro" {
+				t.Fatalf("expected the zone to be left as it was, got deleted %t, %d records on the %q plan", zoneDeleted, len(records), plan)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if !zoneDeleted {
+			t.Fatal("expected the zone to be deleted")
+		}
+	}
+}
+
+func testAccCheckCloudFlareZoneDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*CloudFlareClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_zone" {
+			continue
+		}
+
+		if err := client.apiRequest("GET", "/zones/"+rs.Primary.ID, nil, nil); err == nil {
+			return fmt.Errorf("Zone still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckCloudFlareZoneConfigPartial = `
+resource "cloudflare_zone" "foobar" {
+	zone = "%s"
+	account_id = "%s"
+	type = "partial"
+	plan = "free"
+}`
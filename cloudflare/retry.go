@@ -0,0 +1,93 @@
+package cloudflare
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetryStatusCodes are the responses retried unless the provider
+// configures retry_status_codes: rate limiting and transient server errors.
+var defaultRetryStatusCodes = []int{429, 500, 502, 503, 504}
+
+const (
+	defaultMaxRetries   = 3
+	maxMaxRetries       = 10
+	defaultRetryBackoff = time.Second
+)
+
+// retryTransport retries requests whose response has one of statusCodes up
+// to maxRetries times, waiting backoff before the first retry and twice as long before each one
+// after that. A Retry-After header, as sent with 429s, takes precedence.
+// Once retries run out, the last response is returned. It sits below both cloudflare-go and apiRequest so every request the
+// provider makes is retried the same way.
+//
+// POSTs aren't idempotent: a server error may come back for a request that
+// was carried out anyway, e.g. a record that was created, and sending it
+// again would create a duplicate. They are only retried on 429, which
+// Cloudflare sends before processing the request.
+type retryTransport struct {
+	base        http.RoundTripper
+	statusCodes map[int]bool
+	maxRetries  int
+	backoff     time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, statusCodes []int, maxRetries int) *retryTransport {
+	codes := make(map[int]bool, len(statusCodes))
+	for _, code := range statusCodes {
+		codes[code] = true
+	}
+
+	return &retryTransport{
+		base:        base,
+		statusCodes: codes,
+		maxRetries:  maxRetries,
+		backoff:     defaultRetryBackoff,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	wait := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || !t.retryable(req, resp) || attempt == t.maxRetries {
+			return resp, err
+		}
+
+		// The body has been sent, so it can only be retried if it can be
+		// sent again.
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			retry := *req
+			retry.Body = body
+			req = &retry
+		}
+
+		delay := wait
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+		resp.Body.Close()
+
+		log.Printf("[DEBUG] CloudFlare %s %s returned HTTP status %d, retrying in %s",
+			req.Method, req.URL.Path, resp.StatusCode, delay)
+		time.Sleep(delay)
+		wait *= 2
+	}
+}
+
+// retryable reports whether req may be sent again after getting resp.
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response) bool {
+	if !t.statusCodes[resp.StatusCode] {
+		return false
+	}
+	return req.Method != http.MethodPost || resp.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,159 @@
+package cloudflare
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		statusCodes []int
+		requests    int
+		status      int
+	}{
+		"retried": {
+			statusCodes: []int{429, 520},
+			requests:    2,
+			status:      http.StatusOK,
+		},
+		"not retried": {
+			statusCodes: []int{429},
+			requests:    1,
+			status:      520,
+		},
+	}
+
+	for name, c := range cases {
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			// The body must be sent again with every retry.
+			if body, _ := ioutil.ReadAll(r.Body); string(body) != `{"name":"www"}` {
+				t.Errorf("%s: unexpected body %q", name, body)
+			}
+
+			if requests == 1 {
+				w.WriteHeader(520)
+				return
+			}
+			fmt.Fprint(w, "{}")
+		}))
+
+		transport := newRetryTransport(http.DefaultTransport, c.statusCodes, defaultMaxRetries)
+		transport.backoff = 0
+		client := &http.Client{Transport: transport}
+
+		req, err := http.NewRequest("PUT", ts.URL, strings.NewReader(`{"name":"www"}`))
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		resp, err := client.Do(req)
+		ts.Close()
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		resp.Body.Close()
+
+		if requests != c.requests {
+			t.Fatalf("%s: expected %d requests, got %d", name, c.requests, requests)
+		}
+		if resp.StatusCode != c.status {
+			t.Fatalf("%s: expected status %d, got %d", name, c.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestRetryTransport_Post(t *testing.T) {
+	cases := map[int]int{
+		// The record may have been created, so it isn't created again.
+		http.StatusBadGateway: 1,
+		// Rate limited requests were never processed.
+		http.StatusTooManyRequests: 2,
+	}
+
+	for status, expected := range cases {
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.WriteHeader(status)
+				return
+			}
+			fmt.Fprint(w, "{}")
+		}))
+
+		transport := newRetryTransport(http.DefaultTransport, defaultRetryStatusCodes, defaultMaxRetries)
+		transport.backoff = 0
+		client := &http.Client{Transport: transport}
+
+		resp, err := client.Post(ts.URL, "application/json", strings.NewReader(`{"name":"www"}`))
+		ts.Close()
+		if err != nil {
+			t.Fatalf("%d: %s", status, err)
+		}
+		resp.Body.Close()
+
+		if requests != expected {
+			t.Fatalf("%d: expected %d requests, got %d", status, expected, requests)
+		}
+	}
+}
+
+func TestRetryTransport_GivesUp(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, defaultRetryStatusCodes, defaultMaxRetries)
+	transport.backoff = 0
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if requests != defaultMaxRetries+1 {
+		t.Fatalf("expected %d requests, got %d", defaultMaxRetries+1, requests)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the last response, got status %d", resp.StatusCode)
+	}
+}
+
+func TestRetryTransport_MaxRetries(t *testing.T) {
+	for _, maxRetries := range []int{0, defaultMaxRetries, 5} {
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+
+		transport := newRetryTransport(http.DefaultTransport, defaultRetryStatusCodes, maxRetries)
+		transport.backoff = 0
+		client := &http.Client{Transport: transport}
+
+		resp, err := client.Get(ts.URL)
+		ts.Close()
+		if err != nil {
+			t.Fatalf("%d retries: err: %s", maxRetries, err)
+		}
+		resp.Body.Close()
+
+		if requests != maxRetries+1 {
+			t.Fatalf("%d retries: expected %d requests, got %d", maxRetries, maxRetries+1, requests)
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("%d retries: expected the last response, got status %d", maxRetries, resp.StatusCode)
+		}
+	}
+}
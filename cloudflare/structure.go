@@ -0,0 +1,54 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// expandStringSet returns the members of a set of strings. The API expects
+// empty lists rather than null, so the result is never nil.
+func expandStringSet(v interface{}) []string {
+	strings := []string{}
+	if set, ok := v.(*schema.Set); ok {
+		for _, s := range set.List() {
+			strings = append(strings, s.(string))
+		}
+	}
+	return strings
+}
+
+// expandStringList returns the elements of a list of strings, in order.
+// Like expandStringSet, the result is never nil.
+func expandStringList(v interface{}) []string {
+	strings := []string{}
+	if list, ok := v.([]interface{}); ok {
+		for _, s := range list {
+			str, _ := s.(string)
+			strings = append(strings, str)
+		}
+	}
+	return strings
+}
+
+// stringsToInterfaces converts strings for use in schema.NewSet or d.Set.
+func stringsToInterfaces(strings []string) []interface{} {
+	values := make([]interface{}, 0, len(strings))
+	for _, s := range strings {
+		values = append(values, s)
+	}
+	return values
+}
+
+// parseBool parses the value of a bool in a map, which may be read as a bool
+// or as its string form.
+func parseBool(v interface{}) (bool, error) {
+	switch b := v.(type) {
+	case bool:
+		return b, nil
+	case string:
+		return strconv.ParseBool(b)
+	}
+	return false, fmt.Errorf("expected a bool, got %#v", v)
+}
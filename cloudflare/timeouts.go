@@ -0,0 +1,56 @@
+package cloudflare
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// defaultTimeout is how long resources that wait for Cloudflare to finish
+// something, such as validating a certificate, wait unless their timeouts
+// block says otherwise.
+const defaultTimeout = 20 * time.Minute
+
+// statePollInterval is how often waitForState checks on Cloudflare.
+var statePollInterval = 5 * time.Second
+
+// defaultTimeouts is the Timeouts of every resource that waits in its
+// create, update or delete, so that they can all be configured the same way.
+func defaultTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultTimeout),
+		Update: schema.DefaultTimeout(defaultTimeout),
+		Delete: schema.DefaultTimeout(defaultTimeout),
+	}
+}
+
+// waitForState calls refresh until it reports that what is described is
+// done, returns an error, or timeout passes. Pass it the timeout of the
+// operation, e.g. d.Timeout(schema.TimeoutCreate).
+func waitForState(timeout time.Duration, description string, refresh func() (bool, error)) error {
+	conf := &resource.StateChangeConf{
+		Pending:      []string{"pending"},
+		Target:       []string{"done"},
+		Timeout:      timeout,
+		PollInterval: statePollInterval,
+		Refresh: func() (interface{}, string, error) {
+			done, err := refresh()
+			if err != nil {
+				return nil, "", err
+			}
+			if done {
+				return done, "done", nil
+			}
+			return done, "pending", nil
+		},
+	}
+
+	_, err := conf.WaitForState()
+	if _, ok := err.(*resource.TimeoutError); ok {
+		return fmt.Errorf("Timed out after %s waiting for %s. Set a longer timeout in the resource's timeouts block "+
+			"if Cloudflare needs more time", timeout, description)
+	}
+	return err
+}
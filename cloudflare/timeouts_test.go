@@ -0,0 +1,47 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForState(t *testing.T) {
+	var calls int
+	err := waitForState(time.Minute, "the test", func() (bool, error) {
+		calls++
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 refresh, got %d", calls)
+	}
+}
+
+func TestWaitForState_Error(t *testing.T) {
+	err := waitForState(time.Minute, "the test", func() (bool, error) {
+		return false, fmt.Errorf("boom")
+	})
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("expected the refresh error, got %v", err)
+	}
+}
+
+func TestWaitForState_Timeout(t *testing.T) {
+	start := time.Now()
+	err := waitForState(50*time.Millisecond, "the test", func() (bool, error) {
+		return false, nil
+	})
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if !strings.HasPrefix(err.Error(), "Timed out after 50ms waiting for the test") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the wait to stop at its timeout, took %s", elapsed)
+	}
+}
@@ -3,7 +3,11 @@ package cloudflare
 import (
 	"fmt"
 	"net"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // validateRecordType ensures that the cloudflare record type is valid
@@ -39,14 +43,27 @@ func validateRecordType(t string, proxied bool) error {
 		if !proxied {
 			return nil
 		}
+	case "CAA":
+		if !proxied {
+			return nil
+		}
+	case "PTR":
+		if !proxied {
+			return nil
+		}
 	default:
 		return fmt.Errorf(
-			`Invalid type %q. Valid types are "A", "AAAA", "CNAME", "TXT", "SRV", "LOC", "MX", "NS" or "SPF"`, t)
+			`Invalid type %q. Valid types are "A", "AAAA", "CNAME", "TXT", "SRV", "LOC", "MX", "NS", "SPF", "CAA" or "PTR"`, t)
 	}
 
 	return fmt.Errorf("Type %q cannot be proxied", t)
 }
 
+// recordTypeProxiable reports whether records of type t can be proxied
+func recordTypeProxiable(t string) bool {
+	return validateRecordType(t, true) == nil
+}
+
 // validateRecordName ensures that based on supplied record type, the name content matches
 // Currently only validates A and AAAA types
 func validateRecordName(t string, value string) error {
@@ -75,3 +92,794 @@ func validateRecordName(t string, value string) error {
 
 	return nil
 }
+
+// validateZoneRatePlan ensures that the rate plan is one Cloudflare offers
+func validateZoneRatePlan(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "free", "lite", "pro", "pro_plus", "business", "enterprise",
+		"partners_free", "partners_pro", "partners_business", "partners_enterprise":
+	default:
+		errors = append(errors, fmt.Errorf("%q: unknown rate plan %q", k, v))
+	}
+	return
+}
+
+// validateSubscriptionFrequency ensures that the billing frequency is valid
+func validateSubscriptionFrequency(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "weekly", "monthly", "quarterly", "yearly":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid frequency %q. Valid frequencies are "weekly", "monthly", "quarterly" or "yearly"`, k, v))
+	}
+	return
+}
+
+// validateIPAddress ensures that the value is an IPv4 or IPv6 address
+func validateIPAddress(v interface{}, k string) (ws []string, errors []error) {
+	if net.ParseIP(v.(string)) == nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid IP address, got: %q", k, v))
+	}
+	return
+}
+
+// validateCIDR ensures that the value is an IPv4 or IPv6 network in CIDR
+// notation
+func validateCIDR(v interface{}, k string) (ws []string, errors []error) {
+	if _, _, err := net.ParseCIDR(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a network in CIDR notation, e.g. 10.0.0.0/16, got: %q", k, v))
+	}
+	return
+}
+
+// validateTLSSockaddr ensures that the value is an IP address and port, e.g.
+// 192.0.2.10:443 or [2001:db8::10]:443
+func validateTLSSockaddr(v interface{}, k string) (ws []string, errors []error) {
+	host, port, err := net.SplitHostPort(v.(string))
+	if err == nil && net.ParseIP(host) == nil {
+		err = fmt.Errorf("%q is not an IP address", host)
+	}
+	if err == nil {
+		if n, perr := strconv.Atoi(port); perr != nil || n < 1 || n > 65535 {
+			err = fmt.Errorf("%q is not a port", port)
+		}
+	}
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an IP address and port, e.g. 192.0.2.10:443, got %q: %s", k, v, err))
+	}
+	return
+}
+
+// validateSHA256Fingerprint ensures that the value is a SHA-256 hash in hex
+func validateSHA256Fingerprint(v interface{}, k string) (ws []string, errors []error) {
+	if !sha256Fingerprint.MatchString(v.(string)) {
+		errors = append(errors, fmt.Errorf("%q must be a SHA-256 hash of 64 hex digits, got: %q", k, v))
+	}
+	return
+}
+
+var sha256Fingerprint = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+
+// validateAddressMapMembershipKind ensures that an address map membership
+// binds a zone or an account
+func validateAddressMapMembershipKind(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "zone", "account":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid kind %q. Valid kinds are "zone" or "account"`, k, v))
+	}
+	return
+}
+
+// validateLogpushJobKind ensures that the logpush job kind is valid
+func validateLogpushJobKind(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "", "edge":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid kind %q. The only valid kind is "edge"`, k, v))
+	}
+	return
+}
+
+// validateLogpushJobFrequency ensures that the logpush job frequency is valid
+func validateLogpushJobFrequency(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "high", "low":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid frequency %q. Valid frequencies are "high" or "low"`, k, v))
+	}
+	return
+}
+
+// validateDLPProfileType ensures that the DLP profile type is valid
+func validateDLPProfileType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "custom", "predefined":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid type %q. Valid types are "custom" or "predefined"`, k, v))
+	}
+	return
+}
+
+// validateDLPPatternValidation ensures that the DLP pattern validation is one
+// Cloudflare supports
+func validateDLPPatternValidation(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "", "luhn":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid validation %q. The only valid validation is "luhn"`, k, v))
+	}
+	return
+}
+
+// validateDeviceServiceMode ensures that the WARP client service mode is valid
+func validateDeviceServiceMode(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "warp", "1dot1", "proxy", "posture_only", "warp_tunnel_only":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid mode %q. Valid modes are "warp", "1dot1", "proxy", "posture_only" or "warp_tunnel_only"`, k, v))
+	}
+	return
+}
+
+// validateAccessApplicationType ensures that the Access application type is
+// valid
+func validateAccessApplicationType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "self_hosted", "saas", "ssh", "vnc", "bookmark":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid type %q. Valid types are "self_hosted", "saas", "ssh", "vnc" or "bookmark"`, k, v))
+	}
+	return
+}
+
+// validateAccessSaasNameIDFormat ensures that the SAML name ID format of a
+// SaaS application is valid
+func validateAccessSaasNameIDFormat(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "email", "id":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid name ID format %q. Valid formats are "email" or "id"`, k, v))
+	}
+	return
+}
+
+// validateHTTPErrorStatusCode ensures that the value is an HTTP client or
+// server error status code
+func validateHTTPErrorStatusCode(v interface{}, k string) (ws []string, errors []error) {
+	if code := v.(int); code < 400 || code > 599 {
+		errors = append(errors, fmt.Errorf("%q must be an HTTP status code between 400 and 599, got: %d", k, code))
+	}
+	return
+}
+
+// validateMaxRetries ensures that requests are retried at most 10 times, as
+// the backoff doubles with every retry
+func validateMaxRetries(v interface{}, k string) (ws []string, errors []error) {
+	if retries := v.(int); retries < 0 || retries > maxMaxRetries {
+		errors = append(errors, fmt.Errorf("%q must be between 0 and %d, got: %d", k, maxMaxRetries, retries))
+	}
+	return
+}
+
+// validateCAATag ensures that the CAA property tag is one the CAA
+// specification defines
+func validateCAATag(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "issue", "issuewild", "iodef":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid tag %q. Valid tags are "issue", "issuewild" or "iodef"`, k, v))
+	}
+	return
+}
+
+// validateCAAFlags ensures that the CAA flags fit in their single byte
+func validateCAAFlags(v interface{}, k string) (ws []string, errors []error) {
+	if flags := v.(int); flags < 0 || flags > 255 {
+		errors = append(errors, fmt.Errorf("%q must be between 0 and 255, got: %d", k, flags))
+	}
+	return
+}
+
+// validateZoneStatus ensures that the zone status is one the API reports
+func validateZoneStatus(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "initializing", "pending", "active", "moved":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid status %q. Valid statuses are "initializing", "pending", "active" or "moved"`, k, v))
+	}
+	return
+}
+
+// validateZoneLookupType ensures that the way zone names are matched is one
+// the zones data source supports
+func validateZoneLookupType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "exact", "contains", "regex":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid lookup type %q. Valid lookup types are "exact", "contains" or "regex"`, k, v))
+	}
+	return
+}
+
+// validateZoneType ensures that the zone type is valid
+func validateZoneType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "full", "partial":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid type %q. Valid types are "full" or "partial"`, k, v))
+	}
+	return
+}
+
+// validateRateLimitMode ensures that the action of a rate limit is one
+// Cloudflare can take
+func validateRateLimitMode(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "simulate", "ban", "challenge", "js_challenge":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid mode %q. Valid modes are "simulate", "ban", "challenge" or "js_challenge"`, k, v))
+	}
+	return
+}
+
+// validateRateLimitContentType ensures that a custom rate limit response
+// has a content type Cloudflare can serve
+func validateRateLimitContentType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "text/plain", "text/xml", "application/json":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid content type %q. Valid types are "text/plain", "text/xml" or "application/json"`, k, v))
+	}
+	return
+}
+
+// validateRateLimitPeriod ensures that requests are counted over at most a
+// day, the longest period Cloudflare allows
+func validateRateLimitPeriod(v interface{}, k string) (ws []string, errors []error) {
+	if period := v.(int); period < 1 || period > 86400 {
+		errors = append(errors, fmt.Errorf("%q must be between 1 and 86400 seconds, got: %d", k, period))
+	}
+	return
+}
+
+// validateLoadBalancerMonitorType ensures that the health check of a
+// monitor is one Cloudflare can make
+func validateLoadBalancerMonitorType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "http", "https", "tcp":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid type %q. Valid types are "http", "https" or "tcp"`, k, v))
+	}
+	return
+}
+
+// validateLoadBalancerPoolOriginWeight ensures that the weight of a pool
+// origin is between 0 and 1
+func validateLoadBalancerPoolOriginWeight(v interface{}, k string) (ws []string, errors []error) {
+	if weight := v.(float64); weight < 0 || weight > 1 {
+		errors = append(errors, fmt.Errorf("%q: weight %g must be between 0 and 1", k, weight))
+	}
+	return
+}
+
+var snippetNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// validateSnippetName ensures that a snippet name only has lowercase
+// letters, digits and underscores
+func validateSnippetName(v interface{}, k string) (ws []string, errors []error) {
+	if !snippetNamePattern.MatchString(v.(string)) {
+		errors = append(errors, fmt.Errorf("%q: invalid snippet name %q. Names can only have lowercase letters, digits and underscores", k, v))
+	}
+	return
+}
+
+// validateLoadBalancerSteeringPolicy ensures that the steering policy of
+// a load balancer is one Cloudflare supports
+func validateLoadBalancerSteeringPolicy(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "off", "geo", "random", "dynamic_latency", "proximity", "least_outstanding_requests", "least_connections":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid steering policy %q. Valid policies are "off", "geo", "random", `+
+			`"dynamic_latency", "proximity", "least_outstanding_requests" or "least_connections"`, k, v))
+	}
+	return
+}
+
+// validateLoadBalancerSessionAffinity ensures that the session affinity of
+// a load balancer is one Cloudflare supports
+func validateLoadBalancerSessionAffinity(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "none", "cookie", "ip_cookie", "header":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid session affinity %q. Valid values are "none", "cookie", "ip_cookie" or "header"`, k, v))
+	}
+	return
+}
+
+// validateFirewallRuleAction ensures that the action of a firewall rule is
+// one Cloudflare supports
+func validateFirewallRuleAction(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "block", "challenge", "allow", "log", "js_challenge":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid action %q. Valid actions are "block", "challenge", "allow", "log" or "js_challenge"`, k, v))
+	}
+	return
+}
+
+// validateFirewallRulePriority ensures that the priority of a firewall rule
+// is within the range Cloudflare accepts
+func validateFirewallRulePriority(v interface{}, k string) (ws []string, errors []error) {
+	if priority := v.(int); priority < 0 || priority > 2147483647 {
+		errors = append(errors, fmt.Errorf("%q must be between 0 and 2147483647, got: %d", k, priority))
+	}
+	return
+}
+
+// validateGatewayPolicyAction ensures that the action of a Gateway policy is
+// one Cloudflare supports
+func validateGatewayPolicyAction(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "allow", "block", "safesearch", "ytrestricted", "on", "off", "scan", "noscan",
+		"isolate", "noisolate", "override", "l4_override", "egress", "audit_ssh", "resolve":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid action %q. Valid actions are "allow", "block", "safesearch", `+
+			`"ytrestricted", "on", "off", "scan", "noscan", "isolate", "noisolate", "override", "l4_override", `+
+			`"egress", "audit_ssh" or "resolve"`, k, v))
+	}
+	return
+}
+
+// validateGatewayPolicyFilter ensures that a Gateway policy applies to a
+// kind of traffic Gateway filters
+func validateGatewayPolicyFilter(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "dns", "http", "l4", "egress", "dns_resolver":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid filter %q. Valid filters are "dns", "http", "l4", "egress" or "dns_resolver"`, k, v))
+	}
+	return
+}
+
+// validateRecordTTL ensures that the TTL of a record is 1 for automatic, or
+// within the range of TTLs that any plan allows. The minimum of the plan of
+// the record's zone is only known, and checked, once the record is written.
+func validateRecordTTL(v interface{}, k string) (ws []string, errors []error) {
+	switch ttl := v.(int); {
+	case ttl < 0, ttl > 1 && ttl < enterpriseMinRecordTTL, ttl > maxRecordTTL:
+		errors = append(errors, fmt.Errorf("%q must be 1 for automatic, or between %d and %d seconds, got %d", k, enterpriseMinRecordTTL, maxRecordTTL, ttl))
+	}
+	return
+}
+
+// validateAPIBaseURL ensures that the API base URL is an absolute http or
+// https URL
+func validateAPIBaseURL(v interface{}, k string) (ws []string, errors []error) {
+	u, err := url.Parse(v.(string))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errors = append(errors, fmt.Errorf("%q must be an http or https URL, e.g. \"https://api.cloudflare.com/client/v4\", got %q", k, v))
+	}
+	return
+}
+
+var recordTagPattern = regexp.MustCompile(`^[^\s:]+(:\S+)?$`)
+
+// validateRecordTag ensures that a DNS record tag is a bare name, or a
+// name and a value separated by a colon, e.g. "team:platform"
+func validateRecordTag(v interface{}, k string) (ws []string, errors []error) {
+	if !recordTagPattern.MatchString(v.(string)) {
+		errors = append(errors, fmt.Errorf(`%q: invalid tag %q. Tags are a name or "name:value", without whitespace`, k, v))
+	}
+	return
+}
+
+// validateZoneSettingOverride ensures that the value of a zone setting is
+// one Cloudflare supports for the setting named by the last part of the key
+func validateZoneSettingOverride(v interface{}, k string) (ws []string, errors []error) {
+	id := k[strings.LastIndex(k, ".")+1:]
+	values := zoneSettingOverrides[id].values
+	for _, value := range values {
+		if v.(string) == value {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf(`%q: invalid value %q. Valid values are "%s"`, k, v, strings.Join(values, `", "`)))
+	return
+}
+
+// validatePageRuleStatus ensures that the page rule status is valid
+func validatePageRuleStatus(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "active", "disabled":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid status %q. Valid statuses are "active" or "disabled"`, k, v))
+	}
+	return
+}
+
+// validatePageRuleSSL ensures that the SSL mode of a page rule is valid
+func validatePageRuleSSL(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "off", "flexible", "full", "strict":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid SSL mode %q. Valid modes are "off", "flexible", "full" or "strict"`, k, v))
+	}
+	return
+}
+
+// validatePageRuleCacheLevel ensures that the cache level of a page rule is
+// valid
+func validatePageRuleCacheLevel(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "bypass", "basic", "simplified", "aggressive", "cache_everything":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid cache level %q. Valid levels are "bypass", "basic", "simplified", "aggressive" or "cache_everything"`, k, v))
+	}
+	return
+}
+
+// validateForwardingStatusCode ensures that a forwarding URL redirects with
+// a permanent or temporary redirect
+func validateForwardingStatusCode(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(int) {
+	case 301, 302:
+	default:
+		errors = append(errors, fmt.Errorf("%q must be 301 or 302, got: %d", k, v))
+	}
+	return
+}
+
+// validateDNSConflictStrategy ensures that the DNS conflict strategy is one
+// resolveDNSRecordConflict knows
+func validateDNSConflictStrategy(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case dnsConflictError, dnsConflictAdopt, dnsConflictRecreate:
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid strategy %q. Valid strategies are "error", "adopt" or "recreate"`, k, v))
+	}
+	return
+}
+
+// validateRegexp ensures that the value is a valid regular expression
+func validateRegexp(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := regexp.Compile(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid regular expression, got %q: %s", k, v, err))
+	}
+	return
+}
+
+// validateAccessServiceTokenDuration ensures that the service token duration
+// is a positive duration, e.g. 8760h, or "forever"
+func validateAccessServiceTokenDuration(v interface{}, k string) (ws []string, errors []error) {
+	if v.(string) == "forever" {
+		return
+	}
+	if d, err := time.ParseDuration(v.(string)); err != nil || d <= 0 {
+		errors = append(errors, fmt.Errorf(`%q must be a positive duration, e.g. "8760h", or "forever", got: %q`, k, v))
+	}
+	return
+}
+
+// validateHSTSMaxAge ensures that the HSTS max-age is between zero and the
+// one year Cloudflare allows
+func validateHSTSMaxAge(v interface{}, k string) (ws []string, errors []error) {
+	if maxAge := v.(int); maxAge < 0 || maxAge > hstsMaxAgeLimit {
+		errors = append(errors, fmt.Errorf("%q must be between 0 and %d seconds, got: %d", k, hstsMaxAgeLimit, maxAge))
+	}
+	return
+}
+
+// validateRiskLevel ensures that the risk level of a behavior is valid
+func validateRiskLevel(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "low", "medium", "high":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid risk level %q. Valid levels are "low", "medium" or "high"`, k, v))
+	}
+	return
+}
+
+// validateRiskScoreIntegrationType ensures that risk scores can be shared
+// with the integration's identity provider
+func validateRiskScoreIntegrationType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "Okta":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid integration type %q. The only valid type is "Okta"`, k, v))
+	}
+	return
+}
+
+// validateAccessCustomPageType ensures that the Access custom page type is
+// valid
+func validateAccessCustomPageType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "identity_denied", "forbidden":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid type %q. Valid types are "identity_denied" or "forbidden"`, k, v))
+	}
+	return
+}
+
+// validateNSRecordSubdomain ensures that NS records delegate a subdomain. The
+// NS records of the zone apex are managed by CloudFlare.
+func validateNSRecordSubdomain(t, subdomain string) error {
+	if t == "NS" && subdomain == "" {
+		return fmt.Errorf("NS records can't be created at the zone apex, whose name servers are managed by CloudFlare")
+	}
+	return nil
+}
+
+// validateSRVRecordName ensures that the subdomain of an SRV record starts
+// with _service._proto, and with the service and proto of its data when it
+// has a data block. Otherwise the record is created but never looked up.
+func validateSRVRecordName(t, subdomain, service, proto string) error {
+	if t != "SRV" {
+		return nil
+	}
+
+	labels := strings.SplitN(subdomain, ".", 3)
+	if len(labels) < 2 || len(labels[0]) < 2 || len(labels[1]) < 2 ||
+		!strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+		return fmt.Errorf("SRV record subdomain %q must start with _service._proto, e.g. \"_sip._tcp\"", subdomain)
+	}
+
+	if service == "" && proto == "" {
+		return nil
+	}
+	if !strings.EqualFold(labels[0], service) || !strings.EqualFold(labels[1], proto) {
+		return fmt.Errorf("SRV record subdomain %q must start with the service and proto of its data, %q",
+			subdomain, service+"."+proto)
+	}
+	return nil
+}
+
+// validateRecordPriority ensures that priority is only set on the record
+// types that use it. A priority of 0 is valid for MX and SRV records, so
+// theirs isn't required.
+func validateRecordPriority(t string, priority int) error {
+	switch t {
+	case "MX", "SRV":
+	default:
+		if priority != 0 {
+			return fmt.Errorf("%s records don't have a priority, only MX and SRV records do", t)
+		}
+	}
+	return nil
+}
+
+// validateProxiedRecordTTL ensures that records configured as proxied don't
+// set a TTL, which Cloudflare always sets to 1 (automatic) for them and
+// rejects anything else.
+func validateProxiedRecordTTL(proxied string, ttl int) error {
+	if b, _ := strconv.ParseBool(proxied); b && ttl > 1 {
+		return fmt.Errorf("ttl %d can't be set on a record with proxied = true, as Cloudflare manages the TTL of proxied records. Set ttl to 1 or remove it", ttl)
+	}
+	return nil
+}
+
+// validateDeprecatedRecordName ensures that the deprecated name argument,
+// which records ignore, names the same record as subdomain. Otherwise a
+// record meant for name would silently be written to subdomain instead,
+// usually the zone apex.
+func validateDeprecatedRecordName(name, subdomain, domain string) error {
+	switch {
+	case name == "", name == subdomain, name == recordName(subdomain, domain):
+	case name == "@" && subdomain == "":
+	default:
+		return fmt.Errorf("name %q is deprecated and ignored, so the record would be written to %q. "+
+			"Set subdomain to %q instead", name, recordName(subdomain, domain), name)
+	}
+	return nil
+}
+
+// validateCNAMETarget ensures that a CNAME record doesn't point at itself,
+// such as a CNAME at the zone apex whose value is the zone.
+func validateCNAMETarget(t, name, value string) error {
+	if t != "CNAME" {
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSuffix(value, "."), name) {
+		return fmt.Errorf("CNAME record %q points at itself", name)
+	}
+	return nil
+}
+
+// maxTXTStringLength is the longest string a TXT record can hold, in bytes.
+// Cloudflare splits longer unquoted values itself, but quoted strings are
+// kept as they are written so have to fit.
+const maxTXTStringLength = 255
+
+// validatePTRTarget ensures that a PTR record points at a hostname rather
+// than an address, which is what reverse DNS resolves from.
+func validatePTRTarget(t, value string) error {
+	if t != "PTR" {
+		return nil
+	}
+	if value == "" || net.ParseIP(value) != nil {
+		return fmt.Errorf("PTR record must point to a hostname, got: %q", value)
+	}
+	return nil
+}
+
+// validateTXTContent ensures that the value of a TXT or SPF record is valid
+// for DNS: printable ASCII, and either a single unquoted string, of any
+// length as Cloudflare splits it, or a sequence of quoted strings, with
+// quotes within strings escaped and no string longer than 255 bytes.
+func validateTXTContent(t, value string) error {
+	if t != "TXT" && t != "SPF" {
+		return nil
+	}
+
+	for i := 0; i < len(value); i++ {
+		if value[i] < 0x20 || 0x7E < value[i] {
+			return fmt.Errorf("%s record must contain printable ASCII, found %q at offset %d in %q", t, value[i:i+1], i, txtExcerpt(value[i:]))
+		}
+	}
+
+	if !strings.HasPrefix(value, `"`) {
+		if i := unescapedQuote(value, 0); i >= 0 {
+			return fmt.Errorf("%s record has an unescaped quote at offset %d in %q. Escape it as \\\" or quote every string of the record", t, i, txtExcerpt(value[i:]))
+		}
+		return nil
+	}
+
+	for i := 0; i < len(value); {
+		switch value[i] {
+		case ' ', '\t':
+			i++
+			continue
+		case '"':
+		default:
+			return fmt.Errorf("%s record must be a sequence of quoted strings, found %q at offset %d", t, txtExcerpt(value[i:]), i)
+		}
+
+		end := unescapedQuote(value, i+1)
+		if end < 0 {
+			return fmt.Errorf("%s record has an unterminated string at offset %d: %q", t, i, txtExcerpt(value[i:]))
+		}
+		if err := validateTXTStringLength(t, value[i+1:end]); err != nil {
+			return err
+		}
+		i = end + 1
+	}
+	return nil
+}
+
+// validateTXTStringLength ensures that a single string of a TXT record,
+// without its quotes, fits in 255 bytes once its escapes are decoded.
+func validateTXTStringLength(t, s string) error {
+	length := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			// \DDD is a byte given in decimal, any other escape is the
+			// character escaped.
+			if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+				i += 3
+			} else {
+				i++
+			}
+		}
+		length++
+	}
+
+	if length > maxTXTStringLength {
+		return fmt.Errorf("%s record string %q is %d bytes, longer than the %d allowed. Split it into several quoted strings, e.g. \"first part\" \"second part\"",
+			t, txtExcerpt(s), length, maxTXTStringLength)
+	}
+	return nil
+}
+
+// unescapedQuote returns the index of the first quote in s from index from
+// that isn't escaped with a backslash, or -1 if there isn't one.
+func unescapedQuote(s string, from int) int {
+	for i := from; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || '9' < s[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// txtExcerpt shortens s to the start of the part of a record an error
+// points at.
+func txtExcerpt(s string) string {
+	if len(s) > 20 {
+		return s[:20] + "..."
+	}
+	return s
+}
+
+// validateZeroTrustListType ensures that the Zero Trust list type is valid
+func validateZeroTrustListType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "SERIAL", "URL", "DOMAIN", "EMAIL", "IP":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid type %q. Valid types are "SERIAL", "URL", "DOMAIN", "EMAIL" or "IP"`, k, v))
+	}
+	return
+}
+
+// validateBoolString ensures that the value is a bool in string form:
+// "true" or "false", or "1" or "0" as HCL writes bool literals
+func validateBoolString(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "true", "false", "1", "0":
+	default:
+		errors = append(errors, fmt.Errorf("%q must be true or false, got: %q", k, v))
+	}
+	return
+}
+
+var domainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// validateDomainKeys ensures that the keys of a map are domains
+func validateDomainKeys(v interface{}, k string) (ws []string, errors []error) {
+	for domain := range v.(map[string]interface{}) {
+		if !domainPattern.MatchString(domain) {
+			errors = append(errors, fmt.Errorf("%q: key %q must be a domain, e.g. example.com", k, domain))
+		}
+	}
+	return
+}
+
+// validateDEXTestKind ensures that the DEX test kind is valid
+func validateDEXTestKind(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "http", "traceroute":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid kind %q. Valid kinds are "http" or "traceroute"`, k, v))
+	}
+	return
+}
+
+// validateTagMatch ensures that the way tags are matched is valid
+func validateTagMatch(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "any", "all":
+	default:
+		errors = append(errors, fmt.Errorf(`%q: invalid value %q. Valid values are "any" or "all"`, k, v))
+	}
+	return
+}
+
+// validateAccessPolicyDecision ensures that the decision of an Access policy
+// is valid
+func validateAccessPolicyDecision(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "allow", "deny", "non_identity", "bypass":
+	default:
+		errors = append(errors, fmt.Errorf(
+			`%q: invalid decision %q. Valid decisions are "allow", "deny", "non_identity" or "bypass"`, k, v))
+	}
+	return
+}
+
+// validateDeviceManagedNetworkType ensures that the type of a device managed
+// network is valid. TLS is the only kind of managed network.
+func validateDeviceManagedNetworkType(v interface{}, k string) (ws []string, errors []error) {
+	if v.(string) != "tls" {
+		errors = append(errors, fmt.Errorf(`%q: invalid type %q. The only valid type is "tls"`, k, v))
+	}
+	return
+}
@@ -1,6 +1,9 @@
 package cloudflare
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestValidateRecordType(t *testing.T) {
 	validTypes := map[string]bool{
@@ -13,6 +16,8 @@ func TestValidateRecordType(t *testing.T) {
 		"MX":    false,
 		"NS":    false,
 		"SPF":   false,
+		"CAA":   false,
+		"PTR":   false,
 	}
 	for k, v := range validTypes {
 		err := validateRecordType(k, v)
@@ -31,6 +36,10 @@ func TestValidateRecordType(t *testing.T) {
 		"TXT":   true,
 		"SRV":   true,
 		"SPF":   true,
+		"CAA":   true,
+		"caa":   false,
+		"PTR":   true,
+		"ptr":   false,
 	}
 	for k, v := range invalidTypes {
 		if err := validateRecordType(k, v); err == nil {
@@ -63,3 +72,717 @@ func TestValidateRecordName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateZoneRatePlan(t *testing.T) {
+	for _, v := range []string{"free", "pro", "business", "enterprise", "partners_pro"} {
+		if _, errors := validateZoneRatePlan(v, "rate_plan_id"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid rate plan: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Pro", "premium"} {
+		if _, errors := validateZoneRatePlan(v, "rate_plan_id"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid rate plan", v)
+		}
+	}
+}
+
+func TestValidateCAATag(t *testing.T) {
+	for _, v := range []string{"issue", "issuewild", "iodef"} {
+		if _, errors := validateCAATag(v, "tag"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid CAA tag: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Issue", "issuer", "contactemail"} {
+		if _, errors := validateCAATag(v, "tag"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid CAA tag", v)
+		}
+	}
+}
+
+func TestValidateCAAFlags(t *testing.T) {
+	for _, v := range []int{0, 128, 255} {
+		if _, errors := validateCAAFlags(v, "flags"); len(errors) != 0 {
+			t.Fatalf("%d should be valid CAA flags: %v", v, errors)
+		}
+	}
+
+	for _, v := range []int{-1, 256} {
+		if _, errors := validateCAAFlags(v, "flags"); len(errors) == 0 {
+			t.Fatalf("%d should be invalid CAA flags", v)
+		}
+	}
+}
+
+func TestValidateAccessServiceTokenDuration(t *testing.T) {
+	for _, v := range []string{"8760h", "30m", "2h45m", "forever"} {
+		if _, errors := validateAccessServiceTokenDuration(v, "duration"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid duration: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "1y", "365d", "-1h", "0s", "Forever"} {
+		if _, errors := validateAccessServiceTokenDuration(v, "duration"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid duration", v)
+		}
+	}
+}
+
+func TestValidateMaxRetries(t *testing.T) {
+	for _, v := range []int{0, 3, 10} {
+		if _, errors := validateMaxRetries(v, "max_retries"); len(errors) != 0 {
+			t.Fatalf("%d should be a valid number of retries: %v", v, errors)
+		}
+	}
+
+	for _, v := range []int{-1, 11} {
+		if _, errors := validateMaxRetries(v, "max_retries"); len(errors) == 0 {
+			t.Fatalf("%d should be an invalid number of retries", v)
+		}
+	}
+}
+
+func TestValidateHSTSMaxAge(t *testing.T) {
+	for _, v := range []int{0, 86400, 31536000} {
+		if _, errors := validateHSTSMaxAge(v, "max_age"); len(errors) != 0 {
+			t.Fatalf("%d should be a valid max-age: %v", v, errors)
+		}
+	}
+
+	for _, v := range []int{-1, 31536001, 63072000} {
+		if _, errors := validateHSTSMaxAge(v, "max_age"); len(errors) == 0 {
+			t.Fatalf("%d should be an invalid max-age", v)
+		}
+	}
+}
+
+func TestValidateZoneStatus(t *testing.T) {
+	for _, v := range []string{"initializing", "pending", "active", "moved"} {
+		if _, errors := validateZoneStatus(v, "status"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid zone status: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Active", "paused"} {
+		if _, errors := validateZoneStatus(v, "status"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid zone status", v)
+		}
+	}
+}
+
+func TestValidateRegexp(t *testing.T) {
+	for _, v := range []string{"", "^example\\.com$", "(com|org)$"} {
+		if _, errors := validateRegexp(v, "name_regex"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid regular expression: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"(", "[a-", "*"} {
+		if _, errors := validateRegexp(v, "name_regex"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid regular expression", v)
+		}
+	}
+}
+
+func TestValidateRiskLevel(t *testing.T) {
+	for _, v := range []string{"low", "medium", "high"} {
+		if _, errors := validateRiskLevel(v, "risk_level"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid risk level: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "High", "critical"} {
+		if _, errors := validateRiskLevel(v, "risk_level"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid risk level", v)
+		}
+	}
+}
+
+func TestValidateRiskScoreIntegrationType(t *testing.T) {
+	if _, errors := validateRiskScoreIntegrationType("Okta", "integration_type"); len(errors) != 0 {
+		t.Fatalf("%q should be a valid integration type: %v", "Okta", errors)
+	}
+
+	for _, v := range []string{"", "okta", "Azure"} {
+		if _, errors := validateRiskScoreIntegrationType(v, "integration_type"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid integration type", v)
+		}
+	}
+}
+
+func TestValidatePTRTarget(t *testing.T) {
+	for _, v := range []string{"host.example.com", "host.example.com."} {
+		if err := validatePTRTarget("PTR", v); err != nil {
+			t.Fatalf("%q should be a valid PTR target: %s", v, err)
+		}
+	}
+
+	for _, v := range []string{"", "192.0.2.10", "2001:db8::10"} {
+		if err := validatePTRTarget("PTR", v); err == nil {
+			t.Fatalf("%q should be an invalid PTR target", v)
+		}
+	}
+
+	if err := validatePTRTarget("A", "192.0.2.10"); err != nil {
+		t.Fatalf("other records should be valid: %s", err)
+	}
+}
+
+func TestValidateNSRecordSubdomain(t *testing.T) {
+	if err := validateNSRecordSubdomain("NS", ""); err == nil {
+		t.Fatal("NS records at the zone apex should be rejected")
+	}
+	if err := validateNSRecordSubdomain("NS", "sub"); err != nil {
+		t.Fatalf("NS records delegating a subdomain should be valid: %s", err)
+	}
+	if err := validateNSRecordSubdomain("A", ""); err != nil {
+		t.Fatalf("other records at the zone apex should be valid: %s", err)
+	}
+}
+
+func TestValidateSRVRecordName(t *testing.T) {
+	cases := []struct {
+		Type      string
+		Subdomain string
+		Service   string
+		Proto     string
+		Valid     bool
+	}{
+		{"SRV", "_sip._tcp.terraform", "_sip", "_tcp", true},
+		{"SRV", "_sip._tcp", "_sip", "_tcp", true},
+		{"SRV", "_SIP._TCP.terraform", "_sip", "_tcp", true},
+		{"SRV", "_sip._tcp.terraform", "", "", true},
+		{"SRV", "sip.tcp.terraform", "", "", false},
+		{"SRV", "_sip.terraform", "", "", false},
+		{"SRV", "_._tcp", "", "", false},
+		{"SRV", "", "", "", false},
+		{"SRV", "_tcp._sip.terraform", "_sip", "_tcp", false},
+		{"SRV", "_xmpp._tcp.terraform", "_sip", "_tcp", false},
+		{"SRV", "_sip._tcp.terraform", "sip", "tcp", false},
+		{"A", "terraform", "", "", true},
+	}
+
+	for _, c := range cases {
+		err := validateSRVRecordName(c.Type, c.Subdomain, c.Service, c.Proto)
+		if c.Valid && err != nil {
+			t.Fatalf("%s record %q with data %s.%s should be valid: %s", c.Type, c.Subdomain, c.Service, c.Proto, err)
+		}
+		if !c.Valid && err == nil {
+			t.Fatalf("%s record %q with data %s.%s should be invalid", c.Type, c.Subdomain, c.Service, c.Proto)
+		}
+	}
+}
+
+func TestValidateRecordPriority(t *testing.T) {
+	cases := []struct {
+		Type     string
+		Value    string
+		Priority int
+		Valid    bool
+	}{
+		{"MX", "mx.example.com", 10, true},
+		{"MX", "mx.example.com", 0, true},
+		{"MX", ".", 0, true},
+		{"SRV", "5 5060 sip.example.com", 10, true},
+		{"SRV", "5 5060 sip.example.com", 0, true},
+		{"A", "192.168.0.10", 0, true},
+		{"A", "192.168.0.10", 10, false},
+		{"CNAME", "example.com", 10, false},
+	}
+
+	for _, c := range cases {
+		err := validateRecordPriority(c.Type, c.Priority)
+		if c.Valid && err != nil {
+			t.Fatalf("%s %q with priority %d should be valid: %s", c.Type, c.Value, c.Priority, err)
+		}
+		if !c.Valid && err == nil {
+			t.Fatalf("%s %q with priority %d should be invalid", c.Type, c.Value, c.Priority)
+		}
+	}
+}
+
+func TestValidateDeprecatedRecordName(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Subdomain string
+		Valid     bool
+	}{
+		{"", "www", true},
+		{"www", "www", true},
+		{"www.example.com", "www", true},
+		{"@", "", true},
+		{"example.com", "", true},
+		{"www", "", false},
+		{"www", "api", false},
+		{"@", "www", false},
+	}
+
+	for _, c := range cases {
+		err := validateDeprecatedRecordName(c.Name, c.Subdomain, "example.com")
+		if c.Valid && err != nil {
+			t.Fatalf("name %q with subdomain %q should be valid: %s", c.Name, c.Subdomain, err)
+		}
+		if !c.Valid && err == nil {
+			t.Fatalf("name %q with subdomain %q should be invalid", c.Name, c.Subdomain)
+		}
+	}
+}
+
+func TestValidateCNAMETarget(t *testing.T) {
+	cases := []struct {
+		Type  string
+		Name  string
+		Value string
+		Valid bool
+	}{
+		{"CNAME", "example.com", "example.com", false},
+		{"CNAME", "example.com", "Example.com.", false},
+		{"CNAME", "www.example.com", "www.example.com", false},
+		{"CNAME", "example.com", "www.example.com", true},
+		{"CNAME", "www.example.com", "example.com", true},
+		{"TXT", "example.com", "example.com", true},
+	}
+
+	for _, c := range cases {
+		err := validateCNAMETarget(c.Type, c.Name, c.Value)
+		if c.Valid && err != nil {
+			t.Fatalf("%s %q with value %q should be valid: %s", c.Type, c.Name, c.Value, err)
+		}
+		if !c.Valid && err == nil {
+			t.Fatalf("%s %q with value %q should be invalid", c.Type, c.Name, c.Value)
+		}
+	}
+}
+
+func TestValidateTXTContent(t *testing.T) {
+	long := strings.Repeat("a", maxTXTStringLength)
+
+	cases := []struct {
+		Type  string
+		Value string
+		Error string
+	}{
+		{"TXT", "v=spf1 include:_spf.example.com ~all", ""},
+		{"TXT", long, ""},
+		{"TXT", long + "a", ""},
+		// A DKIM key pasted as one string, which Cloudflare splits.
+		{"TXT", "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0B", 19), ""},
+		{"SPF", long + "a", ""},
+		{"SPF", `"` + long + `a"`, "is 256 bytes"},
+		{"TXT", `"` + long + `" "` + long + `"`, ""},
+		{"TXT", `"` + long + `" "` + long + `a"`, "is 256 bytes"},
+		{"TXT", `"` + strings.Repeat(`\"`, maxTXTStringLength) + `"`, ""},
+		{"TXT", `"` + strings.Repeat(`\065`, maxTXTStringLength+1) + `"`, "is 256 bytes"},
+		{"TXT", `"say \"hello\""`, ""},
+		{"TXT", `say \"hello\"`, ""},
+		{"TXT", `say "hello"`, "unescaped quote at offset 4"},
+		{"TXT", `"first" second`, "sequence of quoted strings"},
+		{"TXT", `"first" "second`, "unterminated string at offset 8"},
+		{"TXT", "caf\xc3\xa9", "printable ASCII, found \"\\xc3\" at offset 3"},
+		{"TXT", "tab\tseparated", "printable ASCII"},
+		{"CNAME", long + "a", ""},
+	}
+
+	for _, c := range cases {
+		err := validateTXTContent(c.Type, c.Value)
+		if c.Error == "" && err != nil {
+			t.Fatalf("%s value %q should be valid: %s", c.Type, c.Value, err)
+		}
+		if c.Error != "" && (err == nil || !strings.Contains(err.Error(), c.Error)) {
+			t.Fatalf("%s value %q should be invalid with %q, got: %v", c.Type, c.Value, c.Error, err)
+		}
+	}
+}
+
+func TestValidateCIDR(t *testing.T) {
+	for _, v := range []string{"10.0.0.0/16", "192.168.1.1/32", "2001:db8::/48"} {
+		if _, errs := validateCIDR(v, "network"); len(errs) != 0 {
+			t.Fatalf("%s should be a valid network: %v", v, errs)
+		}
+	}
+	for _, v := range []string{"10.0.0.0", "10.0.0.0/33", "example.com", ""} {
+		if _, errs := validateCIDR(v, "network"); len(errs) == 0 {
+			t.Fatalf("%s should be an invalid network", v)
+		}
+	}
+}
+
+func TestValidateTLSSockaddr(t *testing.T) {
+	for _, v := range []string{"192.0.2.10:443", "[2001:db8::10]:8443"} {
+		if _, errs := validateTLSSockaddr(v, "tls_sockaddr"); len(errs) != 0 {
+			t.Fatalf("%s should be a valid sockaddr: %v", v, errs)
+		}
+	}
+	for _, v := range []string{"192.0.2.10", "example.com:443", "192.0.2.10:0", "192.0.2.10:https", "2001:db8::10:443", ""} {
+		if _, errs := validateTLSSockaddr(v, "tls_sockaddr"); len(errs) == 0 {
+			t.Fatalf("%s should be an invalid sockaddr", v)
+		}
+	}
+}
+
+func TestValidateDomainKeys(t *testing.T) {
+	valid := map[string]interface{}{"example.com": true, "sub.example.co.uk": false, "my-zone.io": true}
+	if _, errs := validateDomainKeys(valid, "default_proxied_by_zone"); len(errs) != 0 {
+		t.Fatalf("expected the keys to be valid domains: %v", errs)
+	}
+
+	for _, domain := range []string{"example", "https://example.com", "Example.com", "-example.com", "example.com."} {
+		invalid := map[string]interface{}{domain: true}
+		if _, errs := validateDomainKeys(invalid, "default_proxied_by_zone"); len(errs) == 0 {
+			t.Fatalf("%s should be an invalid domain", domain)
+		}
+	}
+}
+
+func TestValidatePageRuleActions(t *testing.T) {
+	validators := map[string]struct {
+		Validate func(interface{}, string) ([]string, []error)
+		Valid    []interface{}
+		Invalid  []interface{}
+	}{
+		"status": {
+			Validate: validatePageRuleStatus,
+			Valid:    []interface{}{"active", "disabled"},
+			Invalid:  []interface{}{"", "paused"},
+		},
+		"ssl": {
+			Validate: validatePageRuleSSL,
+			Valid:    []interface{}{"off", "flexible", "full", "strict"},
+			Invalid:  []interface{}{"", "Full", "on"},
+		},
+		"cache_level": {
+			Validate: validatePageRuleCacheLevel,
+			Valid:    []interface{}{"bypass", "basic", "simplified", "aggressive", "cache_everything"},
+			Invalid:  []interface{}{"", "everything"},
+		},
+		"status_code": {
+			Validate: validateForwardingStatusCode,
+			Valid:    []interface{}{301, 302},
+			Invalid:  []interface{}{0, 200, 307},
+		},
+	}
+
+	for k, tc := range validators {
+		for _, v := range tc.Valid {
+			if _, errors := tc.Validate(v, k); len(errors) != 0 {
+				t.Fatalf("%v should be a valid %s: %v", v, k, errors)
+			}
+		}
+		for _, v := range tc.Invalid {
+			if _, errors := tc.Validate(v, k); len(errors) == 0 {
+				t.Fatalf("%v should be an invalid %s", v, k)
+			}
+		}
+	}
+}
+
+func TestValidateZoneType(t *testing.T) {
+	for _, v := range []string{"full", "partial"} {
+		if _, errors := validateZoneType(v, "type"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid zone type: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Full", "secondary"} {
+		if _, errors := validateZoneType(v, "type"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid zone type", v)
+		}
+	}
+}
+
+func TestValidateZoneLookupType(t *testing.T) {
+	for _, v := range []string{"exact", "contains", "regex"} {
+		if _, errors := validateZoneLookupType(v, "lookup_type"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid lookup type: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Exact", "prefix"} {
+		if _, errors := validateZoneLookupType(v, "lookup_type"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid lookup type", v)
+		}
+	}
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	validators := map[string]struct {
+		Validate func(interface{}, string) ([]string, []error)
+		Valid    []interface{}
+		Invalid  []interface{}
+	}{
+		"mode": {
+			Validate: validateRateLimitMode,
+			Valid:    []interface{}{"simulate", "ban", "challenge", "js_challenge"},
+			Invalid:  []interface{}{"", "block", "disabled"},
+		},
+		"content_type": {
+			Validate: validateRateLimitContentType,
+			Valid:    []interface{}{"text/plain", "text/xml", "application/json"},
+			Invalid:  []interface{}{"", "text/html"},
+		},
+		"period": {
+			Validate: validateRateLimitPeriod,
+			Valid:    []interface{}{1, 60, 86400},
+			Invalid:  []interface{}{0, -1, 86401},
+		},
+	}
+
+	for k, tc := range validators {
+		for _, v := range tc.Valid {
+			if _, errors := tc.Validate(v, k); len(errors) != 0 {
+				t.Fatalf("%v should be a valid %s: %v", v, k, errors)
+			}
+		}
+		for _, v := range tc.Invalid {
+			if _, errors := tc.Validate(v, k); len(errors) == 0 {
+				t.Fatalf("%v should be an invalid %s", v, k)
+			}
+		}
+	}
+}
+
+func TestValidateLoadBalancerMonitorType(t *testing.T) {
+	for _, v := range []string{"http", "https", "tcp"} {
+		if _, errors := validateLoadBalancerMonitorType(v, "type"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid monitor type: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "HTTP", "udp_icmp"} {
+		if _, errors := validateLoadBalancerMonitorType(v, "type"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid monitor type", v)
+		}
+	}
+}
+
+func TestValidateLoadBalancerPoolOriginWeight(t *testing.T) {
+	for _, v := range []float64{0, 0.5, 1} {
+		if _, errors := validateLoadBalancerPoolOriginWeight(v, "weight"); len(errors) != 0 {
+			t.Fatalf("%g should be a valid weight: %v", v, errors)
+		}
+	}
+
+	for _, v := range []float64{-0.1, 1.5, 100} {
+		if _, errors := validateLoadBalancerPoolOriginWeight(v, "weight"); len(errors) == 0 {
+			t.Fatalf("%g should be an invalid weight", v)
+		}
+	}
+}
+
+func TestValidateSnippetName(t *testing.T) {
+	for _, v := range []string{"rewrite", "add_headers_2"} {
+		if _, errors := validateSnippetName(v, "name"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid snippet name: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Rewrite", "add-headers", "main.js"} {
+		if _, errors := validateSnippetName(v, "name"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid snippet name", v)
+		}
+	}
+}
+
+func TestValidateLoadBalancerSteeringPolicy(t *testing.T) {
+	for _, v := range []string{"off", "geo", "random", "dynamic_latency", "proximity", "least_outstanding_requests", "least_connections"} {
+		if _, errors := validateLoadBalancerSteeringPolicy(v, "steering_policy"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid steering policy: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Geo", "round_robin"} {
+		if _, errors := validateLoadBalancerSteeringPolicy(v, "steering_policy"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid steering policy", v)
+		}
+	}
+}
+
+func TestValidateLoadBalancerSessionAffinity(t *testing.T) {
+	for _, v := range []string{"none", "cookie", "ip_cookie", "header"} {
+		if _, errors := validateLoadBalancerSessionAffinity(v, "session_affinity"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid session affinity: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "sticky", "Cookie"} {
+		if _, errors := validateLoadBalancerSessionAffinity(v, "session_affinity"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid session affinity", v)
+		}
+	}
+}
+
+func TestValidateFirewallRuleAction(t *testing.T) {
+	for _, v := range []string{"block", "challenge", "allow", "log", "js_challenge"} {
+		if _, errors := validateFirewallRuleAction(v, "action"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid action: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Block", "simulate", "bypass"} {
+		if _, errors := validateFirewallRuleAction(v, "action"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid action", v)
+		}
+	}
+}
+
+func TestValidateFirewallRulePriority(t *testing.T) {
+	for _, v := range []int{0, 1, 2147483647} {
+		if _, errors := validateFirewallRulePriority(v, "priority"); len(errors) != 0 {
+			t.Fatalf("%d should be a valid priority: %v", v, errors)
+		}
+	}
+
+	if _, errors := validateFirewallRulePriority(-1, "priority"); len(errors) == 0 {
+		t.Fatalf("-1 should be an invalid priority")
+	}
+}
+
+func TestValidateGatewayPolicyAction(t *testing.T) {
+	for _, v := range []string{"allow", "block", "safesearch", "isolate", "l4_override", "resolve"} {
+		if _, errors := validateGatewayPolicyAction(v, "action"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid action: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "Block", "challenge", "deny"} {
+		if _, errors := validateGatewayPolicyAction(v, "action"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid action", v)
+		}
+	}
+}
+
+func TestValidateGatewayPolicyFilter(t *testing.T) {
+	for _, v := range []string{"dns", "http", "l4", "egress", "dns_resolver"} {
+		if _, errors := validateGatewayPolicyFilter(v, "filters"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid filter: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "DNS", "tcp"} {
+		if _, errors := validateGatewayPolicyFilter(v, "filters"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid filter", v)
+		}
+	}
+}
+
+func TestValidateZoneSettingOverride(t *testing.T) {
+	valid := map[string]string{
+		"settings.0.brotli":          "on",
+		"settings.0.ssl":             "strict",
+		"settings.0.min_tls_version": "1.2",
+		"settings.0.tls_1_3":         "zrt",
+		"settings.0.security_level":  "under_attack",
+	}
+	for k, v := range valid {
+		if _, errors := validateZoneSettingOverride(v, k); len(errors) != 0 {
+			t.Fatalf("%q should be a valid value of %s: %v", v, k, errors)
+		}
+	}
+
+	invalid := map[string]string{
+		"settings.0.brotli":          "zrt",
+		"settings.0.ssl":             "on",
+		"settings.0.min_tls_version": "1.4",
+		"settings.0.cache_level":     "",
+	}
+	for k, v := range invalid {
+		if _, errors := validateZoneSettingOverride(v, k); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid value of %s", v, k)
+		}
+	}
+}
+
+func TestValidateDNSConflictStrategy(t *testing.T) {
+	for _, v := range []string{"error", "adopt", "recreate"} {
+		if _, errors := validateDNSConflictStrategy(v, "dns_conflict_strategy"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid strategy: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "overwrite", "Adopt"} {
+		if _, errors := validateDNSConflictStrategy(v, "dns_conflict_strategy"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid strategy", v)
+		}
+	}
+}
+
+func TestValidateRecordTag(t *testing.T) {
+	for _, v := range []string{"production", "team:platform", "ticket:OPS-1234", "url:https://example.com"} {
+		if _, errors := validateRecordTag(v, "tags"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid tag: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", ":platform", "team:", "team :platform", "team:plat form"} {
+		if _, errors := validateRecordTag(v, "tags"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid tag", v)
+		}
+	}
+}
+
+func TestValidateRecordTTL(t *testing.T) {
+	for _, v := range []int{0, 1, 30, 120, 3600, 86400} {
+		if _, errors := validateRecordTTL(v, "ttl"); len(errors) != 0 {
+			t.Fatalf("%d should be a valid TTL: %v", v, errors)
+		}
+	}
+
+	for _, v := range []int{-1, 2, 29, 86401} {
+		if _, errors := validateRecordTTL(v, "ttl"); len(errors) == 0 {
+			t.Fatalf("%d should be an invalid TTL", v)
+		}
+	}
+}
+
+func TestValidateProxiedRecordTTL(t *testing.T) {
+	cases := []struct {
+		Proxied string
+		TTL     int
+		Valid   bool
+	}{
+		{"true", 0, true},
+		{"true", 1, true},
+		{"true", 3600, false},
+		{"false", 3600, true},
+		{"", 3600, true},
+	}
+
+	for _, c := range cases {
+		err := validateProxiedRecordTTL(c.Proxied, c.TTL)
+		if c.Valid && err != nil {
+			t.Fatalf("proxied %q with ttl %d should be valid: %s", c.Proxied, c.TTL, err)
+		}
+		if !c.Valid && err == nil {
+			t.Fatalf("proxied %q with ttl %d should be invalid", c.Proxied, c.TTL)
+		}
+	}
+}
+
+func TestValidateAPIBaseURL(t *testing.T) {
+	for _, v := range []string{"https://api.cloudflare.com/client/v4", "http://127.0.0.1:8080", "https://api.fed.cloudflare.com/client/v4/"} {
+		if _, errors := validateAPIBaseURL(v, "api_base_url"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid API base URL: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "api.cloudflare.com/client/v4", "ftp://api.cloudflare.com", "https://"} {
+		if _, errors := validateAPIBaseURL(v, "api_base_url"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid API base URL", v)
+		}
+	}
+}
+
+func TestValidateBoolString(t *testing.T) {
+	for _, v := range []string{"true", "false", "1", "0"} {
+		if _, errors := validateBoolString(v, "proxied"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid bool: %v", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "ture", "True", "yes", "t"} {
+		if _, errors := validateBoolString(v, "proxied"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid bool", v)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// ZoneIDByName returns the ID of the zone of the given domain. It shadows
+// cloudflare-go's lookup, which lists zones on every call, so that each
+// domain is only looked up once per provider run, however many records are
+// in it. Zone IDs never change for a domain, so the cache isn't
+// invalidated; a zone deleted and recreated during a run would need a new
+// run. Failed lookups aren't cached.
+func (client *CloudFlareClient) ZoneIDByName(zoneName string) (string, error) {
+	lock := client.zoneIDLock(zoneName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	client.zoneIDsMu.Lock()
+	zoneID, ok := client.zoneIDs[zoneName]
+	client.zoneIDsMu.Unlock()
+	if ok {
+		return zoneID, nil
+	}
+
+	zoneID, err := client.lookupZoneID(zoneName)
+	if err != nil {
+		return "", err
+	}
+
+	client.zoneIDsMu.Lock()
+	defer client.zoneIDsMu.Unlock()
+	if client.zoneIDs == nil {
+		client.zoneIDs = make(map[string]string)
+	}
+	client.zoneIDs[zoneName] = zoneID
+	return zoneID, nil
+}
+
+// zoneIDLock returns the lock held while the zone of zoneName is looked up.
+// Lookups of the same domain wait for the one in progress rather than
+// listing zones again, while lookups of other domains go ahead.
+func (client *CloudFlareClient) zoneIDLock(zoneName string) *sync.Mutex {
+	client.zoneIDsMu.Lock()
+	defer client.zoneIDsMu.Unlock()
+
+	if client.zoneIDLocks == nil {
+		client.zoneIDLocks = make(map[string]*sync.Mutex)
+	}
+	lock, ok := client.zoneIDLocks[zoneName]
+	if !ok {
+		lock = &sync.Mutex{}
+		client.zoneIDLocks[zoneName] = lock
+	}
+	return lock
+}
+
+// lookupZoneID looks up the ID of the zone of the given domain.
+func (client *CloudFlareClient) lookupZoneID(zoneName string) (string, error) {
+	var zones []cloudflare.Zone
+	if err := client.apiRequest("GET", "/zones?"+url.Values{"name": {zoneName}}.Encode(), nil, &zones); err != nil {
+		return "", err
+	}
+	for _, zone := range zones {
+		if zone.Name == zoneName {
+			return zone.ID, nil
+		}
+	}
+	return "", fmt.Errorf("Zone could not be found")
+}
+
+// ZoneDetails shadows cloudflare-go's, going through apiRequest so that
+// failures carry the Ray ID of their response.
+func (client *CloudFlareClient) ZoneDetails(zoneID string) (cloudflare.Zone, error) {
+	var zone cloudflare.Zone
+	err := client.apiRequest("GET", "/zones/"+zoneID, nil, &zone)
+	return zone, err
+}
+
+// resourceZone returns the ID and name of the zone of a resource that takes
+// either a zone_id or a domain. A zone_id in the configuration or state is
+// used as is, which manages zones that the credentials can't look up by
+// name, and the zone is only read for its name when domain isn't set.
+// Otherwise the zone is looked up by domain.
+func (client *CloudFlareClient) resourceZone(d *schema.ResourceData) (string, string, error) {
+	zoneID := d.Get("zone_id").(string)
+	domain := d.Get("domain").(string)
+
+	switch {
+	case zoneID != "" && domain != "":
+		return zoneID, domain, nil
+	case zoneID != "":
+		zone, err := client.ZoneDetails(zoneID)
+		if err != nil {
+			return "", "", fmt.Errorf("Error finding zone %q: %s. Check that zone_id is the ID of a zone "+
+				"in this CloudFlare account", zoneID, err)
+		}
+		return zoneID, zone.Name, nil
+	case domain != "":
+		// A resource whose domain changed is destroyed before being
+		// created in the new zone, so make an unknown domain, usually a
+		// typo, obvious.
+		zoneID, err := client.ZoneIDByName(domain)
+		if err != nil {
+			return "", "", fmt.Errorf("Error finding zone %q: %s. Check that domain is spelled correctly "+
+				"and is a zone in this CloudFlare account", domain, err)
+		}
+		return zoneID, domain, nil
+	}
+	return "", "", fmt.Errorf("one of domain or zone_id must be set")
+}
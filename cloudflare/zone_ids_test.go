@@ -0,0 +1,124 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestZoneIDByName_Cached(t *testing.T) {
+	lookups := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		lookups[name]++
+		switch name {
+		case "example.com":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+		case "example.net":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "0987654321", "name": "example.net"}]}`)
+		default:
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": []}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	cases := []struct {
+		Domain string
+		ZoneID string
+	}{
+		{"example.com", "1234567890"},
+		{"example.net", "0987654321"},
+		{"example.com", "1234567890"},
+		{"example.net", "0987654321"},
+	}
+	for _, c := range cases {
+		zoneID, err := client.ZoneIDByName(c.Domain)
+		if err != nil {
+			t.Fatalf("%s: err: %s", c.Domain, err)
+		}
+		if zoneID != c.ZoneID {
+			t.Fatalf("%s: expected zone %s, got %s", c.Domain, c.ZoneID, zoneID)
+		}
+	}
+	if lookups["example.com"] != 1 || lookups["example.net"] != 1 {
+		t.Fatalf("expected each zone to be looked up once, got %v", lookups)
+	}
+
+	// Zones that aren't found are looked up again, e.g. once they are added.
+	for i := 0; i < 2; i++ {
+		if _, err := client.ZoneIDByName("example.org"); err == nil {
+			t.Fatal("expected an error for an unknown zone")
+		}
+	}
+	if lookups["example.org"] != 2 {
+		t.Fatalf("expected failed lookups not to be cached, got %d lookups", lookups["example.org"])
+	}
+}
+
+func TestZoneIDByName_Concurrent(t *testing.T) {
+	// example.com is only found once example.net has been looked up, so
+	// lookups of one zone must not wait for the other's.
+	var mu sync.Mutex
+	lookups := make(map[string]int)
+	released := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		mu.Lock()
+		lookups[name]++
+		mu.Unlock()
+
+		switch name {
+		case "example.com":
+			select {
+			case <-released:
+			case <-time.After(5 * time.Second):
+				writeMockError(w, http.StatusGatewayTimeout, 1000, "example.net was never looked up")
+				return
+			}
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "1234567890", "name": "example.com"}]}`)
+		case "example.net":
+			close(released)
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "0987654321", "name": "example.net"}]}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := testClient(ts.URL)
+	if err != nil {
+		t.Fatalf("Error building CloudFlare API: %s", err)
+	}
+
+	domains := []string{"example.com", "example.com", "example.com", "example.net"}
+	errs := make([]error, len(domains))
+	var wg sync.WaitGroup
+	for i, domain := range domains {
+		wg.Add(1)
+		go func(i int, domain string) {
+			defer wg.Done()
+			_, errs[i] = client.ZoneIDByName(domain)
+		}(i, domain)
+		if i == 0 {
+			// Let the lookup of example.com start first.
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("%s: err: %s", domains[i], err)
+		}
+	}
+	// Concurrent lookups of the same zone share a single request.
+	if lookups["example.com"] != 1 || lookups["example.net"] != 1 {
+		t.Fatalf("expected each zone to be looked up once, got %v", lookups)
+	}
+}
@@ -0,0 +1,9 @@
+// Package version holds the version of the provider, which is set at build
+// time with:
+//
+//	go install -ldflags "-X github.com/deliveroo/terraform-provider-cloudflare/version.ProviderVersion=1.2.3"
+package version
+
+// ProviderVersion is the version of the provider, or "dev" for builds that
+// don't set it.
+var ProviderVersion = "dev"